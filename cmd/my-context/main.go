@@ -1,16 +1,21 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/commands"
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var (
-	jsonOutput bool
+	jsonOutput      bool
+	configOverrides []string
 
 	// Version information - set via ldflags during build
 	Version   = "3.1.0"
@@ -24,6 +29,27 @@ var rootCmd = &cobra.Command{
 	Long: `my-context is a CLI tool for managing work contexts.
 Track your work sessions with notes, file associations, and timestamps.`,
 	Version: formatVersion(),
+	// Errors are printed by reportError so --json can report them as JSON
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	// Config errors fail the command (reportError prints them), except for
+	// 'config edit', which falls back to env and defaults so it can still fix
+	// a broken file
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.ValidateOverrides(configOverrides); err != nil {
+			return err
+		}
+		cfg, err := core.LoadConfig(configOverrides)
+		if err != nil {
+			if cmd.Name() == "edit" && cmd.Parent() != nil && cmd.Parent().Name() == "config" {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				return nil
+			}
+			return err
+		}
+		config.SetCurrent(cfg)
+		return nil
+	},
 }
 
 // formatVersion returns a formatted version string with build metadata
@@ -32,41 +58,98 @@ func formatVersion() string {
 }
 
 func init() {
+	// Export footers name the running version
+	output.Version = Version
+
 	// Persistent flags available to all commands
 	rootCmd.PersistentFlags().BoolVarP(&jsonOutput, "json", "j", false, "Output as JSON")
+	rootCmd.PersistentFlags().StringArrayVar(&configOverrides, "set", nil, "Override a setting for this run as key=value (see 'my-context config list')")
 
 	// Add all subcommands
 	rootCmd.AddCommand(commands.NewStartCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewStopCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewResumeCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewNoteCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewTodosCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewFileCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewTouchCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewShowCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewListCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewHistoryCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewDigestCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewTimelineCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewUICmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewExportCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewExportICSCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewArchiveCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewUnarchiveCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewGCCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewDeleteCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewTrashCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewRenameCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewMergeContextsCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewSplitCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewUndoCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewTagCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewStatusCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewLinkCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewUnlinkCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewTreeCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewUpCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewDownCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewSignalCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewHandoffCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewWatchCmd(&jsonOutput))
 	rootCmd.AddCommand(commands.NewWhichCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewConfigCmd(&jsonOutput))
+	rootCmd.AddCommand(commands.NewCompletionCmd(&jsonOutput))
+
+	// Our completion command replaces cobra's default one
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+// reportError prints an error the command didn't report itself and returns the
+// exit code. With --json every failure is printed as a JSON error envelope.
+func reportError(cmd *cobra.Command, err error) int {
+	var exitErr *output.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code // Already printed
+	}
+
+	if jsonOutput || jsonRequested(os.Args[1:]) {
+		command := strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()), " ")
+		jsonStr, _ := output.FormatJSONError(command, output.ExitUsage, err.Error())
+		fmt.Print(jsonStr)
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+	}
+	return output.ExitUsage
+}
+
+// jsonRequested reports whether --json was given. Flag parsing stops at the
+// first bad flag, so jsonOutput may not be set yet when such an error occurs.
+func jsonRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--json" || arg == "-j" || arg == "--json=true" {
+			return true
+		}
+	}
+	return false
 }
 
 func main() {
 	// Ensure context home directory exists
 	if err := core.EnsureContextHome(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: Failed to initialize context home: %v\n", err)
-		os.Exit(2)
+		os.Exit(output.ExitFailure)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		os.Exit(reportError(cmd, err))
 	}
 }
@@ -4,12 +4,13 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
 	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +19,7 @@ var (
 	archiveDryRun          bool
 	archiveCompletedBefore string
 	archiveAllStopped      bool
+	archiveYes             bool
 )
 
 func NewArchiveCmd(jsonOutput *bool) *cobra.Command {
@@ -44,17 +46,19 @@ Examples:
   my-context archive --pattern "old-*"
   my-context archive --all-stopped --dry-run
   my-context archive --completed-before 2024-01-01
-  my-context archive --pattern "temp-*" --dry-run`,
+  my-context archive --pattern "temp-*" --dry-run
+  my-context archive --pattern "temp-*" --yes --json`,
+		ValidArgsFunction: completeContexts(1, archivableContext),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if bulk mode flags are used
 			isBulkMode := archivePattern != "" || archiveDryRun || archiveCompletedBefore != "" || archiveAllStopped
 
 			if isBulkMode {
-				return runBulkArchive()
+				return runBulkArchive(*jsonOutput)
 			}
 
 			// Single context mode (original behavior)
-			return runSingleArchive(args)
+			return runSingleArchive(args, *jsonOutput)
 		},
 	}
 
@@ -63,47 +67,93 @@ Examples:
 	cmd.Flags().BoolVar(&archiveDryRun, "dry-run", false, "Show what would be archived without actually archiving")
 	cmd.Flags().StringVar(&archiveCompletedBefore, "completed-before", "", "Archive contexts completed before date (YYYY-MM-DD)")
 	cmd.Flags().BoolVar(&archiveAllStopped, "all-stopped", false, "Archive all stopped contexts")
+	cmd.Flags().BoolVarP(&archiveYes, "yes", "y", false, "Skip the bulk confirmation prompt (required with --json)")
+
+	return cmd
+}
+
+func NewUnarchiveCmd(jsonOutput *bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unarchive <context-name>",
+		Short: "Restore an archived context",
+		Long: `Clear the archived flag on a context so it shows up in default list views again.
+
+Examples:
+  my-context unarchive "ps-cli: Phase 1"
+  my-context list --archived   # find archived contexts`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts(1, archivedContext),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := resolveContext(args[0], archivedContext, *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("unarchive", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+			contextName := ctx.Name
+
+			if err := core.UnarchiveContext(contextName); err != nil {
+				if *jsonOutput {
+					return jsonError("unarchive", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"context": contextName,
+				}
+				jsonStr, err := output.FormatJSON("unarchive", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("Unarchived context: %s\n", contextName)
+			}
+
+			return nil
+		},
+	}
 
 	return cmd
 }
 
 // runSingleArchive handles single context archiving (original behavior)
-func runSingleArchive(args []string) error {
+func runSingleArchive(args []string, jsonOutput bool) error {
 	// Validate: need context name
 	if len(args) == 0 {
 		return fmt.Errorf("context name required")
 	}
 
-	contextName := args[0]
-
-	// Check if context exists
-	ctx, _, _, _, err := core.GetContext(contextName)
+	ctx, err := resolveContext(args[0], archivableContext, jsonOutput)
 	if err != nil {
-		return fmt.Errorf("context not found: %s", contextName)
-	}
-
-	// Check if already archived
-	if ctx.IsArchived {
-		return fmt.Errorf("context %q is already archived", contextName)
-	}
-
-	// Prevent archiving active context
-	activeCtx, err := core.GetActiveContext()
-	if err == nil && activeCtx != nil && activeCtx.ActiveContext != nil && *activeCtx.ActiveContext == ctx.Name {
-		return fmt.Errorf("cannot archive active context %q - stop it first with 'my-context stop'", contextName)
+		return err
 	}
+	contextName := ctx.Name
 
 	// Archive the context
 	if err := core.ArchiveContext(contextName); err != nil {
 		return fmt.Errorf("failed to archive context: %w", err)
 	}
 
+	if jsonOutput {
+		jsonStr, err := output.FormatJSON("archive", map[string]interface{}{"archived": []string{contextName}})
+		if err != nil {
+			return err
+		}
+		fmt.Print(jsonStr)
+		return nil
+	}
+
 	fmt.Printf("Archived context: %s\n", contextName)
 	return nil
 }
 
 // runBulkArchive handles bulk archiving operations
-func runBulkArchive() error {
+func runBulkArchive(jsonOutput bool) error {
 	// Step 1: Collect contexts based on flags
 	contexts, err := collectContextsForBulkArchive()
 	if err != nil {
@@ -111,33 +161,49 @@ func runBulkArchive() error {
 	}
 
 	if len(contexts) == 0 {
+		if jsonOutput {
+			return printBulkArchiveJSON(archiveDryRun, nil, nil)
+		}
 		fmt.Println("No contexts found matching the specified criteria.")
 		return nil
 	}
 
 	// Step 2: Apply safety limit
-	safetyLimit := getEnvInt("MC_BULK_LIMIT", 100)
+	safetyLimit := config.Get().BulkLimit
 	if len(contexts) > safetyLimit {
-		return fmt.Errorf("too many contexts (%d) - exceeds safety limit of %d. Use a more specific pattern or adjust MC_BULK_LIMIT", len(contexts), safetyLimit)
+		return fmt.Errorf("too many contexts (%d) - exceeds safety limit of %d. Use a more specific pattern or adjust bulk_limit (MC_BULK_LIMIT or 'my-context config set bulk_limit')", len(contexts), safetyLimit)
 	}
 
 	// Step 3: Show dry-run preview or confirmation
 	if archiveDryRun {
+		if jsonOutput {
+			return printBulkArchiveJSON(true, contextNames(contexts), nil)
+		}
 		return showBulkDryRun(contexts)
 	}
 
 	// Step 4: Get user confirmation
-	confirmed, err := promptBulkConfirmation(contexts)
-	if err != nil {
-		return fmt.Errorf("confirmation failed: %w", err)
-	}
+	if !archiveYes {
+		if jsonOutput {
+			return jsonError("archive", output.ExitUsage, "bulk archive with --json needs --yes (or --dry-run to preview)")
+		}
 
-	if !confirmed {
-		fmt.Println("Bulk archive canceled.")
-		return nil
+		confirmed, err := promptBulkConfirmation(contexts)
+		if err != nil {
+			return fmt.Errorf("confirmation failed: %w", err)
+		}
+
+		if !confirmed {
+			fmt.Println("Bulk archive canceled.")
+			return nil
+		}
 	}
 
 	// Step 5: Execute bulk archive
+	if jsonOutput {
+		archived, failed := archiveContexts(contexts)
+		return printBulkArchiveJSON(false, archived, failed)
+	}
 	return executeBulkArchive(contexts)
 }
 
@@ -283,19 +349,75 @@ func executeBulkArchive(contexts []*models.Context) error {
 		for _, errMsg := range errors {
 			fmt.Printf("  - %s\n", errMsg)
 		}
+		return partialFailure("archive", failedCount)
 	}
 
 	return nil
 }
 
-// getEnvInt reads an integer environment variable with a default value
-func getEnvInt(key string, defaultVal int) int {
-	if val := os.Getenv(key); val != "" {
-		if i, err := strconv.Atoi(val); err == nil {
-			return i
+// archiveContexts archives each context, returning the archived names and the failures by name
+func archiveContexts(contexts []*models.Context) ([]string, map[string]string) {
+	archived := []string{}
+	failed := make(map[string]string)
+	for _, ctx := range contexts {
+		if err := core.ArchiveContext(ctx.Name); err != nil {
+			failed[ctx.Name] = err.Error()
+		} else {
+			archived = append(archived, ctx.Name)
 		}
 	}
-	return defaultVal
+	return archived, failed
+}
+
+// printBulkArchiveJSON prints the result (or dry-run preview) of a bulk archive
+func printBulkArchiveJSON(dryRun bool, archived []string, failed map[string]string) error {
+	if archived == nil {
+		archived = []string{}
+	}
+	data := map[string]interface{}{
+		"dry_run":  dryRun,
+		"archived": archived,
+	}
+	if len(failed) > 0 {
+		data["failed"] = failed
+	}
+	jsonStr, err := output.FormatJSON("archive", data)
+	if err != nil {
+		return err
+	}
+	fmt.Print(jsonStr)
+	if len(failed) > 0 {
+		return partialFailure("archive", len(failed))
+	}
+	return nil
+}
+
+// contextNames returns the names of contexts in order
+func contextNames(contexts []*models.Context) []string {
+	names := make([]string, 0, len(contexts))
+	for _, ctx := range contexts {
+		names = append(names, ctx.Name)
+	}
+	return names
+}
+
+// archivableContext accepts the contexts archive can act on: stopped and not yet archived
+func archivableContext(ctx *models.Context) error {
+	if ctx.IsArchived {
+		return fmt.Errorf("context %q is already archived", ctx.Name)
+	}
+	if ctx.Status == "active" {
+		return fmt.Errorf("cannot archive active context %q - stop it first with 'my-context stop'", ctx.Name)
+	}
+	return nil
+}
+
+// archivedContext accepts the contexts unarchive can restore
+func archivedContext(ctx *models.Context) error {
+	if !ctx.IsArchived {
+		return fmt.Errorf("context %q is not archived", ctx.Name)
+	}
+	return nil
 }
 
 // MatchesPattern checks if a context name matches a glob pattern (copied from resume.go)
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewCompletionCmd(jsonOutput *bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate a shell completion script",
+		Long: `Print a completion script for your shell. Besides commands and flags it
+completes context names (resume offers only stopped contexts, archive only
+unarchived ones), tags, signal names and project names.
+
+Bash (needs the bash-completion package):
+  source <(my-context completion bash)
+  # or permanently:
+  my-context completion bash > ~/.local/share/bash-completion/completions/my-context
+
+Zsh:
+  my-context completion zsh > "${fpath[1]}/_my-context"
+  # compinit must be enabled: autoload -U compinit; compinit
+
+Fish:
+  my-context completion fish > ~/.config/fish/completions/my-context.fish
+
+PowerShell:
+  my-context completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if *jsonOutput {
+				return jsonError("completion", output.ExitUsage, "completion prints a shell script and has no JSON output")
+			}
+
+			root := cmd.Root()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(os.Stdout, true)
+			case "zsh":
+				return root.GenZshCompletion(os.Stdout)
+			case "fish":
+				return root.GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+			return fmt.Errorf("unsupported shell %q", args[0])
+		},
+	}
+
+	return cmd
+}
+
+// completeContexts completes the first n arguments with context names. When
+// check is not nil only the contexts it accepts are offered, so completion
+// matches what the command's resolveContext call allows.
+func completeContexts(n int, check func(*models.Context) error) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return contextCompletions(check, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeContextFlag completes a flag taking a context name
+func completeContextFlag(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return contextCompletions(nil, nil), cobra.ShellCompDirectiveNoFileComp
+}
+
+// contextCompletions lists context names with their status, leaving out the
+// ones already given as arguments
+func contextCompletions(check func(*models.Context) error, exclude []string) []cobra.Completion {
+	contexts, err := core.ListContexts()
+	if err != nil {
+		return nil
+	}
+
+	var completions []cobra.Completion
+	for _, ctx := range contexts {
+		if check != nil && check(ctx) != nil || containsString(exclude, ctx.Name) {
+			continue
+		}
+		description := ctx.Status
+		if ctx.IsArchived {
+			description = "archived"
+		}
+		completions = append(completions, cobra.CompletionWithDesc(ctx.Name, description))
+	}
+	return completions
+}
+
+// completeTags completes tag names used by any context, with how many use them
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	tagCounts, err := core.GetAllTags()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	tags := make([]string, 0, len(tagCounts))
+	for tag := range tagCounts {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	completions := make([]cobra.Completion, len(tags))
+	for i, tag := range tags {
+		completions[i] = cobra.CompletionWithDesc(tag, fmt.Sprintf("%d context(s)", tagCounts[tag]))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTagList completes the last entry of a comma-separated tag list
+func completeTagList(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	completions, directive := completeTags(cmd, args, toComplete)
+	comma := strings.LastIndex(toComplete, ",")
+	if comma < 0 {
+		return completions, directive
+	}
+
+	given := strings.Split(toComplete[:comma], ",")
+	var prefixed []cobra.Completion
+	for _, completion := range completions {
+		tag, _, _ := strings.Cut(completion, "\t")
+		if !containsString(given, tag) {
+			prefixed = append(prefixed, toComplete[:comma+1]+completion)
+		}
+	}
+	return prefixed, directive | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeProjects completes project names, the part of context names before ": "
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	contexts, err := core.ListContexts()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := make([]string, len(contexts))
+	for i, ctx := range contexts {
+		names[i] = ctx.Name
+	}
+
+	var completions []cobra.Completion
+	for _, project := range core.ExtractProjectMetadata(names) {
+		completions = append(completions, cobra.CompletionWithDesc(project.ProjectName, fmt.Sprintf("%d context(s)", project.ContextCount)))
+	}
+	sort.Strings(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSignals completes signal names, from the --context scope when given
+func completeSignals(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	contextName := ""
+	if flag := cmd.Flag("context"); flag != nil {
+		contextName = flag.Value.String()
+	}
+	manager, err := newSignalManager(contextName)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	signals, err := manager.ListSignals()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []cobra.Completion
+	for _, sig := range signals {
+		if containsString(args, sig.Name) {
+			continue
+		}
+		if sig.Message == "" {
+			completions = append(completions, sig.Name)
+		} else {
+			completions = append(completions, cobra.CompletionWithDesc(sig.Name, sig.Message))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// completeTagArgs completes tag add/remove: a context first, then tags. For
+// remove only the context's own tags are offered, for add the other known tags.
+func completeTagArgs(remove bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return contextCompletions(nil, nil), cobra.ShellCompDirectiveNoFileComp
+		}
+
+		contextTags, err := core.GetContextTags(core.ContextNameOrID(args[0]))
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if remove {
+			var completions []cobra.Completion
+			for _, tag := range contextTags {
+				if !containsString(args[1:], tag) {
+					completions = append(completions, tag)
+				}
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		all, directive := completeTags(cmd, args, toComplete)
+		var completions []cobra.Completion
+		for _, completion := range all {
+			tag, _, _ := strings.Cut(completion, "\t")
+			if !containsString(contextTags, tag) && !containsString(args[1:], tag) {
+				completions = append(completions, completion)
+			}
+		}
+		return completions, directive
+	}
+}
@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCmd(jsonOutput *bool) *cobra.Command {
+	var project string
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show and change settings",
+		Long: `Show and change my-context settings.
+
+Settings are read from config.yaml in the context home. A "projects" section
+overrides settings for contexts of one project (the text before the first ':'
+in the context name); the active context's project applies by default.
+Projects have no config file of their own: their overrides live only in
+that section of the home config.yaml.
+
+Precedence, highest first:
+  --set key=value flag > environment variable > project section > config.yaml > default
+
+Subcommands:
+  get   - Show one setting and where it comes from
+  set   - Store a setting in config.yaml
+  list  - Show every setting
+  edit  - Open config.yaml in $EDITOR`,
+	}
+
+	cmd.PersistentFlags().StringVar(&project, "project", "", "Project section to read or write (default: active context's project for get/list, top level for set)")
+
+	cmd.AddCommand(newConfigGetCmd(jsonOutput, &project))
+	cmd.AddCommand(newConfigSetCmd(jsonOutput, &project))
+	cmd.AddCommand(newConfigListCmd(jsonOutput, &project))
+	cmd.AddCommand(newConfigEditCmd(jsonOutput))
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+
+	return cmd
+}
+
+// configForProject returns the current config, switched to another project's section if one was given
+func configForProject(project string) *config.Config {
+	cfg := config.Current()
+	if project != "" {
+		cfg = cfg.WithProject(project)
+	}
+	return cfg
+}
+
+func newConfigGetCmd(jsonOutput *bool, project *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Show one setting and where it comes from",
+		Long: `Show the effective value of a setting and the layer it comes from
+(flag, env, project, home or default).
+
+Examples:
+  my-context config get timestamp_format
+  my-context config get bulk_limit --project ps-cli`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := configForProject(*project).Lookup(args[0])
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("config get", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("config get", value)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("%s = %s (%s)\n", value.Key, value.Value, value.Source)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd(jsonOutput *bool, project *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Store a setting in config.yaml",
+		Long: `Store a setting in config.yaml in the context home. With --project the
+setting goes into that project's section instead of the top level.
+
+Examples:
+  my-context config set timestamp_format short
+  my-context config set warn_at 30 --project ps-cli`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Current()
+			if err := cfg.Set(args[0], args[1], *project); err != nil {
+				if *jsonOutput {
+					return jsonError("config set", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"key":     args[0],
+					"value":   args[1],
+					"project": *project,
+					"file":    cfg.FilePath(),
+				}
+				jsonStr, err := output.FormatJSON("config set", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if *project != "" {
+				fmt.Printf("✓ Set %s = %s for project %s\n", args[0], args[1], *project)
+			} else {
+				fmt.Printf("✓ Set %s = %s\n", args[0], args[1])
+			}
+
+			// An environment variable or flag would still win over the stored value
+			if value, err := configForProject(*project).Lookup(args[0]); err == nil &&
+				(value.Source == config.SourceFlag || value.Source == config.SourceEnv) {
+				key, _ := config.FindKey(args[0])
+				fmt.Printf("Note: %s is currently overridden by %s (%s=%s)\n", args[0], value.Source, key.Env, value.Value)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newConfigListCmd(jsonOutput *bool, project *string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show every setting",
+		Long: `Show the effective value of every setting and the layer it comes from.
+
+Examples:
+  my-context config list
+  my-context config list --project ps-cli`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := configForProject(*project)
+			values := cfg.List()
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"file":    cfg.FilePath(),
+					"project": cfg.Project(),
+					"values":  values,
+				}
+				jsonStr, err := output.FormatJSON("config list", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Printf("Config file: %s\n", cfg.FilePath())
+			if cfg.Project() != "" {
+				fmt.Printf("Project: %s\n", cfg.Project())
+			}
+			fmt.Println()
+			for _, value := range values {
+				fmt.Printf("  %-18s %-22s (%s)\n", value.Key, value.Value, value.Source)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newConfigEditCmd(jsonOutput *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Open config.yaml in $EDITOR",
+		Long: `Open config.yaml in $VISUAL or $EDITOR (vi if neither is set). A commented
+template listing every setting is written first if the file doesn't exist.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.Path(core.GetContextHome())
+
+			if !core.FileExists(path) {
+				if err := os.WriteFile(path, []byte(config.Template()), 0o600); err != nil {
+					return fmt.Errorf("failed to create %s: %w", path, err)
+				}
+			}
+
+			editor := os.Getenv("VISUAL")
+			if editor == "" {
+				editor = os.Getenv("EDITOR")
+			}
+			if editor == "" {
+				editor = "vi"
+			}
+
+			editCmd := exec.Command(editor, path)
+			editCmd.Stdin = os.Stdin
+			editCmd.Stdout = os.Stdout
+			editCmd.Stderr = os.Stderr
+			if err := editCmd.Run(); err != nil {
+				return fmt.Errorf("editor %s failed: %w", editor, err)
+			}
+
+			// Catch mistakes now rather than on the next command
+			if _, err := config.Load(core.GetContextHome(), "", nil); err != nil {
+				if *jsonOutput {
+					return jsonError("config edit", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("config edit", map[string]interface{}{"file": path})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("✓ Saved %s\n", path)
+			}
+
+			return nil
+		},
+	}
+}
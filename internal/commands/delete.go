@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -18,24 +19,31 @@ func NewDeleteCmd(jsonOutput *bool) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete [context-name]",
 		Aliases: []string{"d"},
-		Short:   "Delete a context permanently",
-		Long: `Delete a context and all its data permanently.
+		Short:   "Delete a context (moves it to the trash)",
+		Long: `Delete a context and all its data.
 
-This removes the entire context directory from ~/.my-context/.
-The context must be stopped before deletion.
+The context directory is moved to ~/.my-context/.trash/ together with a note of
+when and why it was deleted. Use 'my-context trash restore <name>' to bring it
+back. Trashed contexts are purged automatically after the retention policy's
+trash_purge_days (default 30), or immediately with 'my-context trash empty'.
+
+The context must be stopped before deletion, and named exactly (or by its ID):
+unlike most commands, delete doesn't accept prefixes, fuzzy matches or -.
 Transition history in transitions.log is preserved.
 
 Examples:
   my-context delete "Test Context"
   my-context delete "ps-cli: Phase 1" --force
-  my-context d "Old Work"`,
+  my-context d "Old Work"
+  my-context delete "Old Work" --force --json   # --json requires --force`,
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Validate: need context name
 			if len(args) == 0 {
 				return fmt.Errorf("context name required")
 			}
 
-			contextName := args[0]
+			contextName := core.ContextNameOrID(args[0])
 
 			// Check if context exists
 			ctx, _, _, _, err := core.GetContext(contextName)
@@ -49,9 +57,12 @@ Examples:
 				return fmt.Errorf("cannot delete active context %q - stop it first with 'my-context stop'", contextName)
 			}
 
-			// Confirmation prompt (unless --force)
+			// Confirmation prompt (unless --force); JSON output can't be mixed with a prompt
+			if !force && *jsonOutput {
+				return jsonError("delete", output.ExitUsage, "delete with --json needs --force")
+			}
 			if !force {
-				fmt.Printf("⚠️  WARNING: This will permanently delete context %q and all its data.\n", contextName)
+				fmt.Printf("⚠️  WARNING: This will delete context %q and move all its data to the trash.\n", contextName)
 				fmt.Printf("Are you sure? (yes/no): ")
 
 				reader := bufio.NewReader(os.Stdin)
@@ -68,11 +79,30 @@ Examples:
 			}
 
 			// Delete the context (passing force flag and confirmed=true after prompt)
-			if err := core.DeleteContext(contextName, force, true); err != nil {
+			entry, err := core.DeleteContext(contextName, force, true)
+			if err != nil {
 				return fmt.Errorf("failed to delete context: %w", err)
 			}
 
+			// Opportunistically purge trash entries past their retention age
+			if _, err := core.PurgeExpiredTrash(); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: Could not purge expired trash: %v\n", err)
+			}
+
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("delete", map[string]interface{}{
+					"context":  contextName,
+					"trash_id": entry.ID,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
 			fmt.Printf("Deleted context: %s\n", contextName)
+			fmt.Printf("  Moved to trash as %s (restore with: my-context trash restore %q)\n", entry.ID, contextName)
 			return nil
 		},
 	}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewDigestCmd(jsonOutput *bool) *cobra.Command {
+	var since string
+	var week bool
+	var project string
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Summarize recent work across contexts",
+		Long: `Summarize what you worked on in a time window: time spent, contexts started
+and stopped, workflow state changes, notes and files, grouped by project and
+context. The output is markdown, ready to paste into a standup.
+
+Time spent is reconstructed from the transition history, so a context that was
+active across the start of the window only counts the part inside it.
+
+--since accepts today, yesterday (the default), week, a number of days (3d), a
+duration (36h), a date (2025-10-20) or an RFC 3339 time. --week is short for
+--since week: the last seven days, starting at midnight.
+
+Examples:
+  my-context digest                       # Since the start of yesterday
+  my-context digest --since today
+  my-context digest --week --project ps-cli
+  my-context digest --since 2025-10-20 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if week {
+				if cmd.Flags().Changed("since") {
+					if *jsonOutput {
+						return jsonError("digest", output.ExitUsage, "--week can't be combined with --since")
+					}
+					return fmt.Errorf("--week can't be combined with --since")
+				}
+				since = "week"
+			}
+
+			now := time.Now()
+			from, err := core.ParseSince(since, now)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("digest", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			digest, err := core.BuildDigest(from, now, project)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("digest", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("digest", digest)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Print(output.FormatDigestMarkdown(digest))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "yesterday", "Start of the window (today, yesterday, week, 3d, 36h, 2006-01-02)")
+	cmd.Flags().BoolVar(&week, "week", false, "Cover the last seven days")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Only include contexts of this project")
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+
+	return cmd
+}
@@ -2,35 +2,137 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func NewExportCmd(jsonOutput *bool) *cobra.Command {
 	var (
-		exportToPath string
-		exportAll    bool
-		exportForce  bool
-		exportAsJSON bool
+		exportToPath   string
+		exportAll      bool
+		exportCombined bool
+		exportForce    bool
+		exportFormat   string
+		exportTemplate string
+		exportTree     string
+		listTemplates  bool
 	)
 
 	cmd := &cobra.Command{
 		Use:     "export [context-name]",
 		Aliases: []string{"e"},
 		Short:   "Export context data to markdown file",
-		Long: `Export a context's notes, files, and activity to a markdown file for sharing.
+		Long: `Export a context's notes, files, and activity to a file for sharing.
+
+--format selects the file format:
+  markdown  Markdown document (default)
+  json      JSON document
+  html      Self-contained, styled HTML page
+  org       Emacs Org-mode document
+  csv       One row per event (start, note, file, touch, stop)
+  jsonl     One JSON object per event, one per line
+
+--template renders the context with a Go text/template instead. It takes a
+file path or the name of a template in ~/.my-context/templates/ (the templates
+directory of the context home) or a built-in one: standup, pr and retro. A
+template named standup.md.tmpl is used as "standup" and writes .md files. Use
+--list-templates to see what's available; docs/guides/EXPORT-TEMPLATES.md
+describes the data templates receive.
+
+--tree <root> writes a context and everything linked below it (see 'tree')
+as one nested document, with durations and note counts rolled up from each
+context's descendants and a section per child, in any format or template.
+
+--all writes one file per context into the --to directory. Add --combined to
+write every context into a single document with a table of contents instead.
+
+The global --json flag reports which files were written as JSON instead of text.
 
 Examples:
   my-context export "ps-cli: Phase 1"
   my-context export "Phase 1" --to reports/phase-1.md
+  my-context export "Phase 1" --format html
+  my-context export "Phase 1" --template standup
+  my-context export "Phase 1" --template ./release-notes.md.tmpl
   my-context export --all --to exports/
+  my-context export --all --combined --format html --to report.html
+  my-context export --tree "Sprint 3" --format html
   my-context e "Phase 1"`,
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Validate: need context name or --all flag
-			if len(args) == 0 && !exportAll {
+			if listTemplates {
+				return printTemplates(*jsonOutput)
+			}
+
+			// Validate: need context name, --all or --tree
+			if len(args) == 0 && !exportAll && exportTree == "" {
 				return fmt.Errorf("context name required or use --all to export all contexts")
 			}
+			if exportTree != "" && (len(args) > 0 || exportAll) {
+				return fmt.Errorf("--tree can't be combined with a context name or --all")
+			}
+			if exportCombined && !exportAll {
+				return fmt.Errorf("--combined requires --all")
+			}
+
+			if exportTemplate != "" && cmd.Flags().Changed("format") {
+				return fmt.Errorf("--template and --format can't be combined")
+			}
+
+			exporter, err := core.GetExporter(exportFormat, exportTemplate)
+			if err != nil {
+				return err
+			}
+			format := exporter.Name()
+
+			// Export a context hierarchy into one document
+			if exportTree != "" {
+				root, err := resolveContext(exportTree, nil, *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("export", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				exportTree = root.Name
+
+				outputPath, err := core.ExportTree(exportTree, exportToPath, exporter)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("export", output.ExitFailure, err.Error())
+					}
+					return err
+				}
+
+				if *jsonOutput {
+					return printExportJSON(format, []string{outputPath})
+				}
+
+				fmt.Printf("Exported tree %q to %s (%s)\n", exportTree, outputPath, format)
+				return nil
+			}
+
+			// Export all contexts into one document
+			if exportCombined {
+				outputPath, err := core.ExportCombined(exportToPath, exporter)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("export", output.ExitFailure, fmt.Sprintf("export failed: %v", err))
+					}
+					return fmt.Errorf("export failed: %w", err)
+				}
+
+				if *jsonOutput {
+					return printExportJSON(format, []string{outputPath})
+				}
+
+				fmt.Printf("Exported all contexts to %s (%s)\n", outputPath, format)
+				return nil
+			}
 
 			// Export all contexts
 			if exportAll {
@@ -39,15 +141,18 @@ Examples:
 					outputDir = "."
 				}
 
-				exportedPaths, err := core.ExportAllContexts(outputDir, exportAsJSON)
+				exportedPaths, err := core.ExportAllContexts(outputDir, exporter)
 				if err != nil {
+					if *jsonOutput {
+						return jsonError("export", output.ExitFailure, fmt.Sprintf("export failed: %v", err))
+					}
 					return fmt.Errorf("export failed: %w", err)
 				}
 
-				format := "markdown"
-				if exportAsJSON {
-					format = "JSON"
+				if *jsonOutput {
+					return printExportJSON(format, exportedPaths)
 				}
+
 				fmt.Printf("Exporting %d contexts to %s as %s...\n", len(exportedPaths), outputDir, format)
 				for _, path := range exportedPaths {
 					fmt.Printf("  ✓ %s\n", path)
@@ -57,26 +162,77 @@ Examples:
 			}
 
 			// Export single context
-			contextName := args[0]
+			ctx, err := resolveContext(args[0], nil, *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("export", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+			contextName := ctx.Name
 
-			outputPath, err := core.ExportContext(contextName, exportToPath, exportAsJSON)
+			outputPath, err := core.ExportContext(contextName, exportToPath, exporter)
 			if err != nil {
+				if *jsonOutput {
+					return jsonError("export", output.ExitFailure, err.Error())
+				}
 				return err
 			}
 
-			format := "markdown"
-			if exportAsJSON {
-				format = "JSON"
+			if *jsonOutput {
+				return printExportJSON(format, []string{outputPath})
 			}
+
 			fmt.Printf("Exported context %q to %s (%s)\n", contextName, outputPath, format)
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&exportToPath, "to", "", "Output file path (default: ./{context_name}.md)")
+	cmd.Flags().StringVar(&exportToPath, "to", "", "Output file path (default: ./{context_name}.{ext})")
 	cmd.Flags().BoolVar(&exportAll, "all", false, "Export all contexts to separate files")
+	cmd.Flags().StringVar(&exportTree, "tree", "", "Export this context and all of its descendants as one document")
+	cmd.Flags().BoolVar(&exportCombined, "combined", false, "With --all, write a single document with a table of contents")
 	cmd.Flags().BoolVar(&exportForce, "force", false, "Overwrite existing files without confirmation")
-	cmd.Flags().BoolVar(&exportAsJSON, "json", false, "Output as JSON instead of markdown")
+	cmd.Flags().StringVar(&exportFormat, "format", "markdown", "File format: "+strings.Join(output.ExporterNames(), ", "))
+	cmd.Flags().StringVar(&exportTemplate, "template", "", "Render with a text/template file or named template")
+	cmd.Flags().BoolVar(&listTemplates, "list-templates", false, "List user and built-in templates")
 
 	return cmd
 }
+
+// printExportJSON reports exported files in the JSON envelope
+func printExportJSON(format string, paths []string) error {
+	jsonStr, err := output.FormatJSON("export", map[string]interface{}{
+		"format": format,
+		"paths":  paths,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Print(jsonStr)
+	return nil
+}
+
+// printTemplates lists the templates export --template can use
+func printTemplates(jsonOutput bool) error {
+	templates := output.ListTemplates(core.GetContextHome())
+
+	if jsonOutput {
+		jsonStr, err := output.FormatJSON("export", map[string]interface{}{"templates": templates})
+		if err != nil {
+			return err
+		}
+		fmt.Print(jsonStr)
+		return nil
+	}
+
+	fmt.Printf("User templates: %s\n\n", filepath.Join(core.GetContextHome(), output.TemplateDir))
+	for _, t := range templates {
+		if t.Source == "user" {
+			fmt.Printf("  %-12s %s\n", t.Name, t.Path)
+		} else {
+			fmt.Printf("  %-12s (built-in)\n", t.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewExportICSCmd(jsonOutput *bool) *cobra.Command {
+	var since string
+	var project string
+	var toPath string
+
+	cmd := &cobra.Command{
+		Use:   "export-ics",
+		Short: "Export work sessions as an iCalendar file",
+		Long: `Export the time you spent in contexts as an iCalendar (.ics) file that
+calendar apps can import or subscribe to.
+
+Each interval during which a context was active, as recorded in the transition
+history, becomes an event titled with the context name. The event description
+lists the notes written during that interval, and its category is the project.
+A context that is still active ends at the time of the export.
+
+--since takes the same values as 'digest --since' (today, yesterday, week, 3d,
+36h, 2025-10-20, ...); without it every session is exported.
+
+Examples:
+  my-context export-ics                                # All sessions to my-context.ics
+  my-context export-ics --since week --to week.ics
+  my-context export-ics --since 2025-10-01 --project ps-cli`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var from time.Time
+			if since != "" {
+				var err error
+				from, err = core.ParseSince(since, time.Now())
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("export-ics", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+			}
+
+			path, events, err := core.ExportICS(from, project, toPath)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("export-ics", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("export-ics", map[string]interface{}{
+					"path":   path,
+					"events": events,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Printf("Exported %d session(s) to %s\n", events, path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only sessions from this time on (today, yesterday, week, 3d, 36h, 2006-01-02)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Only sessions of contexts in this project")
+	cmd.Flags().StringVar(&toPath, "to", "", "Output file path (default: ./"+core.DefaultICSPath+")")
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+
+	return cmd
+}
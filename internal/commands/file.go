@@ -23,9 +23,7 @@ func NewFileCmd(jsonOutput *bool) *cobra.Command {
 			state, err := core.GetActiveContext()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("file", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("file", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -33,9 +31,7 @@ func NewFileCmd(jsonOutput *bool) *cobra.Command {
 			if !state.HasActiveContext() {
 				errMsg := "No active context. Start a context with: my-context start <name>"
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("file", 1, errMsg)
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("file", output.ExitUsage, errMsg)
 				}
 				return errors.New(errMsg)
 			}
@@ -44,9 +40,7 @@ func NewFileCmd(jsonOutput *bool) *cobra.Command {
 			file, err := core.AddFile(filePath)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("file", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("file", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -59,7 +53,7 @@ func NewFileCmd(jsonOutput *bool) *cobra.Command {
 					FilePath:      file.FilePath,
 					OriginalPath:  filePath,
 				}
-				jsonStr, err := output.FormatJSON("file", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("file", data)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewGCCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		dryRun       bool
+		archiveAfter int
+		deleteAfter  int
+		purgeAfter   int
+		savePolicy   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Apply the retention policy to old contexts",
+		Long: `Apply the retention policy: archive stopped contexts that have been idle for
+a while, move long-idle archived contexts to the trash, and purge trash entries
+older than the trash retention period (default 30 days).
+
+The policy is read from the archive_after_days, delete_after_days and
+trash_purge_days config keys (see "my-context config list"). Flags override it
+for a single run, or persist it to config.yaml with --save-policy. Idle time is
+measured from the last note, file, touch or stop recorded in a context.
+
+Examples:
+  my-context gc --dry-run
+  my-context gc --archive-after 30 --delete-after 90 --save-policy
+  my-context gc --purge-after 7 --save-policy
+  my-context gc --archive-after 14`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			policy, err := core.LoadRetentionPolicy()
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("gc", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			// Flags override the stored policy
+			if cmd.Flags().Changed("archive-after") {
+				policy.ArchiveAfterDays = archiveAfter
+			}
+			if cmd.Flags().Changed("delete-after") {
+				policy.DeleteAfterDays = deleteAfter
+			}
+			if cmd.Flags().Changed("purge-after") {
+				policy.TrashPurgeDays = purgeAfter
+			}
+
+			actions, err := core.PlanGC(policy, time.Now())
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("gc", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			// Trash entries older than this are purged after the actions are applied
+			var purgeBefore time.Time
+			if policy.TrashPurgeDays > 0 {
+				purgeBefore = time.Now().Add(-time.Duration(policy.TrashPurgeDays) * 24 * time.Hour)
+			}
+
+			if dryRun {
+				var expired []*core.TrashEntry
+				if !purgeBefore.IsZero() {
+					expired, err = core.ExpiredTrash(purgeBefore)
+					if err != nil {
+						if *jsonOutput {
+							return jsonError("gc", output.ExitFailure, err.Error())
+						}
+						return err
+					}
+				}
+				if savePolicy && !*jsonOutput {
+					fmt.Println("DRY RUN: retention policy not saved")
+				}
+				return outputGCDryRun(policy, actions, expired, jsonOutput)
+			}
+
+			if savePolicy {
+				if err := core.SaveRetentionPolicy(policy); err != nil {
+					if *jsonOutput {
+						return jsonError("gc", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				if !*jsonOutput {
+					fmt.Printf("Saved retention policy to %s\n", config.Path(core.GetContextHome()))
+				}
+			}
+
+			applied, failures := core.ApplyGC(actions)
+
+			// Purge trash after applying, using the effective (possibly overridden) policy
+			var purged []*core.TrashEntry
+			if !purgeBefore.IsZero() {
+				purged, err = core.EmptyTrash(purgeBefore)
+				if err != nil {
+					failures[".trash"] = err
+				}
+			}
+
+			return outputGCResult(policy, applied, purged, failures, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be archived, trashed or purged without changing anything (--save-policy is ignored)")
+	cmd.Flags().IntVar(&archiveAfter, "archive-after", 0, "Archive stopped contexts idle for this many days (0 disables)")
+	cmd.Flags().IntVar(&deleteAfter, "delete-after", 0, "Move archived contexts idle for this many days to trash (0 disables)")
+	cmd.Flags().IntVar(&purgeAfter, "purge-after", core.DefaultTrashPurgeDays, "Permanently remove trash entries older than this many days (0 disables)")
+	cmd.Flags().BoolVar(&savePolicy, "save-policy", false, "Persist --archive-after/--delete-after/--purge-after as the retention policy")
+
+	return cmd
+}
+
+// describePolicy returns a one-line summary of a retention policy
+func describePolicy(policy *core.RetentionPolicy) string {
+	archive := "never"
+	if policy.ArchiveAfterDays > 0 {
+		archive = fmt.Sprintf("after %d days", policy.ArchiveAfterDays)
+	}
+	trash := "never"
+	if policy.DeleteAfterDays > 0 {
+		trash = fmt.Sprintf("after %d days", policy.DeleteAfterDays)
+	}
+	purge := "never"
+	if policy.TrashPurgeDays > 0 {
+		purge = fmt.Sprintf("after %d days", policy.TrashPurgeDays)
+	}
+	return fmt.Sprintf("archive stopped: %s, trash archived: %s, purge trash: %s", archive, trash, purge)
+}
+
+// outputGCDryRun displays what gc would do without doing it
+func outputGCDryRun(policy *core.RetentionPolicy, actions []core.GCAction, expired []*core.TrashEntry, jsonOutput *bool) error {
+	if *jsonOutput {
+		if actions == nil {
+			actions = []core.GCAction{}
+		}
+		if expired == nil {
+			expired = []*core.TrashEntry{}
+		}
+		data := map[string]interface{}{
+			"policy":  policy,
+			"dry_run": true,
+			"actions": actions,
+			"purged":  expired,
+		}
+		jsonStr, err := output.FormatJSON("gc", data)
+		if err != nil {
+			return err
+		}
+		fmt.Print(jsonStr)
+		return nil
+	}
+
+	fmt.Printf("Retention policy: %s\n\n", describePolicy(policy))
+
+	if len(actions) == 0 && len(expired) == 0 {
+		fmt.Println("DRY RUN: Nothing to clean up.")
+		return nil
+	}
+
+	if len(actions) > 0 {
+		fmt.Printf("DRY RUN: Would apply %d actions:\n", len(actions))
+		for i, action := range actions {
+			fmt.Printf("  %d. %-7s %s (idle %d days, last activity: %s)\n",
+				i+1, action.Action, action.ContextName, action.IdleDays, action.LastActivity.Format("2006-01-02"))
+		}
+	}
+	if len(expired) > 0 {
+		fmt.Printf("DRY RUN: Would purge %d expired trash entries:\n", len(expired))
+		for _, entry := range expired {
+			fmt.Printf("  - %s (deleted %s)\n", entry.ContextName, entry.DeletedAt.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+// outputGCResult displays the outcome of a gc run
+func outputGCResult(policy *core.RetentionPolicy, applied []core.GCAction, purged []*core.TrashEntry, failures map[string]error, jsonOutput *bool) error {
+	if *jsonOutput {
+		if applied == nil {
+			applied = []core.GCAction{}
+		}
+		if purged == nil {
+			purged = []*core.TrashEntry{}
+		}
+		errs := make(map[string]string, len(failures))
+		for name, err := range failures {
+			errs[name] = err.Error()
+		}
+		data := map[string]interface{}{
+			"policy":  policy,
+			"dry_run": false,
+			"actions": applied,
+			"purged":  purged,
+			"errors":  errs,
+		}
+		jsonStr, err := output.FormatJSON("gc", data)
+		if err != nil {
+			return err
+		}
+		fmt.Print(jsonStr)
+		if len(failures) > 0 {
+			return partialFailure("gc", len(failures))
+		}
+		return nil
+	}
+
+	fmt.Printf("Retention policy: %s\n\n", describePolicy(policy))
+
+	if len(applied) == 0 && len(purged) == 0 && len(failures) == 0 {
+		fmt.Println("Nothing to clean up.")
+		return nil
+	}
+
+	for _, action := range applied {
+		switch action.Action {
+		case core.GCActionArchive:
+			fmt.Printf("✅ Archived: %s (idle %d days)\n", action.ContextName, action.IdleDays)
+		case core.GCActionTrash:
+			fmt.Printf("🗑️  Trashed: %s (idle %d days)\n", action.ContextName, action.IdleDays)
+		}
+	}
+	if len(purged) > 0 {
+		fmt.Printf("🔥 Purged %d expired trash entries\n", len(purged))
+	}
+	for name, err := range failures {
+		fmt.Printf("❌ Failed: %s (%v)\n", name, err)
+	}
+
+	fmt.Printf("\nGC complete: %d successful, %d failed\n", len(applied), len(failures))
+	if len(failures) > 0 {
+		return partialFailure("gc", len(failures))
+	}
+	return nil
+}
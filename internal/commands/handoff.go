@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewHandoffCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		to        string
+		from      string
+		summary   string
+		questions []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "handoff <context> --to <team>",
+		Short: "Hand a context off to another team",
+		Long: `Hand a context off to another team.
+
+The context is stopped, a structured HANDOFF note is added (summary, open
+questions and associated files), the handoff is recorded in meta.json and the
+signal handoff-<team>-<context> is raised. The receiving team runs
+'handoff accept' to resume the context.
+
+Examples:
+  my-context handoff "final-completion" --to deb-sanity -m "Sprint 004 done, please verify the .deb"
+  my-context handoff "final-completion" --to deb-sanity -q "Does install work on 22.04?" -q "Any lint noise?"
+  my-context signal wait 'handoff-deb-sanity-*'    # receiving side`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == "" {
+				from = os.Getenv("USER")
+			}
+
+			contextName, err := resolveContextName(args[0], *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("handoff", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			result, err := core.HandoffContext(contextName, core.HandoffOptions{
+				To:        to,
+				From:      from,
+				Summary:   summary,
+				Questions: questions,
+			})
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("handoff", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("handoff", result)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if result.WasActive {
+				fmt.Printf("Stopped context: %s\n", result.Context)
+			}
+			fmt.Printf("✓ Handed off \"%s\" → %s\n", result.Context, result.Handoff.To)
+			if n := len(result.Handoff.Questions); n > 0 {
+				fmt.Printf("  Open questions: %d\n", n)
+			}
+			if n := len(result.Handoff.Files); n > 0 {
+				fmt.Printf("  Files: %d\n", n)
+			}
+			fmt.Printf("  Signal raised: %s\n", result.Handoff.Signal)
+			fmt.Printf("  Receiver accepts with: my-context handoff accept \"%s\"\n", result.Context)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Team receiving the context (required)")
+	cmd.Flags().StringVar(&from, "from", "", "Team or person handing off (default: $USER)")
+	cmd.Flags().StringVarP(&summary, "message", "m", "", "Summary of where things stand")
+	cmd.Flags().StringArrayVarP(&questions, "question", "q", nil, "Open question for the receiving team (repeatable)")
+
+	cmd.AddCommand(newHandoffAcceptCmd(jsonOutput))
+
+	return cmd
+}
+
+func newHandoffAcceptCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		team string
+		by   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "accept [context]",
+		Short: "Accept a handoff and resume the context",
+		Long: `Accept a pending handoff: the context is resumed (stopping any other active
+context), the handoff is marked accepted in meta.json, a HANDOFF ACCEPTED note is
+added, the handoff signal is cleared and handoff-accepted-<context> is raised so
+the sending team knows.
+
+Without a context name, the single pending handoff (optionally addressed to
+--team) is accepted.
+
+Examples:
+  my-context handoff accept "final-completion"
+  my-context handoff accept --team deb-sanity`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if by == "" {
+				by = os.Getenv("USER")
+			}
+
+			var contextName string
+			if len(args) > 0 {
+				name, err := resolveContextName(args[0], *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("handoff accept", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				contextName = name
+			} else {
+				pending, err := core.PendingHandoffs(team)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("handoff accept", output.ExitFailure, err.Error())
+					}
+					return err
+				}
+
+				switch len(pending) {
+				case 0:
+					errMsg := "No pending handoffs"
+					if team != "" {
+						errMsg = fmt.Sprintf("No pending handoffs for %s", team)
+					}
+					if *jsonOutput {
+						return jsonError("handoff accept", output.ExitUsage, errMsg)
+					}
+					return fmt.Errorf("%s", errMsg)
+				case 1:
+					contextName = pending[0].Name
+				default:
+					names := make([]string, 0, len(pending))
+					for _, ctx := range pending {
+						names = append(names, fmt.Sprintf("%q (to %s)", ctx.Name, ctx.Metadata.Handoff.To))
+					}
+					errMsg := fmt.Sprintf("Several handoffs are pending, name one: %s", strings.Join(names, ", "))
+					if *jsonOutput {
+						return jsonError("handoff accept", output.ExitUsage, errMsg)
+					}
+					return fmt.Errorf("%s", errMsg)
+				}
+			}
+
+			result, err := core.AcceptHandoff(contextName, by)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("handoff accept", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("handoff accept", result)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if result.Superseded != "" {
+				fmt.Printf("Stopped context: %s\n", result.Superseded)
+			}
+			h := result.Handoff
+			fmt.Printf("✓ Accepted handoff of \"%s\" from %s\n", result.Context, h.From)
+			if h.Summary != "" {
+				fmt.Printf("  Summary: %s\n", h.Summary)
+			}
+			for _, q := range h.Questions {
+				fmt.Printf("  ? %s\n", q)
+			}
+			fmt.Printf("  Resumed context: %s\n", result.Context)
+			fmt.Printf("  Signal raised: %s\n", result.AckSignal)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&team, "team", "", "Only consider handoffs addressed to this team")
+	cmd.Flags().StringVar(&by, "by", "", "Who is accepting (default: $USER)")
+
+	return cmd
+}
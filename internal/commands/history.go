@@ -13,26 +13,33 @@ func NewHistoryCmd(jsonOutput *bool) *cobra.Command {
 		Use:     "history",
 		Aliases: []string{"h"},
 		Short:   "Show context transition history",
-		Long:    `Display the chronological history of all context transitions.`,
-		Args:    cobra.NoArgs,
+		Long: `Display the chronological history of all context transitions.
+
+With --json the output also includes signal events (see 'signal history'), so
+handoffs can be reconstructed from a single timeline.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get all transitions
 			transitions, err := core.GetTransitions()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("history", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("history", output.ExitFailure, err.Error())
 				}
 				return err
 			}
 
 			// Output
 			if *jsonOutput {
+				signalEvents, err := collectSignalHistory("")
+				if err != nil {
+					return jsonError("history", output.ExitFailure, err.Error())
+				}
+
 				data := output.HistoryData{
-					Transitions: transitions,
+					Transitions:  transitions,
+					SignalEvents: signalEvents,
 				}
-				jsonStr, err := output.FormatJSON("history", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("history", data)
 				if err != nil {
 					return err
 				}
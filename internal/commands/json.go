@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// jsonError prints a JSON error envelope and returns an error that makes the
+// process exit with code without printing the error again
+func jsonError(command string, code int, message string) error {
+	jsonStr, _ := output.FormatJSONError(command, code, message)
+	fmt.Print(jsonStr)
+	return &output.ExitError{Code: code, Message: message}
+}
+
+// partialFailure returns an error that makes a command which has already
+// printed its result exit with ExitFailure because some of its actions failed
+func partialFailure(command string, failed int) error {
+	return &output.ExitError{Code: output.ExitFailure, Message: fmt.Sprintf("%s: %d failed", command, failed)}
+}
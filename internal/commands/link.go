@@ -18,36 +18,31 @@ This is useful for organizing contexts hierarchically (e.g., sprint -> task -> s
 Examples:
   my-context link "API bugfix" "Sprint 3"
   my-context link "Unit tests" "API bugfix"`,
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeContexts(2, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			childName := args[0]
-			parentName := args[1]
-
-			// Verify both contexts exist
-			if _, err := core.LoadContext(childName); err != nil {
+			// Resolve both contexts, which may be given by prefix or fuzzy match
+			child, err := resolveContext(args[0], nil, *jsonOutput)
+			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("link", 1, fmt.Sprintf("child context %q not found", childName))
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("link", output.ExitUsage, fmt.Sprintf("child context: %v", err))
 				}
-				return fmt.Errorf("child context %q not found", childName)
+				return fmt.Errorf("child context: %w", err)
 			}
 
-			if _, err := core.LoadContext(parentName); err != nil {
+			parent, err := resolveContext(args[1], nil, *jsonOutput)
+			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("link", 1, fmt.Sprintf("parent context %q not found", parentName))
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("link", output.ExitUsage, fmt.Sprintf("parent context: %v", err))
 				}
-				return fmt.Errorf("parent context %q not found", parentName)
+				return fmt.Errorf("parent context: %w", err)
 			}
+			childName, parentName := child.Name, parent.Name
 
 			// Prevent circular dependencies
 			if childName == parentName {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("link", 1, "cannot link a context to itself")
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("link", output.ExitUsage, "cannot link a context to itself")
 				}
 				return fmt.Errorf("cannot link a context to itself")
 			}
@@ -55,9 +50,7 @@ Examples:
 			// Set parent relationship
 			if err := core.SetParent(childName, parentName); err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("link", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("link", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -68,7 +61,7 @@ Examples:
 					"child":  childName,
 					"parent": parentName,
 				}
-				jsonStr, err := output.FormatJSON("link", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("link", data)
 				if err != nil {
 					return err
 				}
@@ -92,26 +85,22 @@ func NewUnlinkCmd(jsonOutput *bool) *cobra.Command {
 
 Examples:
   my-context unlink "API bugfix"`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			contextName := args[0]
-
-			// Verify context exists
-			if _, err := core.LoadContext(contextName); err != nil {
+			ctx, err := resolveContext(args[0], nil, *jsonOutput)
+			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("unlink", 1, fmt.Sprintf("context %q not found", contextName))
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("unlink", output.ExitUsage, err.Error())
 				}
-				return fmt.Errorf("context %q not found", contextName)
+				return err
 			}
+			contextName := ctx.Name
 
 			// Remove parent relationship
 			if err := core.ClearParent(contextName); err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("unlink", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("unlink", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -121,7 +110,7 @@ Examples:
 				data := map[string]interface{}{
 					"context": contextName,
 				}
-				jsonStr, err := output.FormatJSON("unlink", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("unlink", data)
 				if err != nil {
 					return err
 				}
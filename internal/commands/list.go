@@ -60,6 +60,21 @@ func filterByTag(contexts []*models.Context, tagFilter string) []*models.Context
 	return filtered
 }
 
+// filterByState filters contexts by workflow state
+func filterByState(contexts []*models.Context, stateFilter string) []*models.Context {
+	var filtered []*models.Context
+	for _, ctx := range contexts {
+		state, err := core.GetContextState(ctx.Name)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(state, stateFilter) {
+			filtered = append(filtered, ctx)
+		}
+	}
+	return filtered
+}
+
 // filterByArchiveStatus filters contexts by archive status
 func filterByArchiveStatus(contexts []*models.Context, showArchived, activeOnly bool) []*models.Context {
 	if showArchived {
@@ -94,7 +109,7 @@ func filterByActive(contexts []*models.Context, activeContextName string) []*mod
 }
 
 // applyFilters applies all filters to the context list
-func applyFilters(contexts []*models.Context, projectFilter, searchTerm, tagFilter string, showArchived, activeOnly bool, activeContextName string) []*models.Context {
+func applyFilters(contexts []*models.Context, projectFilter, searchTerm, tagFilter, stateFilter string, showArchived, activeOnly bool, activeContextName string) []*models.Context {
 	if projectFilter != "" {
 		contexts = filterByProject(contexts, projectFilter)
 	}
@@ -104,6 +119,9 @@ func applyFilters(contexts []*models.Context, projectFilter, searchTerm, tagFilt
 	if tagFilter != "" {
 		contexts = filterByTag(contexts, tagFilter)
 	}
+	if stateFilter != "" {
+		contexts = filterByState(contexts, stateFilter)
+	}
 	contexts = filterByArchiveStatus(contexts, showArchived, activeOnly)
 	if activeOnly {
 		contexts = filterByActive(contexts, activeContextName)
@@ -118,12 +136,14 @@ func buildContextSummaries(contexts []*models.Context) []*output.ContextSummary
 		notesLines, _ := core.ReadLog(core.GetNotesLogPath(ctx.Name))
 		filesLines, _ := core.ReadLog(core.GetFilesLogPath(ctx.Name))
 		touchesLines, _ := core.ReadLog(core.GetTouchLogPath(ctx.Name))
+		state, _ := core.GetContextState(ctx.Name)
 
 		summary := &output.ContextSummary{
 			Name:            ctx.Name,
 			StartTime:       ctx.StartTime,
 			EndTime:         ctx.EndTime,
 			Status:          ctx.Status,
+			State:           state,
 			DurationSeconds: int(ctx.Duration().Seconds()),
 			NoteCount:       len(notesLines),
 			FileCount:       len(filesLines),
@@ -139,6 +159,7 @@ func NewListCmd(jsonOutput *bool) *cobra.Command {
 		projectFilter string
 		searchTerm    string
 		tagFilter     string
+		stateFilter   string
 		limitCount    int
 		showAll       bool
 		showArchived  bool
@@ -151,16 +172,14 @@ func NewListCmd(jsonOutput *bool) *cobra.Command {
 		Short:   "List all contexts",
 		Long: `List all contexts (active and stopped) with their status and timestamps.
 
-Supports filtering by project, search term, and archive status.`,
+Supports filtering by project, search term, tag, workflow state, and archive status.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get all contexts
 			allContexts, err := core.ListContexts()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("list", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("list", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -169,16 +188,14 @@ Supports filtering by project, search term, and archive status.`,
 			state, err := core.GetActiveContext()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("list", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("list", output.ExitFailure, err.Error())
 				}
 				return err
 			}
 			activeContextName := state.GetActiveContextName()
 
 			// Apply all filters
-			contexts := applyFilters(allContexts, projectFilter, searchTerm, tagFilter, showArchived, activeOnly, activeContextName)
+			contexts := applyFilters(allContexts, projectFilter, searchTerm, tagFilter, stateFilter, showArchived, activeOnly, activeContextName)
 
 			// Apply limit (default 10 unless --all)
 			totalCount := len(contexts)
@@ -190,7 +207,7 @@ Supports filtering by project, search term, and archive status.`,
 			if *jsonOutput {
 				summaries := buildContextSummaries(contexts)
 				data := output.ListData{Contexts: summaries}
-				jsonStr, err := output.FormatJSON("list", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("list", data)
 				if err != nil {
 					return err
 				}
@@ -212,10 +229,13 @@ Supports filtering by project, search term, and archive status.`,
 	cmd.Flags().StringVar(&projectFilter, "project", "", "Filter by project name")
 	cmd.Flags().StringVar(&searchTerm, "search", "", "Search contexts by name (case-insensitive)")
 	cmd.Flags().StringVar(&tagFilter, "tag", "", "Filter by tag/label")
+	cmd.Flags().StringVar(&stateFilter, "state", "", "Filter by workflow state (see 'my-context status')")
 	cmd.Flags().IntVar(&limitCount, "limit", 10, "Maximum number of contexts to show")
 	cmd.Flags().BoolVar(&showAll, "all", false, "Show all contexts (no limit)")
 	cmd.Flags().BoolVar(&showArchived, "archived", false, "Show only archived contexts")
 	cmd.Flags().BoolVar(&activeOnly, "active-only", false, "Show only the active context")
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+	cmd.RegisterFlagCompletionFunc("tag", completeTags)
 
 	return cmd
 }
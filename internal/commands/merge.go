@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewMergeContextsCmd(jsonOutput *bool) *cobra.Command {
+	var into string
+
+	cmd := &cobra.Command{
+		Use:   "merge-contexts <a> <b> --into <name>",
+		Short: "Merge two contexts into one",
+		Long: `Merge two stopped contexts into a single context.
+
+Notes, files and touches are interleaved by timestamp, labels are combined and
+children of either context are re-linked to the merged one. The original
+contexts are moved to the trash and can be recovered with 'trash restore'. Time
+spent in them is reported under the merged context from then on.
+
+--into may name a new context or one of the two being merged. Because the
+originals go to the trash, both must be given by exact name or ID; prefixes,
+fuzzy matches and - are not accepted.
+
+Examples:
+  my-context merge-contexts "auth spike" "login bug" --into "ps-cli: Auth rework"
+  my-context merge-contexts "Sprint 3" "Sprint 3_2" --into "Sprint 3"`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeContexts(2, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if into == "" {
+				errMsg := "--into is required"
+				if *jsonOutput {
+					return jsonError("merge-contexts", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			first, second := core.ContextNameOrID(args[0]), core.ContextNameOrID(args[1])
+			result, err := core.MergeContexts(first, second, into)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("merge-contexts", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("merge-contexts", result)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Printf("✓ Merged \"%s\" + \"%s\" → \"%s\"\n", first, second, result.Into)
+			fmt.Printf("  Notes: %d, Files: %d, Touches: %d\n", result.NoteCount, result.FileCount, result.TouchCount)
+			if len(result.ChildrenUpdated) > 0 {
+				fmt.Printf("  Re-linked %d child context(s)\n", len(result.ChildrenUpdated))
+			}
+			fmt.Println("  Originals moved to trash (see: my-context trash list)")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&into, "into", "", "Name of the merged context (required)")
+
+	return cmd
+}
+
+func NewSplitCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		after int
+		into  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "split <context> --after <note-id> --into <name>",
+		Short: "Split later entries into a new linked context",
+		Long: `Move every note after the given note, plus files and touches recorded after
+it, into a new stopped context. The new context is linked as a child of the
+original and keeps its labels.
+
+Note IDs are the numbers shown next to notes in 'show'.
+
+Examples:
+  my-context split "Sprint 3" --after 12 --into "Sprint 3: release prep"`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if into == "" || after == 0 {
+				errMsg := "--after and --into are required"
+				if *jsonOutput {
+					return jsonError("split", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			contextName, err := resolveContextName(args[0], *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("split", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			result, err := core.SplitContext(contextName, after, into)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("split", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("split", result)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Printf("✓ Split \"%s\" after note #%d → \"%s\"\n", result.Source, result.AfterNote, result.Into)
+			fmt.Printf("  Moved notes: %d, files: %d, touches: %d\n", result.NoteCount, result.FileCount, result.TouchCount)
+			fmt.Printf("  Linked \"%s\" → \"%s\"\n", result.Into, result.Source)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&after, "after", 0, "Split after this note ID (required)")
+	cmd.Flags().StringVar(&into, "into", "", "Name of the new context (required)")
+
+	return cmd
+}
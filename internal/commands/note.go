@@ -3,22 +3,44 @@ package commands
 import (
 	"errors"
 	"fmt"
-	"os"
-	"strconv"
+	"strings"
 
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
 	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func NewNoteCmd(jsonOutput *bool) *cobra.Command {
+	var noteType string
+
 	cmd := &cobra.Command{
 		Use:     "note <text>",
 		Aliases: []string{"n"},
 		Short:   "Add a note to the active context",
-		Long:    `Add a timestamped note to the currently active context.`,
-		Args:    cobra.MinimumNArgs(1),
+		Long: `Add a timestamped note to the currently active context.
+
+With --type the note is a todo, decision, question, blocker or link. Typed
+notes are marked in 'show', grouped by type in exports, and open todos are
+listed across contexts by 'my-context todos'. Close a todo with 'note done'
+and the note number from show or todos.
+
+Examples:
+  my-context note "Parser handles nested quotes now"
+  my-context note --type todo "Add tests for the empty input case"
+  my-context note --type decision "Use JSON lines for the event log"
+  my-context note done 3`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			noteType = strings.ToLower(noteType)
+			if err := models.ValidateNoteType(noteType); err != nil {
+				if *jsonOutput {
+					return jsonError("note", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
 			// Join all args as the note text
 			noteText := ""
 			for i, arg := range args {
@@ -32,9 +54,7 @@ func NewNoteCmd(jsonOutput *bool) *cobra.Command {
 			state, err := core.GetActiveContext()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("note", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("note", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -42,43 +62,51 @@ func NewNoteCmd(jsonOutput *bool) *cobra.Command {
 			if !state.HasActiveContext() {
 				errMsg := "No active context. Start a context with: my-context start <name>"
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("note", 1, errMsg)
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("note", output.ExitUsage, errMsg)
 				}
 				return errors.New(errMsg)
 			}
 
 			// Check note count thresholds and show warnings (before adding note)
 			contextName := state.GetActiveContextName()
-			currentCount, err := core.GetNoteCount(contextName)
-			if err == nil { // Continue even if we can't get count
+			currentCount, countErr := core.CountNotes(contextName)
+			if countErr == nil { // Continue even if we can't get count
 				ShowNoteWarning(currentCount)
 			}
 
 			// Add the note
-			note, err := core.AddNote(noteText)
+			note, err := core.AddTypedNote(noteText, noteType)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("note", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("note", output.ExitFailure, err.Error())
 				}
 				return err
 			}
 
+			// The new note's number in show, which note done takes
+			number, numberErr := core.CountNotes(contextName)
+
 			// Output
 			if *jsonOutput {
 				data := output.NoteData{
 					ContextName:   state.GetActiveContextName(),
 					NoteTimestamp: note.Timestamp,
 					NoteText:      note.TextContent,
+					NoteType:      note.Type,
 				}
-				jsonStr, err := output.FormatJSON("note", map[string]interface{}{"data": data})
+				if numberErr == nil {
+					data.NoteNumber = number
+				}
+				jsonStr, err := output.FormatJSON("note", data)
 				if err != nil {
 					return err
 				}
 				fmt.Print(jsonStr)
+			} else if note.Type == models.NoteTypeTodo && numberErr == nil {
+				fmt.Printf("Todo #%d added to context: %s (close it with: my-context note done %d)\n",
+					number, state.GetActiveContextName(), number)
+			} else if note.Type != "" {
+				fmt.Printf("%s added to context: %s\n", capitalize(note.Type), state.GetActiveContextName())
 			} else {
 				fmt.Printf("Note added to context: %s\n", state.GetActiveContextName())
 			}
@@ -87,25 +115,82 @@ func NewNoteCmd(jsonOutput *bool) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&noteType, "type", "", "Note type: "+strings.Join(models.NoteTypes, ", "))
+	cmd.RegisterFlagCompletionFunc("type", cobra.FixedCompletions(models.NoteTypes, cobra.ShellCompDirectiveNoFileComp))
+
+	cmd.AddCommand(newNoteDoneCmd(jsonOutput))
+
 	return cmd
 }
 
-// GetEnvInt reads an integer environment variable with a default value
-func GetEnvInt(key string, defaultVal int) int {
-	if val := os.Getenv(key); val != "" {
-		if i, err := strconv.Atoi(val); err == nil {
-			return i
-		}
+func newNoteDoneCmd(jsonOutput *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "done <note-id>",
+		Short: "Close a todo note",
+		Long: `Mark a todo as done. The note ID is its number in 'show' for the active
+context (3 or #3), or <context>#<number> for any context, where context is
+a name or ID as printed by 'my-context todos'. 'undo' reopens it.
+
+To add a plain note that starts with the word "done", quote it:
+  my-context note "done with the parser"
+
+Examples:
+  my-context note done 3
+  my-context note done k3x9fq#2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contextName, number, err := core.ParseNoteRef(args[0])
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("note done", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			note, err := core.MarkNoteDone(contextName, number)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("note done", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"context": contextName,
+					"number":  number,
+					"note":    note,
+				}
+				jsonStr, err := output.FormatJSON("note done", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("✓ Done: %s (#%d in %s)\n", note.TextContent, number, contextName)
+			}
+
+			return nil
+		},
+	}
+}
+
+// capitalize upper-cases the first letter of a note type for messages
+func capitalize(s string) string {
+	if s == "" {
+		return s
 	}
-	return defaultVal
+	return strings.ToUpper(s[:1]) + s[1:]
 }
 
 // ShowNoteWarning displays warnings when note count reaches thresholds
 func ShowNoteWarning(currentCount int) {
-	// Read threshold environment variables
-	warnAt1 := GetEnvInt("MC_WARN_AT", 50)
-	warnAt2 := GetEnvInt("MC_WARN_AT_2", 100)
-	warnAt3 := GetEnvInt("MC_WARN_AT_3", 200)
+	// Read thresholds (MC_WARN_AT* or config.yaml)
+	settings := config.Get()
+	warnAt1 := settings.WarnAt
+	warnAt2 := settings.WarnAt2
+	warnAt3 := settings.WarnAt3
 
 	newCount := currentCount + 1 // Count after adding this note
 
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewRenameCmd(jsonOutput *bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rename <old-name> <new-name>",
+		Aliases: []string{"mv"},
+		Short:   "Rename a context",
+		Long: `Rename a context, keeping everything that refers to it consistent.
+
+The context directory is moved, child contexts are re-linked to the new name,
+the active context is updated if needed, and a RENAME entry is added to history.
+
+Examples:
+  my-context rename "bugfix" "ps-cli: Fix login timeout"
+  my-context rename "Sprint 3" "Sprint 3 (done)"`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeContexts(1, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldName, err := resolveContextName(args[0], *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("rename", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			result, err := core.RenameContext(oldName, args[1])
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("rename", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("rename", result)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Printf("✓ Renamed \"%s\" → \"%s\"\n", result.OldName, result.NewName)
+			if len(result.ChildrenUpdated) > 0 {
+				fmt.Printf("  Re-linked %d child context(s)\n", len(result.ChildrenUpdated))
+			}
+			if result.WasActive {
+				fmt.Printf("  Active context is now: %s\n", result.NewName)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
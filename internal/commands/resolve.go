@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/tui"
+	"golang.org/x/term"
+)
+
+// resolveContext finds the context a command-line argument refers to: an exact
+// name, a unique prefix or fuzzy match, or - / @{-N} for a previous context
+// (see core.ResolveContext). When the argument matches several contexts and the
+// terminal is interactive, the user picks one; otherwise the ambiguity is an error.
+func resolveContext(query string, check func(*models.Context) error, jsonOutput bool) (*models.Context, error) {
+	ctx, err := core.ResolveContext(query, check)
+
+	var ambiguous *core.AmbiguousContextError
+	if !errors.As(err, &ambiguous) || jsonOutput ||
+		!term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return ctx, err
+	}
+
+	names := make([]string, len(ambiguous.Matches))
+	for i, match := range ambiguous.Matches {
+		names[i] = match.Name
+	}
+	// Draw on stderr so the picker stays out of piped output
+	picked, err := tui.Pick(os.Stdin, os.Stderr, fmt.Sprintf("%q matches %d contexts:", query, len(names)), names, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range ambiguous.Matches {
+		if match.Name == picked {
+			return match, nil
+		}
+	}
+	return nil, fmt.Errorf("context %q not found", picked)
+}
+
+// resolveContextName is resolveContext for commands that only need the name
+func resolveContextName(query string, jsonOutput bool) (string, error) {
+	ctx, err := resolveContext(query, nil, jsonOutput)
+	if err != nil {
+		return "", err
+	}
+	return ctx.Name, nil
+}
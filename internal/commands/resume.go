@@ -1,13 +1,9 @@
 package commands
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"os"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
 	"github.com/jefferycaldwell/my-context-copilot/internal/models"
@@ -18,7 +14,7 @@ import (
 var resumeLast bool
 
 // findTargetContext finds the context to resume based on arguments and flags
-func findTargetContext(args []string, useLast bool) (*models.Context, error) {
+func findTargetContext(args []string, useLast, jsonOutput bool) (*models.Context, error) {
 	if useLast {
 		return core.GetMostRecentStopped()
 	}
@@ -27,25 +23,19 @@ func findTargetContext(args []string, useLast bool) (*models.Context, error) {
 		return nil, errors.New("must specify context name/pattern or use --last flag")
 	}
 
-	pattern := args[0]
-	contexts, err := core.FindContextsByPattern(pattern)
-	if err != nil {
-		return nil, err
-	}
+	query := args[0]
+	ctx, err := resolveContext(query, stoppedContext, jsonOutput)
 
-	switch len(contexts) {
-	case 0:
+	var notFound *core.ContextNotFoundError
+	if errors.As(err, &notFound) {
 		availableContexts, listErr := getAvailableStoppedContexts()
-		errMsg := fmt.Sprintf("No stopped contexts match pattern %q", pattern)
+		errMsg := fmt.Sprintf("No stopped contexts match %q", query)
 		if listErr == nil && len(availableContexts) > 0 {
 			errMsg += fmt.Sprintf(". Available stopped contexts: %s", strings.Join(availableContexts, ", "))
 		}
 		return nil, errors.New(errMsg)
-	case 1:
-		return contexts[0], nil
-	default:
-		return PromptSelection(contexts)
 	}
+	return ctx, err
 }
 
 func NewResumeCmd(jsonOutput *bool) *cobra.Command {
@@ -53,16 +43,19 @@ func NewResumeCmd(jsonOutput *bool) *cobra.Command {
 		Use:     "resume <name|pattern>",
 		Aliases: []string{"r"},
 		Short:   "Resume a stopped context",
-		Long:    `Resume a previously stopped context by name, pattern, or --last flag.`,
-		Args:    cobra.MaximumNArgs(1),
+		Long: `Resume a previously stopped context by name, pattern, or --last flag.
+
+The name can be shortened to a unique prefix, a substring ("auth" for
+"ps-cli: Auth") or any letters of it in order; - resumes the previous context
+and @{-2} the one before it. When several contexts match, pick one from a list.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, stoppedContext),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if we have an active context
 			state, err := core.GetActiveContext()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("resume", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("resume", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -70,20 +63,16 @@ func NewResumeCmd(jsonOutput *bool) *cobra.Command {
 			if state.HasActiveContext() {
 				errMsg := fmt.Sprintf("Cannot resume: context %q is already active", state.GetActiveContextName())
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("resume", 1, errMsg)
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("resume", output.ExitUsage, errMsg)
 				}
 				return errors.New(errMsg)
 			}
 
 			// Find the target context to resume
-			targetContext, err := findTargetContext(args, resumeLast)
+			targetContext, err := findTargetContext(args, resumeLast, *jsonOutput)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("resume", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("resume", output.ExitUsage, err.Error())
 				}
 				return err
 			}
@@ -100,21 +89,8 @@ func NewResumeCmd(jsonOutput *bool) *cobra.Command {
 
 // resumeContext resumes a specific context
 func resumeContext(ctx *models.Context, jsonOutput *bool) error {
-	// Set the context as active
-	if err := core.SetActiveContext(ctx.Name); err != nil {
-		return fmt.Errorf("failed to activate context: %w", err)
-	}
-
-	// Log the transition
-	now := time.Now()
-	transition := &models.ContextTransition{
-		Timestamp:      now,
-		NewContext:     &ctx.Name,
-		TransitionType: models.TransitionStart,
-	}
-
-	if err := core.AppendLog(core.GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
-		return fmt.Errorf("failed to log transition: %w", err)
+	if err := core.ResumeContext(ctx.Name); err != nil {
+		return err
 	}
 
 	// Output
@@ -124,7 +100,7 @@ func resumeContext(ctx *models.Context, jsonOutput *bool) error {
 			OriginalName: ctx.Name,
 			WasDuplicate: false,
 		}
-		jsonStr, err := output.FormatJSON("resume", map[string]interface{}{"data": data})
+		jsonStr, err := output.FormatJSON("resume", data)
 		if err != nil {
 			return err
 		}
@@ -136,29 +112,6 @@ func resumeContext(ctx *models.Context, jsonOutput *bool) error {
 	return nil
 }
 
-// PromptSelection displays a numbered list of contexts and prompts user to select one
-func PromptSelection(contexts []*models.Context) (*models.Context, error) {
-	fmt.Println("Multiple contexts match:")
-	for i, ctx := range contexts {
-		fmt.Printf("  %d. %s\n", i+1, ctx.Name)
-	}
-	fmt.Print("Select context (1-" + strconv.Itoa(len(contexts)) + "): ")
-
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return nil, fmt.Errorf("failed to read user input: %w", err)
-	}
-
-	response = strings.TrimSpace(response)
-	selection, err := strconv.Atoi(response)
-	if err != nil || selection < 1 || selection > len(contexts) {
-		return nil, fmt.Errorf("invalid selection: %s (expected 1-%d)", response, len(contexts))
-	}
-
-	return contexts[selection-1], nil
-}
-
 // getAvailableStoppedContexts returns a list of available stopped context names for error messages
 func getAvailableStoppedContexts() ([]string, error) {
 	contexts, err := core.ListContexts()
@@ -175,3 +128,11 @@ func getAvailableStoppedContexts() ([]string, error) {
 
 	return stopped, nil
 }
+
+// stoppedContext accepts the contexts resume can switch to
+func stoppedContext(ctx *models.Context) error {
+	if ctx.Status != "stopped" {
+		return fmt.Errorf("context %q is not stopped", ctx.Name)
+	}
+	return nil
+}
@@ -5,30 +5,38 @@ import (
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
 	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/jefferycaldwell/my-context-copilot/internal/signal"
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
 	"github.com/spf13/cobra"
 )
 
 func NewShowCmd(jsonOutput *bool) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "show [context-name]",
-		Aliases: []string{"w"},
-		Short:   "Show context details",
-		Long:    `Display details about the currently active context including notes, files, and touch events.`,
-		Args:    cobra.MaximumNArgs(1),
+		Use:               "show [context-name]",
+		Aliases:           []string{"w"},
+		Short:             "Show context details",
+		Long:              `Display details about the currently active context including notes, files, and touch events.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var contextName string
 
 			// If context name provided as argument, use it
 			if len(args) > 0 {
-				contextName = args[0]
+				ctx, err := resolveContext(args[0], nil, *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("show", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				contextName = ctx.Name
 			} else {
 				// No argument - show active context (backward compatible)
 				state, err := core.GetActiveContext()
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("show", 2, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("show", output.ExitFailure, err.Error())
 					}
 					return err
 				}
@@ -36,12 +44,10 @@ func NewShowCmd(jsonOutput *bool) *cobra.Command {
 				if !state.HasActiveContext() {
 					errMsg := "No active context"
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("show", 1, errMsg)
-						fmt.Print(jsonStr)
-					} else {
-						fmt.Println(errMsg)
-						fmt.Println("Start one with: my-context start <name>")
+						return jsonError("show", output.ExitUsage, errMsg)
 					}
+					fmt.Println(errMsg)
+					fmt.Println("Start one with: my-context start <name>")
 					return nil
 				}
 
@@ -52,9 +58,15 @@ func NewShowCmd(jsonOutput *bool) *cobra.Command {
 			context, notes, files, touches, err := core.GetContextWithMetadata(contextName)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("show", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("show", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			signals, err := listContextSignals(contextName)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("show", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -66,8 +78,9 @@ func NewShowCmd(jsonOutput *bool) *cobra.Command {
 					Notes:   notes,
 					Files:   files,
 					Touches: touches,
+					Signals: signals,
 				}
-				jsonStr, err := output.FormatJSON("show", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("show", data)
 				if err != nil {
 					return err
 				}
@@ -76,6 +89,7 @@ func NewShowCmd(jsonOutput *bool) *cobra.Command {
 				// Print context home header
 				output.PrintContextHomeHeader(core.GetContextHomeDisplay(), core.GetContextCount())
 				fmt.Print(output.FormatContext(context, notes, files, touches))
+				fmt.Print(output.FormatSignalsSection(signals))
 			}
 
 			return nil
@@ -84,3 +98,17 @@ func NewShowCmd(jsonOutput *bool) *cobra.Command {
 
 	return cmd
 }
+
+// listContextSignals returns a context's unexpired signals without creating its signals directory
+func listContextSignals(contextName string) ([]models.SignalInfo, error) {
+	signalsDir := core.GetContextSignalsDir(contextName)
+	if !core.FileExists(signalsDir) {
+		return nil, nil
+	}
+
+	manager, err := signal.NewManager(signalsDir)
+	if err != nil {
+		return nil, err
+	}
+	return manager.ListSignals()
+}
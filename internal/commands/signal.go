@@ -3,126 +3,263 @@ package commands
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
 	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	"github.com/jefferycaldwell/my-context-copilot/internal/signal"
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
 	"github.com/spf13/cobra"
 )
 
 func NewSignalCmd(jsonOutput *bool) *cobra.Command {
+	var contextName string
+
 	cmd := &cobra.Command{
 		Use:   "signal",
 		Short: "Manage signal files for event coordination",
 		Long: `Manage signal files for event coordination between processes and team members.
 
-Signal files are simple timestamped files stored in ~/.my-context/signals/ that can be used
-to coordinate events like binary updates, context changes, or custom notifications.`,
+Signal files are small JSON files stored in the signals/ directory of the context
+home (~/.my-context/signals/ by default, or $MY_CONTEXT_HOME/signals/) that can be used
+to coordinate events like binary updates, context changes, or custom notifications.
+A signal can carry a message, sender, related context and key/value data, and can
+expire after a TTL.
+
+Signals are level-triggered: a signal either exists or it doesn't. For a stream
+of events use a queue instead: 'signal send' appends messages, 'signal recv'
+reads them through a named consumer cursor and 'signal ack' acknowledges them.
+
+With --context, signals are scoped to one context: they are stored in that
+context's directory, shown by 'show', and move with the context on rename.`,
 	}
 
+	cmd.PersistentFlags().StringVar(&contextName, "context", "", "Use signals scoped to this context instead of global signals")
+
 	// Add subcommands
-	cmd.AddCommand(newSignalCreateCmd(jsonOutput))
-	cmd.AddCommand(newSignalListCmd(jsonOutput))
-	cmd.AddCommand(newSignalWaitCmd(jsonOutput))
-	cmd.AddCommand(newSignalClearCmd(jsonOutput))
+	cmd.AddCommand(newSignalCreateCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalListCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalWaitCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalClearCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalGCCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalSendCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalRecvCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalAckCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalQueuesCmd(jsonOutput, &contextName))
+	cmd.AddCommand(newSignalHistoryCmd(jsonOutput, &contextName))
+	cmd.RegisterFlagCompletionFunc("context", completeContextFlag)
 
 	return cmd
 }
 
-func newSignalCreateCmd(jsonOutput *bool) *cobra.Command {
+func newSignalCreateCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var (
+		message        string
+		sender         string
+		relatedContext string
+		data           []string
+		ttl            string
+	)
+
 	cmd := &cobra.Command{
 		Use:   "create <name>",
 		Short: "Create a signal file",
-		Long:  `Create a signal file with the given name. The signal file will be stored as ~/.my-context/signals/<name>.signal`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Create a signal file with the given name. The signal file will be stored as <context home>/signals/<name>.signal,
+or in the context's own signals/ directory with --context.
+
+The payload is printed by 'signal wait' when the signal arrives. With --ttl the
+signal is hidden from 'signal list' once it expires and removed by 'signal gc'.
+
+Examples:
+  my-context signal create binary-updated -m "binary updated to bdac191" --data commit=bdac191
+  my-context signal create review-ready --related-context "ps-cli: Phase 1" --ttl 2h`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
 
-			// Get signal directory
-			homeDir, err := os.UserHomeDir()
+			payload, ttlDuration, err := parseSignalPayload(message, sender, relatedContext, data, ttl)
 			if err != nil {
-				return fmt.Errorf("failed to get home directory: %w", err)
+				if *jsonOutput {
+					return jsonError("signal create", output.ExitUsage, err.Error())
+				}
+				return err
 			}
-			signalsDir := filepath.Join(homeDir, ".my-context", "signals")
 
 			// Create signal manager
-			manager, err := signal.NewManager(signalsDir)
+			manager, err := newSignalManager(*contextName)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal create", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal create", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
 			// Create signal
-			signal, err := manager.CreateSignal(name)
+			signal, err := manager.CreateSignalWithPayload(name, payload, ttlDuration)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal create", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal create", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
 			// Output
 			if *jsonOutput {
-				data := map[string]interface{}{
-					"name":       signal.Name,
-					"created_at": signal.CreatedAt.Format(time.RFC3339),
-					"path":       signal.Path,
-				}
-				jsonStr, err := output.FormatJSON("signal create", data)
+				jsonStr, err := output.FormatJSON("signal create", signal.ToInfo())
 				if err != nil {
 					return err
 				}
 				fmt.Print(jsonStr)
 			} else {
 				fmt.Printf("Signal '%s' created\n", name)
+				if signal.ExpiresAt != nil {
+					fmt.Printf("  Expires: %s\n", signal.ExpiresAt.Format(time.RFC3339))
+				}
 			}
 
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Message carried by the signal")
+	cmd.Flags().StringVar(&sender, "sender", "", "Sender name (default: $USER)")
+	cmd.Flags().StringVar(&relatedContext, "related-context", "", "Name of the context this signal is about")
+	cmd.Flags().StringArrayVar(&data, "data", nil, "Key/value data as key=value (repeatable)")
+	cmd.Flags().StringVar(&ttl, "ttl", "", "Expire the signal after this duration (e.g., '30m', '24h')")
+	cmd.RegisterFlagCompletionFunc("related-context", completeContextFlag)
+
 	return cmd
 }
 
-func newSignalListCmd(jsonOutput *bool) *cobra.Command {
+// newSignalManager returns a manager for global signals, or for one context's signals
+func newSignalManager(contextName string) (*signal.Manager, error) {
+	if contextName == "" {
+		return signal.NewManager(core.GetSignalsDir())
+	}
+
+	// No picker here: completion builds managers too
+	ctx, err := core.ResolveContext(contextName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return signal.NewManager(core.GetContextSignalsDir(ctx.Name))
+}
+
+// collectSignalHistory reads signal events from the global audit log and every
+// context's log, oldest first. Events from scoped signals carry their context in Scope.
+func collectSignalHistory(pattern string) ([]models.SignalEvent, error) {
+	events, err := signal.ReadAuditLog(signal.AuditLogPath(core.GetSignalsDir()), pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	contexts, err := core.ListContexts()
+	if err != nil {
+		return nil, err
+	}
+	for _, ctx := range contexts {
+		scoped, err := signal.ReadAuditLog(signal.AuditLogPath(core.GetContextSignalsDir(ctx.Name)), pattern)
+		if err != nil {
+			return nil, err
+		}
+		for i := range scoped {
+			scoped[i].Scope = ctx.Name
+		}
+		events = append(events, scoped...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+// parseSignalPayload builds a signal payload and TTL from create flags
+func parseSignalPayload(message, sender, relatedContext string, data []string, ttl string) (models.SignalPayload, time.Duration, error) {
+	if sender == "" {
+		sender = os.Getenv("USER")
+	}
+
+	payload := models.SignalPayload{
+		Message: message,
+		Sender:  sender,
+		Context: relatedContext,
+	}
+
+	for _, pair := range data {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return payload, 0, fmt.Errorf("invalid --data %q: expected key=value", pair)
+		}
+		if payload.Data == nil {
+			payload.Data = make(map[string]string)
+		}
+		payload.Data[strings.TrimSpace(key)] = value
+	}
+
+	var ttlDuration time.Duration
+	if ttl != "" {
+		var err error
+		ttlDuration, err = time.ParseDuration(ttl)
+		if err != nil || ttlDuration <= 0 {
+			return payload, 0, fmt.Errorf("invalid --ttl '%s': expected a positive duration like '30m' or '24h'", ttl)
+		}
+	}
+
+	return payload, ttlDuration, nil
+}
+
+// printSignalPayload prints a signal's payload, one field per line
+func printSignalPayload(payload models.SignalPayload) {
+	if payload.Message != "" {
+		fmt.Printf("  Message: %s\n", payload.Message)
+	}
+	if payload.Sender != "" {
+		fmt.Printf("  From: %s\n", payload.Sender)
+	}
+	if payload.Context != "" {
+		fmt.Printf("  Context: %s\n", payload.Context)
+	}
+
+	keys := make([]string, 0, len(payload.Data))
+	for key := range payload.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  %s: %s\n", key, payload.Data[key])
+	}
+}
+
+func newSignalListCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var showAll bool
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all signal files",
-		Long:  `List all existing signal files with their creation timestamps.`,
+		Long:  `List all existing signal files with their creation timestamps. Expired signals are hidden unless --all is given.`,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get signal directory
-			homeDir, err := os.UserHomeDir()
-			if err != nil {
-				return fmt.Errorf("failed to get home directory: %w", err)
-			}
-			signalsDir := filepath.Join(homeDir, ".my-context", "signals")
-
 			// Create signal manager
-			manager, err := signal.NewManager(signalsDir)
+			manager, err := newSignalManager(*contextName)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal list", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal list", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
 			// List signals
-			signals, err := manager.ListSignals()
+			listSignals := manager.ListSignals
+			if showAll {
+				listSignals = manager.ListAllSignals
+			}
+			signals, err := listSignals()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal list", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal list", output.ExitUsage, err.Error())
 				}
 				return err
 			}
@@ -142,7 +279,19 @@ func newSignalListCmd(jsonOutput *bool) *cobra.Command {
 
 				fmt.Println("Signals:")
 				for _, sig := range signals {
-					fmt.Printf("  %s (created: %s)\n", sig.Name, sig.CreatedAt)
+					status := ""
+					if sig.Expired {
+						status = ", expired"
+					} else if sig.ExpiresAt != "" {
+						status = ", expires: " + sig.ExpiresAt
+					}
+					fmt.Printf("  %s (created: %s%s)\n", sig.Name, sig.CreatedAt, status)
+					if sig.Message != "" {
+						fmt.Printf("    %s\n", sig.Message)
+					}
+					if sig.Sender != "" {
+						fmt.Printf("    from: %s\n", sig.Sender)
+					}
 				}
 			}
 
@@ -150,21 +299,59 @@ func newSignalListCmd(jsonOutput *bool) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().BoolVar(&showAll, "all", false, "Include expired signals")
+
 	return cmd
 }
 
-func newSignalWaitCmd(jsonOutput *bool) *cobra.Command {
-	var timeout string
+func newSignalWaitCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var (
+		timeout string
+		waitAny bool
+		waitAll bool
+		consume bool
+	)
 
 	cmd := &cobra.Command{
-		Use:   "wait <name>",
+		Use:   "wait <name|pattern>...",
 		Short: "Wait for a signal file to appear",
 		Long: `Wait for a signal file with the given name to appear. Blocks until the signal is created or timeout expires.
 
-Timeout can be specified as a duration (e.g., "30s", "5m", "1h") or "infinite" to wait indefinitely.`,
-		Args: cobra.ExactArgs(1),
+Names may be glob patterns (e.g., 'binary-updated*' or 'binary-updated*.signal').
+With several names, --any (the default) returns as soon as one matches and --all
+waits until every name has a match. The signals that fired are reported with
+their payloads.
+
+--consume clears the fired signals atomically, so when several processes wait
+on the same signal only one of them receives it.
+
+Timeout can be specified as a duration (e.g., "30s", "5m", "1h") or "infinite" to wait indefinitely.
+
+Examples:
+  my-context signal wait binary-updated
+  my-context signal wait 'binary-updated*' --consume
+  my-context signal wait tests-passed lint-passed --all --timeout 30m`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeSignals,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			name := args[0]
+			patterns := args
+
+			if waitAny && waitAll {
+				errMsg := "--any and --all cannot be used together"
+				if *jsonOutput {
+					return jsonError("signal wait", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			for _, pattern := range patterns {
+				if err := signal.ValidatePattern(pattern); err != nil {
+					if *jsonOutput {
+						return jsonError("signal wait", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+			}
 
 			// Parse timeout
 			var timeoutDuration time.Duration
@@ -178,39 +365,25 @@ Timeout can be specified as a duration (e.g., "30s", "5m", "1h") or "infinite" t
 				}
 			}
 
-			// Get signal directory
-			homeDir, err := os.UserHomeDir()
-			if err != nil {
-				return fmt.Errorf("failed to get home directory: %w", err)
-			}
-			signalsDir := filepath.Join(homeDir, ".my-context", "signals")
-
 			// Create signal manager
-			manager, err := signal.NewManager(signalsDir)
+			manager, err := newSignalManager(*contextName)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal wait", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal wait", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
-			// If signal already exists, return immediately
-			if manager.SignalExists(name) {
+			// If signals already exist, return immediately
+			fired, err := manager.TrySignals(patterns, waitAll, consume)
+			if err != nil {
 				if *jsonOutput {
-					jsonStr, err := output.FormatJSON("signal wait", map[string]interface{}{
-						"name":    name,
-						"existed": true,
-					})
-					if err != nil {
-						return err
-					}
-					fmt.Print(jsonStr)
-				} else {
-					fmt.Printf("Signal '%s' already exists\n", name)
+					return jsonError("signal wait", output.ExitUsage, err.Error())
 				}
-				return nil
+				return err
+			}
+			if len(fired) > 0 {
+				return outputSignalArrival(patterns, fired, true, waitAll, consume, jsonOutput)
 			}
 
 			// Set a reasonable timeout if infinite was requested
@@ -218,87 +391,164 @@ Timeout can be specified as a duration (e.g., "30s", "5m", "1h") or "infinite" t
 				timeoutDuration = 24 * time.Hour // Default to 24 hours for "infinite"
 			}
 
-			// Wait for signal
-			err = manager.WaitForSignal(name, timeoutDuration)
+			// Wait for signals
+			fired, err = manager.WaitForSignals(patterns, waitAll, consume, timeoutDuration)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal wait", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal wait", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			return outputSignalArrival(patterns, fired, false, waitAll, consume, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringVar(&timeout, "timeout", "5m", "Timeout duration (e.g., '30s', '5m', '1h') or 'infinite'")
+	cmd.Flags().BoolVar(&waitAny, "any", false, "Return when any name matches (default)")
+	cmd.Flags().BoolVar(&waitAll, "all", false, "Wait until every name has a match")
+	cmd.Flags().BoolVar(&consume, "consume", false, "Clear the fired signals atomically so other waiters don't act on them")
+
+	return cmd
+}
+
+// outputSignalArrival reports the signals that satisfied a wait, including their payloads
+func outputSignalArrival(patterns []string, fired []*models.Signal, existed, all, consume bool, jsonOutput *bool) error {
+	if *jsonOutput {
+		mode := "any"
+		if all {
+			mode = "all"
+		}
+		signals := make([]models.SignalInfo, 0, len(fired))
+		for _, sig := range fired {
+			signals = append(signals, sig.ToInfo())
+		}
+		data := map[string]interface{}{
+			"patterns": patterns,
+			"mode":     mode,
+			"existed":  existed,
+			"consumed": consume,
+			"fired":    signals,
+		}
+		// Single-signal fields kept for scripts written against earlier versions
+		data["name"] = fired[0].Name
+		data["payload"] = fired[0].SignalPayload
+
+		jsonStr, err := output.FormatJSON("signal wait", data)
+		if err != nil {
+			return err
+		}
+		fmt.Print(jsonStr)
+		return nil
+	}
+
+	for _, sig := range fired {
+		switch {
+		case consume:
+			fmt.Printf("Signal '%s' consumed\n", sig.Name)
+		case existed:
+			fmt.Printf("Signal '%s' already exists\n", sig.Name)
+		default:
+			fmt.Printf("Signal '%s' detected\n", sig.Name)
+		}
+		printSignalPayload(sig.SignalPayload)
+	}
+	return nil
+}
+
+func newSignalClearCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "clear <name>",
+		Short:             "Remove a signal file",
+		Long:              `Remove the signal file with the given name.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSignals,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			// Create signal manager
+			manager, err := newSignalManager(*contextName)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal clear", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Clear signal
+			err = manager.ClearSignal(name)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal clear", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
 			// Output
 			if *jsonOutput {
-				jsonStr, err := output.FormatJSON("signal wait", map[string]interface{}{
-					"name":    name,
-					"existed": false,
-				})
+				jsonStr, err := output.FormatJSON("signal clear", map[string]interface{}{"name": name})
 				if err != nil {
 					return err
 				}
 				fmt.Print(jsonStr)
 			} else {
-				fmt.Printf("Signal '%s' detected\n", name)
+				fmt.Printf("Signal '%s' cleared\n", name)
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&timeout, "timeout", "5m", "Timeout duration (e.g., '30s', '5m', '1h') or 'infinite'")
-
 	return cmd
 }
 
-func newSignalClearCmd(jsonOutput *bool) *cobra.Command {
+func newSignalGCCmd(jsonOutput *bool, contextName *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "clear <name>",
-		Short: "Remove a signal file",
-		Long:  `Remove the signal file with the given name.`,
-		Args:  cobra.ExactArgs(1),
+		Use:   "gc",
+		Short: "Remove expired signals and acknowledged queue messages",
+		Long:  `Remove signal files whose TTL has passed, and queue messages every consumer has acknowledged.`,
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			name := args[0]
-
-			// Get signal directory
-			homeDir, err := os.UserHomeDir()
+			// Create signal manager
+			manager, err := newSignalManager(*contextName)
 			if err != nil {
-				return fmt.Errorf("failed to get home directory: %w", err)
+				if *jsonOutput {
+					return jsonError("signal gc", output.ExitUsage, err.Error())
+				}
+				return err
 			}
-			signalsDir := filepath.Join(homeDir, ".my-context", "signals")
 
-			// Create signal manager
-			manager, err := signal.NewManager(signalsDir)
+			removed, err := manager.RemoveExpiredSignals()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal clear", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal gc", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
-			// Clear signal
-			err = manager.ClearSignal(name)
+			pruned, err := manager.PruneQueues()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("signal clear", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("signal gc", output.ExitUsage, err.Error())
 				}
 				return err
 			}
 
 			// Output
 			if *jsonOutput {
-				jsonStr, err := output.FormatJSON("signal clear", map[string]interface{}{"name": name})
+				jsonStr, err := output.FormatJSON("signal gc", map[string]interface{}{"removed": removed, "pruned_messages": pruned})
 				if err != nil {
 					return err
 				}
 				fmt.Print(jsonStr)
 			} else {
-				fmt.Printf("Signal '%s' cleared\n", name)
+				for _, sig := range removed {
+					fmt.Printf("Removed expired signal '%s'\n", sig.Name)
+				}
+				fmt.Printf("%d expired signal(s) removed\n", len(removed))
+				if pruned > 0 {
+					fmt.Printf("%d acknowledged queue message(s) removed\n", pruned)
+				}
 			}
 
 			return nil
@@ -307,3 +557,95 @@ func newSignalClearCmd(jsonOutput *bool) *cobra.Command {
 
 	return cmd
 }
+
+func newSignalHistoryCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history [name|pattern]",
+		Short: "Show the signal audit log",
+		Long: `Show recorded signal events (create, clear, consume and expire) with who
+caused them and the payload the signal carried, oldest first.
+
+Events are read from signals.log in each signals directory. Without --context,
+global and context-scoped signals are combined; with --context only that
+context's signals are shown.
+
+Examples:
+  my-context signal history
+  my-context signal history 'binary-updated*' --limit 20
+  my-context signal history review-ready --context "ps-cli: Auth rework"`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeSignals,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pattern := ""
+			if len(args) > 0 {
+				pattern = args[0]
+				if err := signal.ValidatePattern(pattern); err != nil {
+					if *jsonOutput {
+						return jsonError("signal history", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+			}
+
+			var events []models.SignalEvent
+			var err error
+			if *contextName != "" {
+				var manager *signal.Manager
+				manager, err = newSignalManager(*contextName)
+				if err == nil {
+					events, err = manager.History(pattern)
+				}
+			} else {
+				events, err = collectSignalHistory(pattern)
+			}
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal history", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			if limit > 0 && len(events) > limit {
+				events = events[len(events)-limit:]
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("signal history", map[string]interface{}{"events": events})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if len(events) == 0 {
+				fmt.Println("No signal events recorded")
+				return nil
+			}
+
+			fmt.Println("Signal History:")
+			for _, event := range events {
+				fmt.Printf("  [%s] %-7s %s", event.Timestamp.Format("2006-01-02 15:04:05"), strings.ToUpper(event.Event), event.Signal)
+				if event.Scope != "" {
+					fmt.Printf(" (%s)", event.Scope)
+				}
+				if event.Actor != "" {
+					fmt.Printf(" by %s", event.Actor)
+				}
+				fmt.Println()
+				if event.Message != "" {
+					fmt.Printf("    %s\n", event.Message)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 0, "Show only the most recent N events")
+
+	return cmd
+}
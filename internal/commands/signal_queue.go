@@ -0,0 +1,313 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+func newSignalSendCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var (
+		message        string
+		sender         string
+		relatedContext string
+		data           []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "send <queue>",
+		Short: "Send a message to a signal queue",
+		Long: `Append a message to a queue. Unlike 'signal create', sending never fails
+because the queue already has messages: every send gets the next message ID.
+
+Consumers read messages with 'signal recv' and acknowledge them with 'signal ack'.
+
+Examples:
+  my-context signal send deb-built -m "my-context_2.4.0_amd64.deb" --data version=2.4.0
+  my-context signal send review-requests --related-context "ps-cli: Auth rework"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queue := args[0]
+
+			payload, _, err := parseSignalPayload(message, sender, relatedContext, data, "")
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal send", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Create signal manager
+			manager, err := newSignalManager(*contextName)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal send", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			msg, err := manager.SendMessage(queue, payload)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal send", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("signal send", msg)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("Sent message #%d to queue '%s'\n", msg.ID, queue)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Message text")
+	cmd.Flags().StringVar(&sender, "sender", "", "Sender name (default: $USER)")
+	cmd.Flags().StringVar(&relatedContext, "related-context", "", "Name of the context this message is about")
+	cmd.Flags().StringArrayVar(&data, "data", nil, "Key/value data as key=value (repeatable)")
+	cmd.RegisterFlagCompletionFunc("related-context", completeContextFlag)
+
+	return cmd
+}
+
+func newSignalRecvCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var (
+		consumer string
+		limit    int
+		wait     bool
+		timeout  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "recv <queue> --consumer <name>",
+		Short: "Receive unacknowledged messages from a signal queue",
+		Long: `Print the oldest messages the consumer has not acknowledged yet.
+
+Each consumer has its own cursor, so several teams can read the same queue
+independently. Receiving does not move the cursor: a message is delivered again
+until it is acknowledged with 'signal ack', so a consumer that crashes midway
+picks up where it left off (at-least-once delivery).
+
+Examples:
+  my-context signal recv deb-built --consumer deb-sanity
+  my-context signal recv deb-built --consumer deb-sanity --wait --timeout 1h
+  my-context signal recv deb-built --consumer release-notes --max 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queue := args[0]
+
+			if consumer == "" {
+				errMsg := "--consumer is required"
+				if *jsonOutput {
+					return jsonError("signal recv", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			// Create signal manager
+			manager, err := newSignalManager(*contextName)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal recv", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			var messages []*models.QueueMessage
+			if wait {
+				var timeoutDuration time.Duration
+				timeoutDuration, err = time.ParseDuration(timeout)
+				if err != nil {
+					return fmt.Errorf("invalid timeout duration '%s': %w", timeout, err)
+				}
+				messages, err = manager.WaitForMessages(queue, consumer, limit, timeoutDuration)
+			} else {
+				messages, err = manager.Receive(queue, consumer, limit)
+			}
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal recv", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				if messages == nil {
+					messages = []*models.QueueMessage{}
+				}
+				jsonStr, err := output.FormatJSON("signal recv", map[string]interface{}{
+					"queue":    queue,
+					"consumer": consumer,
+					"messages": messages,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if len(messages) == 0 {
+				fmt.Printf("No new messages on queue '%s' for %s\n", queue, consumer)
+				return nil
+			}
+
+			for _, msg := range messages {
+				fmt.Printf("#%d [%s]\n", msg.ID, msg.SentAt.Format(time.RFC3339))
+				printSignalPayload(msg.SignalPayload)
+			}
+			last := messages[len(messages)-1].ID
+			fmt.Printf("\nAcknowledge with: my-context signal ack %s %d --consumer %s\n", queue, last, consumer)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&consumer, "consumer", "", "Consumer name whose cursor to read from (required)")
+	cmd.Flags().IntVar(&limit, "max", 1, "Maximum number of messages to return (0 for all)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Block until a message is available")
+	cmd.Flags().StringVar(&timeout, "timeout", "5m", "How long --wait blocks (e.g., '30s', '5m', '1h')")
+
+	return cmd
+}
+
+func newSignalAckCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var consumer string
+
+	cmd := &cobra.Command{
+		Use:   "ack <queue> <message-id> --consumer <name>",
+		Short: "Acknowledge signal queue messages",
+		Long: `Acknowledge every message up to and including the given ID for a consumer.
+Acknowledged messages are no longer returned by 'signal recv' for that consumer,
+and once all consumers have acknowledged them 'signal gc' removes them.
+
+Examples:
+  my-context signal ack deb-built 7 --consumer deb-sanity`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queue := args[0]
+
+			id, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				errMsg := fmt.Sprintf("invalid message ID '%s'", args[1])
+				if *jsonOutput {
+					return jsonError("signal ack", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			if consumer == "" {
+				errMsg := "--consumer is required"
+				if *jsonOutput {
+					return jsonError("signal ack", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			// Create signal manager
+			manager, err := newSignalManager(*contextName)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal ack", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			cursor, err := manager.Ack(queue, consumer, id)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal ack", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("signal ack", map[string]interface{}{
+					"queue":    queue,
+					"consumer": cursor.Name,
+					"acked":    cursor.Acked,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("%s acknowledged queue '%s' through #%d\n", cursor.Name, queue, cursor.Acked)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&consumer, "consumer", "", "Consumer name whose cursor to move (required)")
+
+	return cmd
+}
+
+func newSignalQueuesCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queues",
+		Short: "List signal queues and their consumers",
+		Long:  `List every signal queue with its message counters and how far each consumer has acknowledged.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Create signal manager
+			manager, err := newSignalManager(*contextName)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal queues", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			queues, err := manager.ListQueues()
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("signal queues", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("signal queues", map[string]interface{}{"queues": queues})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if len(queues) == 0 {
+				fmt.Println("No queues found")
+				return nil
+			}
+
+			fmt.Println("Queues:")
+			for _, queue := range queues {
+				fmt.Printf("  %s (%d stored, last #%d)\n", queue.Name, queue.Messages, queue.LastID)
+				for _, consumer := range queue.Consumers {
+					fmt.Printf("    %s: acked #%d, %d pending\n", consumer.Name, consumer.Acked, consumer.Pending)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
 	"github.com/jefferycaldwell/my-context-copilot/internal/models"
@@ -66,12 +65,12 @@ func outputStartResult(context *pkgmodels.ContextWithMetadata, originalName, pre
 		data := output.StartData{
 			ContextName:  context.Name,
 			OriginalName: originalName,
-			WasDuplicate: context.Name != core.SanitizeContextName(originalName),
+			WasDuplicate: context.Name != originalName,
 		}
 		if previousContext != "" {
 			data.PreviousContext = &previousContext
 		}
-		jsonStr, err := output.FormatJSON("start", map[string]interface{}{"data": data})
+		jsonStr, err := output.FormatJSON("start", data)
 		if err != nil {
 			return err
 		}
@@ -117,9 +116,7 @@ func NewStartCmd(jsonOutput *bool) *cobra.Command {
 				newName, shouldResume, err := handleDuplicateContext(existingContext, contextName, startForce)
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("start", 3, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("start", output.ExitConflict, err.Error())
 					}
 					return err
 				}
@@ -134,13 +131,24 @@ func NewStartCmd(jsonOutput *bool) *cobra.Command {
 			// Parse labels
 			labels := parseLabels(startLabels)
 
+			// The parent may be given by name, ID, prefix or fuzzy match
+			parent := startParent
+			if parent != "" {
+				ctx, err := resolveContext(parent, nil, *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("start", output.ExitUsage, fmt.Sprintf("parent context: %v", err))
+					}
+					return fmt.Errorf("parent context: %w", err)
+				}
+				parent = ctx.Name
+			}
+
 			// Create the context
-			context, previousContext, err := core.CreateContextWithMetadata(contextName, startCreatedBy, startParent, labels)
+			context, previousContext, err := core.CreateContextWithMetadata(contextName, startCreatedBy, parent, labels)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("start", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("start", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -155,13 +163,16 @@ func NewStartCmd(jsonOutput *bool) *cobra.Command {
 	cmd.Flags().StringVar(&startCreatedBy, "created-by", "", "User who created this context")
 	cmd.Flags().StringVar(&startParent, "parent", "", "Parent context name for hierarchy")
 	cmd.Flags().StringVar(&startLabels, "labels", "", "Comma-separated labels for categorization")
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+	cmd.RegisterFlagCompletionFunc("parent", completeContextFlag)
+	cmd.RegisterFlagCompletionFunc("labels", completeTagList)
 
 	return cmd
 }
 
 // promptResume displays context summary and prompts user to resume or create new
 func promptResume(ctx *models.Context) (bool, error) {
-	noteCount, err := core.GetNoteCount(ctx.Name)
+	noteCount, err := core.CountNotes(ctx.Name)
 	if err != nil {
 		noteCount = 0 // Continue even if we can't get note count
 	}
@@ -213,48 +224,12 @@ func promptNewName(original string) (string, error) {
 
 // resumeExistingContext resumes an existing stopped context
 func resumeExistingContext(ctx *models.Context, jsonOutput *bool) error {
-	// Stop any currently active context
-	state, err := core.GetActiveContext()
+	// Switch from any currently active context
+	previousContext, err := core.SwitchContext(ctx.Name)
 	if err != nil {
 		return err
 	}
 
-	var previousContext string
-	if state.HasActiveContext() {
-		previousContext = state.GetActiveContextName()
-		_, err := core.StopContext()
-		if err != nil {
-			return fmt.Errorf("failed to stop previous context: %w", err)
-		}
-	}
-
-	// Set the existing context as active
-	if err := core.SetActiveContext(ctx.Name); err != nil {
-		return err
-	}
-
-	// Log the transition
-	now := time.Now()
-	var transitionType models.TransitionType
-	if previousContext != "" {
-		transitionType = models.TransitionSwitch
-	} else {
-		transitionType = models.TransitionStart
-	}
-
-	transition := &models.ContextTransition{
-		Timestamp:      now,
-		NewContext:     &ctx.Name,
-		TransitionType: transitionType,
-	}
-	if transitionType == models.TransitionSwitch {
-		transition.PreviousContext = &previousContext
-	}
-
-	if err := core.AppendLog(core.GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
-		return err
-	}
-
 	// Output
 	if *jsonOutput {
 		data := output.StartData{
@@ -265,7 +240,7 @@ func resumeExistingContext(ctx *models.Context, jsonOutput *bool) error {
 		if previousContext != "" {
 			data.PreviousContext = &previousContext
 		}
-		jsonStr, err := output.FormatJSON("start", map[string]interface{}{"data": data})
+		jsonStr, err := output.FormatJSON("start", data)
 		if err != nil {
 			return err
 		}
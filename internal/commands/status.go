@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewStatusCmd(jsonOutput *bool) *cobra.Command {
+	var contextName string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show or change a context's workflow state",
+		Long: `Show a context's workflow state and the states it can move to next.
+
+Contexts move through workflow states such as planned → in-progress → review →
+done → archived. The default workflow can be replaced, and per-project workflows
+added, in workflows.json in the context home:
+
+  {
+    "projects": {
+      "ps-cli": {
+        "states": ["todo", "doing", "qa", "shipped"],
+        "transitions": {"todo": ["doing"], "doing": ["qa"], "qa": ["doing", "shipped"]},
+        "done_states": ["shipped"]
+      }
+    }
+  }
+
+Without --context the active context is used.
+
+Examples:
+  my-context status
+  my-context status set review
+  my-context status set done --context "ps-cli: Auth rework"
+  my-context list --state review`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := resolveStatusContext(contextName, *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("status", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			state, err := core.GetContextState(name)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("status", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			workflow, err := core.WorkflowFor(name)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("status", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+			next := workflow.NextStates(state)
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"context":     name,
+					"state":       state,
+					"done":        workflow.IsDone(state),
+					"next_states": next,
+					"workflow":    workflow,
+				}
+				jsonStr, err := output.FormatJSON("status", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			fmt.Printf("Context:  %s\n", name)
+			if state == "" {
+				fmt.Println("State:    (not set)")
+			} else {
+				fmt.Printf("State:    %s\n", state)
+			}
+			fmt.Printf("Workflow: %s\n", strings.Join(workflow.States, " → "))
+			if len(next) > 0 {
+				fmt.Printf("Next:     %s\n", strings.Join(next, ", "))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&contextName, "context", "", "Context to use (default: active context)")
+
+	cmd.AddCommand(newStatusSetCmd(jsonOutput, &contextName))
+	cmd.RegisterFlagCompletionFunc("context", completeContextFlag)
+
+	return cmd
+}
+
+func newStatusSetCmd(jsonOutput *bool, contextName *string) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "set <state>",
+		Short: "Move a context to a workflow state",
+		Long: `Move a context to a workflow state.
+
+The state must be defined by the context's workflow, and the move must be one
+the workflow allows from the current state. A context with no state yet can be
+moved to any state. Use --force to skip the transition check.
+
+State changes can be reverted with 'my-context undo'.
+
+Examples:
+  my-context status set in-progress
+  my-context status set review --context "ps-cli: Auth rework"
+  my-context status set planned --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := resolveStatusContext(*contextName, *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("status set", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			change, err := core.SetContextState(name, args[0], force)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("status set", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("status set", change)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else if change.From == "" {
+				fmt.Printf("✓ \"%s\" is now %s\n", change.Context, change.To)
+			} else {
+				fmt.Printf("✓ \"%s\": %s → %s\n", change.Context, change.From, change.To)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Allow a transition the workflow doesn't permit")
+
+	return cmd
+}
+
+// resolveStatusContext returns the named context, or the active one if no name was given
+func resolveStatusContext(name string, jsonOutput bool) (string, error) {
+	if name != "" {
+		return resolveContextName(name, jsonOutput)
+	}
+
+	state, err := core.GetActiveContext()
+	if err != nil {
+		return "", err
+	}
+	if !state.HasActiveContext() {
+		return "", fmt.Errorf("no active context (use --context to pick one)")
+	}
+	return state.GetActiveContextName(), nil
+}
@@ -21,9 +21,7 @@ func NewStopCmd(jsonOutput *bool) *cobra.Command {
 			context, err := core.StopContext()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("stop", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("stop", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -50,7 +48,7 @@ func NewStopCmd(jsonOutput *bool) *cobra.Command {
 					EndTime:         *context.EndTime,
 					DurationSeconds: durationSeconds,
 				}
-				jsonStr, err := output.FormatJSON("stop", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("stop", data)
 				if err != nil {
 					return err
 				}
@@ -78,7 +76,7 @@ func NewStopCmd(jsonOutput *bool) *cobra.Command {
 // displayLifecycleGuidance shows helpful suggestions after stopping a context
 func displayLifecycleGuidance(context *models.Context) error {
 	// Get context summary information
-	noteCount, err := core.GetNoteCount(context.Name)
+	noteCount, err := core.CountNotes(context.Name)
 	if err != nil {
 		return fmt.Errorf("failed to get note count: %w", err)
 	}
@@ -101,8 +99,13 @@ func displayLifecycleGuidance(context *models.Context) error {
 		return fmt.Errorf("failed to find related contexts: %w", err)
 	}
 
-	// Detect completion
+	// A workflow state says whether the work is done; otherwise guess from the notes
 	isComplete := detectCompletion(notes)
+	if state, err := core.GetContextState(context.Name); err == nil && state != "" {
+		if workflow, err := core.WorkflowFor(context.Name); err == nil {
+			isComplete = workflow.IsDone(state)
+		}
+	}
 
 	// Display guidance
 	displayGuidance(context, relatedContexts, isComplete)
@@ -39,18 +39,23 @@ func newTagAddCmd(jsonOutput *bool) *cobra.Command {
 Examples:
   my-context tag add "Bug fix" bug urgent
   my-context tag add "Sprint 3" feature frontend`,
-		Args: cobra.MinimumNArgs(2),
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeTagArgs(false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			contextName := args[0]
+			contextName, err := resolveContextName(args[0], *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("tag add", output.ExitUsage, err.Error())
+				}
+				return err
+			}
 			tags := args[1:]
 
 			// Validate tags
 			for _, tag := range tags {
 				if strings.ContainsAny(tag, " \t\n\r") {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("tag_add", 1, fmt.Sprintf("tag '%s' cannot contain whitespace", tag))
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("tag add", output.ExitUsage, fmt.Sprintf("tag '%s' cannot contain whitespace", tag))
 					}
 					return fmt.Errorf("tag '%s' cannot contain whitespace", tag)
 				}
@@ -60,9 +65,7 @@ Examples:
 			added, err := core.AddTags(contextName, tags)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("tag_add", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("tag add", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -73,7 +76,7 @@ Examples:
 					"context":    contextName,
 					"added_tags": added,
 				}
-				jsonStr, err := output.FormatJSON("tag_add", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("tag add", data)
 				if err != nil {
 					return err
 				}
@@ -110,8 +113,15 @@ Examples:
 			}
 			return cobra.MinimumNArgs(2)(cmd, args)
 		},
+		ValidArgsFunction: completeTagArgs(true),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			contextName := args[0]
+			contextName, err := resolveContextName(args[0], *jsonOutput)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("tag remove", output.ExitUsage, err.Error())
+				}
+				return err
+			}
 
 			var tags []string
 			if removeAll {
@@ -119,9 +129,7 @@ Examples:
 				allTags, err := core.GetContextTags(contextName)
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("tag_remove", 2, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("tag remove", output.ExitFailure, err.Error())
 					}
 					return err
 				}
@@ -134,9 +142,7 @@ Examples:
 			removed, err := core.RemoveTags(contextName, tags)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("tag_remove", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("tag remove", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -147,7 +153,7 @@ Examples:
 					"context":      contextName,
 					"removed_tags": removed,
 				}
-				jsonStr, err := output.FormatJSON("tag_remove", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("tag remove", data)
 				if err != nil {
 					return err
 				}
@@ -181,28 +187,36 @@ func newTagListCmd(jsonOutput *bool) *cobra.Command {
 Examples:
   my-context tag list              # List all tags with usage counts
   my-context tag list "Bug fix"    # List tags for specific context`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 1 {
 				// List tags for specific context
-				contextName := args[0]
+				contextName, err := resolveContextName(args[0], *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("tag list", output.ExitUsage, err.Error())
+					}
+					return err
+				}
 				tags, err := core.GetContextTags(contextName)
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("tag_list", 2, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("tag list", output.ExitFailure, err.Error())
 					}
 					return err
 				}
 
 				// Output
 				if *jsonOutput {
+					if tags == nil {
+						tags = []string{}
+					}
 					data := map[string]interface{}{
 						"context": contextName,
 						"tags":    tags,
 					}
-					jsonStr, err := output.FormatJSON("tag_list", map[string]interface{}{"data": data})
+					jsonStr, err := output.FormatJSON("tag list", data)
 					if err != nil {
 						return err
 					}
@@ -219,9 +233,7 @@ Examples:
 				tagCounts, err := core.GetAllTags()
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("tag_list", 2, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("tag list", output.ExitFailure, err.Error())
 					}
 					return err
 				}
@@ -231,7 +243,7 @@ Examples:
 					data := map[string]interface{}{
 						"tags": tagCounts,
 					}
-					jsonStr, err := output.FormatJSON("tag_list", map[string]interface{}{"data": data})
+					jsonStr, err := output.FormatJSON("tag list", data)
 					if err != nil {
 						return err
 					}
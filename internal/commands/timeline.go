@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func NewTimelineCmd(jsonOutput *bool) *cobra.Command {
+	var day, week bool
+	var since string
+	var project string
+	var width int
+	var noColor, ascii bool
+
+	cmd := &cobra.Command{
+		Use:     "timeline",
+		Aliases: []string{"tl"},
+		Short:   "Chart when each context was active",
+		Long: `Draw a Gantt-style chart of your work: one row per context, bars where the
+context was active and markers where notes were written, over a time axis.
+Each row ends with the time spent in the context during the window.
+
+--day (the default) charts today and --week the last seven days, both up to
+now. --since takes the same values as 'digest --since'.
+
+The chart fills the terminal width (or $COLUMNS, or 80 columns; --width
+overrides it). Rows are colored on a terminal unless NO_COLOR is set or
+--no-color is given. When the output isn't a terminal, or with --ascii, the
+chart is drawn in plain ASCII.
+
+Examples:
+  my-context timeline
+  my-context timeline --week --project ps-cli
+  my-context timeline --since 3d --ascii > timeline.txt`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fail := func(code int, err error) error {
+				if *jsonOutput {
+					return jsonError("timeline", code, err.Error())
+				}
+				return err
+			}
+
+			windows := 0
+			for _, set := range []bool{day, week, since != ""} {
+				if set {
+					windows++
+				}
+			}
+			if windows > 1 {
+				return fail(output.ExitUsage, fmt.Errorf("use only one of --day, --week and --since"))
+			}
+
+			now := time.Now()
+			window := "today"
+			if week {
+				window = "week"
+			} else if since != "" {
+				window = since
+			}
+			from, err := core.ParseSince(window, now)
+			if err != nil {
+				return fail(output.ExitUsage, err)
+			}
+
+			rows, err := core.GetTimeline(from, now, project)
+			if err != nil {
+				return fail(output.ExitFailure, err)
+			}
+
+			if *jsonOutput {
+				jsonStr, err := output.FormatJSON("timeline", map[string]interface{}{
+					"from": from,
+					"to":   now,
+					"rows": rows,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			isTerminal := term.IsTerminal(int(os.Stdout.Fd()))
+			opts := output.TimelineOptions{
+				Width: width,
+				Color: isTerminal && !noColor && os.Getenv("NO_COLOR") == "",
+				ASCII: ascii || !isTerminal,
+			}
+			if opts.Width <= 0 {
+				opts.Width = terminalWidth(isTerminal)
+			}
+
+			fmt.Print(output.FormatTimeline(rows, from, now, opts))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&day, "day", false, "Chart today (default)")
+	cmd.Flags().BoolVar(&week, "week", false, "Chart the last seven days")
+	cmd.Flags().StringVar(&since, "since", "", "Chart from this time on (today, yesterday, week, 3d, 36h, 2006-01-02)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "Only include contexts of this project")
+	cmd.Flags().IntVar(&width, "width", 0, "Chart width in columns (default: terminal width)")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "Don't color the chart")
+	cmd.Flags().BoolVar(&ascii, "ascii", false, "Draw with plain ASCII characters")
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+
+	return cmd
+}
+
+// terminalWidth returns the width of the terminal on stdout, then $COLUMNS, then 80
+func terminalWidth(isTerminal bool) int {
+	if isTerminal {
+		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+			return w
+		}
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
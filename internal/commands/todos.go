@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewTodosCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		includeDone     bool
+		includeArchived bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "todos",
+		Short: "List open todo notes across contexts",
+		Long: `List todo notes (added with 'note --type todo') from every context, grouped
+by context. Each todo has an ID, <context-id>#<note-number>, to close it with
+'note done'.
+
+Examples:
+  my-context todos
+  my-context todos --done        # include closed todos
+  my-context note done k3x9fq#2`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			todos, err := core.ListTodos(includeDone, includeArchived)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("todos", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"todos": todos,
+				}
+				jsonStr, err := output.FormatJSON("todos", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if len(todos) == 0 {
+				fmt.Println("No open todos")
+				return nil
+			}
+
+			fmt.Printf("Todos (%d):\n", len(todos))
+			lastContext := ""
+			for _, todo := range todos {
+				if todo.Context != lastContext {
+					fmt.Printf("\n  %s\n", todo.Context)
+					lastContext = todo.Context
+				}
+				box := "[ ]"
+				if todo.Note.Done {
+					box = "[x]"
+				}
+				fmt.Printf("    %s %-10s %s (%s ago)\n", box, todo.Ref, todo.Note.TextContent,
+					output.FormatDuration(time.Since(todo.Note.Timestamp)))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeDone, "done", false, "Include todos that are done")
+	cmd.Flags().BoolVar(&includeArchived, "archived", false, "Include todos of archived contexts")
+
+	return cmd
+}
@@ -21,9 +21,7 @@ func NewTouchCmd(jsonOutput *bool) *cobra.Command {
 			state, err := core.GetActiveContext()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("touch", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("touch", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -31,9 +29,7 @@ func NewTouchCmd(jsonOutput *bool) *cobra.Command {
 			if !state.HasActiveContext() {
 				errMsg := "No active context. Start a context with: my-context start <name>"
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("touch", 1, errMsg)
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("touch", output.ExitUsage, errMsg)
 				}
 				return errors.New(errMsg)
 			}
@@ -42,9 +38,7 @@ func NewTouchCmd(jsonOutput *bool) *cobra.Command {
 			touch, err := core.AddTouch()
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("touch", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("touch", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -55,7 +49,7 @@ func NewTouchCmd(jsonOutput *bool) *cobra.Command {
 					ContextName:    state.GetActiveContextName(),
 					TouchTimestamp: touch.Timestamp,
 				}
-				jsonStr, err := output.FormatJSON("touch", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("touch", data)
 				if err != nil {
 					return err
 				}
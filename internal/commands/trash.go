@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewTrashCmd(jsonOutput *bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Manage deleted contexts",
+		Long: `Manage contexts that were deleted with 'delete' or trashed by 'gc'.
+
+Deleted contexts are kept in ~/.my-context/.trash/ until they are purged.
+
+Subcommands:
+  list     - List trashed contexts
+  restore  - Move a trashed context back into the context home
+  empty    - Permanently remove trashed contexts`,
+	}
+
+	cmd.AddCommand(newTrashListCmd(jsonOutput))
+	cmd.AddCommand(newTrashRestoreCmd(jsonOutput))
+	cmd.AddCommand(newTrashEmptyCmd(jsonOutput))
+
+	return cmd
+}
+
+func newTrashListCmd(jsonOutput *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "l"},
+		Short:   "List trashed contexts",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Drop anything past its retention age before showing the list
+			if _, err := core.PurgeExpiredTrash(); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: Could not purge expired trash: %v\n", err)
+			}
+
+			trashed, err := core.ListTrash()
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("trash list", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"entries": trashed,
+				}
+				jsonStr, err := output.FormatJSON("trash list", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				return nil
+			}
+
+			if len(trashed) == 0 {
+				fmt.Println("Trash is empty")
+				return nil
+			}
+
+			fmt.Printf("Trashed contexts (%d):\n\n", len(trashed))
+			for _, entry := range trashed {
+				fmt.Printf("  %s\n", entry.ContextName)
+				fmt.Printf("    ID: %s\n", entry.ID)
+				fmt.Printf("    Deleted: %s (%s ago)\n",
+					entry.DeletedAt.Format("2006-01-02 15:04:05"),
+					output.FormatDuration(time.Since(entry.DeletedAt)))
+				if entry.Reason != "" {
+					fmt.Printf("    Reason: %s\n", entry.Reason)
+				}
+				fmt.Printf("    Notes: %d\n\n", entry.NoteCount)
+			}
+			fmt.Println("Restore with: my-context trash restore <name|id>")
+
+			return nil
+		},
+	}
+}
+
+func newTrashRestoreCmd(jsonOutput *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <name|id>",
+		Short: "Restore a trashed context",
+		Long: `Move a trashed context back into the context home.
+
+If the same name was deleted more than once, the most recent deletion is restored.
+Use the ID from 'trash list' to pick a specific one.
+
+Examples:
+  my-context trash restore "ps-cli: Phase 1"
+  my-context trash restore 20251022T101500_ps-cli__Phase_1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entry, err := core.RestoreFromTrash(args[0])
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("trash restore", output.ExitUsage, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"context": entry.ContextName,
+					"id":      entry.ID,
+					"path":    entry.Path,
+				}
+				jsonStr, err := output.FormatJSON("trash restore", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("✓ Restored context: %s\n", entry.ContextName)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newTrashEmptyCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		olderThan int
+		force     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Permanently remove trashed contexts",
+		Long: `Permanently remove trashed contexts. This cannot be undone.
+
+Examples:
+  my-context trash empty
+  my-context trash empty --older-than 7
+  my-context trash empty --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var before time.Time
+			if olderThan > 0 {
+				before = time.Now().Add(-time.Duration(olderThan) * 24 * time.Hour)
+			}
+
+			// Confirmation prompt (unless --force); JSON output can't be mixed with a prompt
+			if !force && *jsonOutput {
+				return jsonError("trash empty", output.ExitUsage, "trash empty with --json needs --force")
+			}
+			if !force {
+				fmt.Print("⚠️  WARNING: This will permanently remove trashed contexts. Continue? (yes/no): ")
+
+				reader := bufio.NewReader(os.Stdin)
+				response, err := reader.ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("failed to read confirmation: %w", err)
+				}
+
+				response = strings.TrimSpace(strings.ToLower(response))
+				if response != "yes" && response != "y" {
+					fmt.Println("Empty trash canceled.")
+					return nil
+				}
+			}
+
+			removed, err := core.EmptyTrash(before)
+			if err != nil {
+				if *jsonOutput {
+					return jsonError("trash empty", output.ExitFailure, err.Error())
+				}
+				return err
+			}
+
+			// Output
+			if *jsonOutput {
+				if removed == nil {
+					removed = []*core.TrashEntry{}
+				}
+				data := map[string]interface{}{
+					"removed": removed,
+				}
+				jsonStr, err := output.FormatJSON("trash empty", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+			} else {
+				fmt.Printf("Permanently removed %d trashed context(s)\n", len(removed))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&olderThan, "older-than", 0, "Only remove entries deleted more than this many days ago")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+
+	return cmd
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
@@ -10,23 +9,29 @@ import (
 )
 
 // showSingleContextTree shows the tree for a specific context
-func showSingleContextTree(contextName string, jsonOutput *bool) error {
+func showSingleContextTree(contextName, stateFilter string, jsonOutput *bool) error {
 	tree, err := core.GetContextTree(contextName)
 	if err != nil {
 		if *jsonOutput {
-			jsonStr, _ := output.FormatJSONError("tree", 1, err.Error())
-			fmt.Print(jsonStr)
-			return nil
+			return jsonError("tree", output.ExitUsage, err.Error())
 		}
 		return err
 	}
 
+	if stateFilter != "" {
+		tree = core.FilterTreeByState(tree, stateFilter)
+	}
+
 	if *jsonOutput {
-		jsonStr, err := json.MarshalIndent(tree, "", "  ")
-		if err != nil {
-			return err
+		trees := []*core.ContextTreeNode{}
+		if tree != nil {
+			trees = append(trees, tree)
 		}
-		fmt.Println(string(jsonStr))
+		return printTreeJSON(trees)
+	}
+
+	if tree == nil {
+		fmt.Printf("No contexts under \"%s\" are in state %s\n", contextName, stateFilter)
 	} else {
 		fmt.Printf("Context hierarchy for \"%s\":\n\n", contextName)
 		printTree(tree, "", true)
@@ -35,25 +40,20 @@ func showSingleContextTree(contextName string, jsonOutput *bool) error {
 }
 
 // showAllRootContexts shows all root contexts
-func showAllRootContexts(jsonOutput *bool) error {
+func showAllRootContexts(stateFilter string, jsonOutput *bool) error {
 	roots, err := core.GetRootContexts()
 	if err != nil {
 		if *jsonOutput {
-			jsonStr, _ := output.FormatJSONError("tree", 2, err.Error())
-			fmt.Print(jsonStr)
-			return nil
+			return jsonError("tree", output.ExitFailure, err.Error())
 		}
 		return err
 	}
 
 	if len(roots) == 0 {
 		if *jsonOutput {
-			data := map[string]interface{}{"message": "No contexts found"}
-			jsonStr, _ := output.FormatJSON("tree", map[string]interface{}{"data": data})
-			fmt.Print(jsonStr)
-		} else {
-			fmt.Println("No contexts found")
+			return printTreeJSON([]*core.ContextTreeNode{})
 		}
+		fmt.Println("No contexts found")
 		return nil
 	}
 
@@ -64,17 +64,22 @@ func showAllRootContexts(jsonOutput *bool) error {
 		if err != nil {
 			continue
 		}
+		if stateFilter != "" {
+			if tree = core.FilterTreeByState(tree, stateFilter); tree == nil {
+				continue
+			}
+		}
 		trees = append(trees, tree)
 	}
 
 	if *jsonOutput {
-		jsonStr, err := json.MarshalIndent(trees, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonStr))
+		return printTreeJSON(trees)
+	}
+
+	if stateFilter != "" && len(trees) == 0 {
+		fmt.Printf("No contexts in state %s\n", stateFilter)
 	} else {
-		fmt.Printf("Context hierarchies (%d root contexts):\n\n", len(roots))
+		fmt.Printf("Context hierarchies (%d root contexts):\n\n", len(trees))
 		for i, tree := range trees {
 			printTree(tree, "", true)
 			if i < len(trees)-1 {
@@ -85,7 +90,19 @@ func showAllRootContexts(jsonOutput *bool) error {
 	return nil
 }
 
+// printTreeJSON prints context trees in the JSON envelope
+func printTreeJSON(trees []*core.ContextTreeNode) error {
+	jsonStr, err := output.FormatJSON("tree", map[string]interface{}{"trees": trees})
+	if err != nil {
+		return err
+	}
+	fmt.Print(jsonStr)
+	return nil
+}
+
 func NewTreeCmd(jsonOutput *bool) *cobra.Command {
+	var stateFilter string
+
 	cmd := &cobra.Command{
 		Use:   "tree [context]",
 		Short: "Show context hierarchy as a tree",
@@ -94,32 +111,48 @@ If no context is specified, shows all root contexts (contexts without parents).
 
 Examples:
   my-context tree              # Show all root contexts and their children
-  my-context tree "Sprint 3"   # Show tree starting from specific context`,
-		Args: cobra.MaximumNArgs(1),
+  my-context tree "Sprint 3"   # Show tree starting from specific context
+  my-context tree --state review  # Only contexts in review, with their parents`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 1 {
-				return showSingleContextTree(args[0], jsonOutput)
+				contextName, err := resolveContextName(args[0], *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("tree", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				return showSingleContextTree(contextName, stateFilter, jsonOutput)
 			}
-			return showAllRootContexts(jsonOutput)
+			return showAllRootContexts(stateFilter, jsonOutput)
 		},
 	}
 
+	cmd.Flags().StringVar(&stateFilter, "state", "", "Only show contexts in this workflow state (and their parents)")
+
 	return cmd
 }
 
 // printTree prints a tree structure with proper indentation and branch characters
 func printTree(node *core.ContextTreeNode, prefix string, isLast bool) {
+	label := node.Name
+	if node.State != "" {
+		label = fmt.Sprintf("%s [%s]", node.Name, node.State)
+	}
+
 	// Print current node
 	if prefix == "" {
 		// Root node
-		fmt.Printf("└─ %s\n", node.Name)
+		fmt.Printf("└─ %s\n", label)
 	} else {
 		// Child node
 		marker := "├─"
 		if isLast {
 			marker = "└─"
 		}
-		fmt.Printf("%s%s %s\n", prefix, marker, node.Name)
+		fmt.Printf("%s%s %s\n", prefix, marker, label)
 	}
 
 	// Print children
@@ -155,29 +188,33 @@ If a context name is provided, shows the parent of that context.
 Examples:
   my-context up              # Show parent of active context
   my-context up "Bug fix"    # Show parent of specific context`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var contextName string
 
 			if len(args) == 1 {
-				contextName = args[0]
+				name, err := resolveContextName(args[0], *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("up", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				contextName = name
 			} else {
 				// Get active context
 				state, err := core.GetActiveContext()
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("up", 2, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("up", output.ExitFailure, err.Error())
 					}
 					return err
 				}
 
 				if !state.HasActiveContext() {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("up", 1, "no active context")
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("up", output.ExitUsage, "no active context")
 					}
 					return fmt.Errorf("no active context")
 				}
@@ -189,9 +226,7 @@ Examples:
 			ctx, _, _, _, err := core.GetContextWithMetadata(contextName)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("up", 1, fmt.Sprintf("context %q not found", contextName))
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("up", output.ExitUsage, fmt.Sprintf("context %q not found", contextName))
 				}
 				return fmt.Errorf("context %q not found", contextName)
 			}
@@ -202,7 +237,7 @@ Examples:
 						"context": contextName,
 						"message": "no parent",
 					}
-					jsonStr, _ := output.FormatJSON("up", map[string]interface{}{"data": data})
+					jsonStr, _ := output.FormatJSON("up", data)
 					fmt.Print(jsonStr)
 					return nil
 				}
@@ -216,7 +251,7 @@ Examples:
 					"context": contextName,
 					"parent":  ctx.Metadata.Parent,
 				}
-				jsonStr, err := output.FormatJSON("up", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("up", data)
 				if err != nil {
 					return err
 				}
@@ -241,29 +276,33 @@ If a context name is provided, lists children of that context.
 Examples:
   my-context down              # List children of active context
   my-context down "Sprint 3"   # List children of specific context`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var contextName string
 
 			if len(args) == 1 {
-				contextName = args[0]
+				name, err := resolveContextName(args[0], *jsonOutput)
+				if err != nil {
+					if *jsonOutput {
+						return jsonError("down", output.ExitUsage, err.Error())
+					}
+					return err
+				}
+				contextName = name
 			} else {
 				// Get active context
 				state, err := core.GetActiveContext()
 				if err != nil {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("down", 2, err.Error())
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("down", output.ExitFailure, err.Error())
 					}
 					return err
 				}
 
 				if !state.HasActiveContext() {
 					if *jsonOutput {
-						jsonStr, _ := output.FormatJSONError("down", 1, "no active context")
-						fmt.Print(jsonStr)
-						return nil
+						return jsonError("down", output.ExitUsage, "no active context")
 					}
 					return fmt.Errorf("no active context")
 				}
@@ -274,9 +313,7 @@ Examples:
 			// Verify context exists
 			if _, err := core.LoadContext(contextName); err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("down", 1, fmt.Sprintf("context %q not found", contextName))
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("down", output.ExitUsage, fmt.Sprintf("context %q not found", contextName))
 				}
 				return fmt.Errorf("context %q not found", contextName)
 			}
@@ -285,9 +322,7 @@ Examples:
 			children, err := core.GetChildren(contextName)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("down", 2, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("down", output.ExitFailure, err.Error())
 				}
 				return err
 			}
@@ -298,7 +333,7 @@ Examples:
 					"context":  contextName,
 					"children": children,
 				}
-				jsonStr, err := output.FormatJSON("down", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("down", data)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/jefferycaldwell/my-context-copilot/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+func NewUICmd(jsonOutput *bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ui",
+		Short: "Browse and manage contexts in a full-screen terminal interface",
+		Long: `Open a full-screen browser of your contexts. The list shows every context
+with its workflow state; the details pane shows the selected context's metadata,
+notes and files.
+
+From the browser you can switch to a context (stopping the active one), stop the
+active context, add notes, tag, link to a parent and archive or unarchive, with
+the same effect as the matching commands.
+
+Keys:
+  ↑/k ↓/j       move                  enter   show details
+  /             filter by name, #label or state:x
+  A             show or hide archived contexts
+  s             switch to the selected context
+  x             stop the active context
+  n             add a note to the active context
+  t             tag the selected context
+  L             link the selected context to a parent
+  a             archive or unarchive the selected context
+  ?             help                  q       quit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if *jsonOutput {
+				return jsonError("ui", output.ExitUsage, "ui is interactive and has no JSON output")
+			}
+			if err := tui.Run(os.Stdin, os.Stdout); err != nil {
+				return fmt.Errorf("ui: %w", err)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func NewUndoCmd(jsonOutput *bool) *cobra.Command {
+	var (
+		count int
+		list  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Revert the last operation",
+		Long: `Revert the most recent start, stop, resume, switch, handoff accept, note,
+file, tag, link, archive or status set operation.
+
+Undoing a start removes the new context (it is moved to the trash) and makes the
+previously active context active again. Undoing a stop re-activates the context;
+undoing a resume, switch or handoff accept makes the previously active context
+active again. Signals raised by a handoff accept are left as they are.
+Up to 100 operations are kept in journal.json in the context home.
+
+Examples:
+  my-context undo
+  my-context undo -n 3
+  my-context undo --list`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list {
+				return outputJournal(jsonOutput)
+			}
+
+			if count < 1 {
+				errMsg := "-n must be at least 1"
+				if *jsonOutput {
+					return jsonError("undo", output.ExitUsage, errMsg)
+				}
+				return fmt.Errorf("%s", errMsg)
+			}
+
+			var undone []*core.JournalEntry
+			var undoErr error
+			for i := 0; i < count; i++ {
+				entry, err := core.UndoLast()
+				if err != nil {
+					undoErr = err
+					break
+				}
+				undone = append(undone, entry)
+			}
+
+			// Nothing could be undone at all
+			if len(undone) == 0 && undoErr != nil {
+				if *jsonOutput {
+					return jsonError("undo", output.ExitUsage, undoErr.Error())
+				}
+				return undoErr
+			}
+
+			// Output
+			if *jsonOutput {
+				data := map[string]interface{}{
+					"undone": undone,
+				}
+				if undoErr != nil {
+					data["error"] = undoErr.Error()
+				}
+				jsonStr, err := output.FormatJSON("undo", data)
+				if err != nil {
+					return err
+				}
+				fmt.Print(jsonStr)
+				if undoErr != nil {
+					return partialFailure("undo", count-len(undone))
+				}
+				return nil
+			}
+
+			for _, entry := range undone {
+				fmt.Printf("↩️  Undid: %s\n", entry.Summary)
+			}
+			if undoErr != nil {
+				fmt.Printf("Stopped after %d of %d: %v\n", len(undone), count, undoErr)
+			}
+
+			state, err := core.GetActiveContext()
+			if err == nil {
+				if state.HasActiveContext() {
+					fmt.Printf("Active context: %s\n", state.GetActiveContextName())
+				} else {
+					fmt.Println("No active context")
+				}
+			}
+
+			if undoErr != nil {
+				return partialFailure("undo", count-len(undone))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&count, "count", "n", 1, "Number of operations to undo")
+	cmd.Flags().BoolVar(&list, "list", false, "Show undoable operations, most recent first")
+
+	return cmd
+}
+
+// outputJournal displays the operations that can be undone
+func outputJournal(jsonOutput *bool) error {
+	entries, err := core.ReadJournal()
+	if err != nil {
+		if *jsonOutput {
+			return jsonError("undo", output.ExitFailure, err.Error())
+		}
+		return err
+	}
+
+	// Most recent first - that is the order undo walks them
+	recent := make([]*core.JournalEntry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		recent = append(recent, entries[i])
+	}
+
+	if *jsonOutput {
+		data := map[string]interface{}{
+			"operations": recent,
+		}
+		jsonStr, err := output.FormatJSON("undo", data)
+		if err != nil {
+			return err
+		}
+		fmt.Print(jsonStr)
+		return nil
+	}
+
+	if len(recent) == 0 {
+		fmt.Println("Nothing to undo")
+		return nil
+	}
+
+	fmt.Printf("Undoable operations (%d):\n", len(recent))
+	for i, entry := range recent {
+		fmt.Printf("  %d. %s  %s\n", i+1, entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Summary)
+	}
+	return nil
+}
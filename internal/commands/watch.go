@@ -13,9 +13,9 @@ import (
 )
 
 // getContextNameOrActive returns the specified context name or active context
-func getContextNameOrActive(args []string) (string, error) {
+func getContextNameOrActive(args []string, jsonOutput bool) (string, error) {
 	if len(args) > 0 {
-		return args[0], nil
+		return resolveContextName(args[0], jsonOutput)
 	}
 
 	state, err := core.GetActiveContext()
@@ -105,10 +105,11 @@ Examples:
 
   # Watch and execute command on changes
   my-context watch --exec="notify-send 'Context updated'"`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeContexts(1, nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get context name
-			contextName, err := getContextNameOrActive(args)
+			contextName, err := getContextNameOrActive(args, *jsonOutput)
 			if err != nil {
 				return err
 			}
@@ -144,9 +145,7 @@ Examples:
 			watcher, err := watch.NewWatcher(contextDir, options)
 			if err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("watch", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("watch", output.ExitUsage, err.Error())
 				}
 				return err
 			}
@@ -154,9 +153,7 @@ Examples:
 			// Start watching
 			if err := watcher.Start(); err != nil {
 				if *jsonOutput {
-					jsonStr, _ := output.FormatJSONError("watch", 1, err.Error())
-					fmt.Print(jsonStr)
-					return nil
+					return jsonError("watch", output.ExitUsage, err.Error())
 				}
 				return err
 			}
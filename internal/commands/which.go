@@ -48,7 +48,7 @@ which context home is currently active.`,
 					"env_set":              envSet,
 					"env_value":            envValue,
 				}
-				jsonStr, err := output.FormatJSON("which", map[string]interface{}{"data": data})
+				jsonStr, err := output.FormatJSON("which", data)
 				if err != nil {
 					return err
 				}
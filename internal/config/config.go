@@ -0,0 +1,387 @@
+// Package config resolves my-context settings from flags, environment
+// variables and config.yaml files.
+//
+// Precedence, highest first: --set flag > environment variable > project
+// section of config.yaml > top level of config.yaml > built-in default.
+//
+// Per-project overrides live only in the projects section of the config.yaml
+// in the context home; there is no config file inside a project.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the config file in the context home
+const FileName = "config.yaml"
+
+// Value sources, as reported by Lookup
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceProject = "project"
+	SourceHome    = "home"
+	SourceDefault = "default"
+)
+
+// Kinds of values a key can hold
+const (
+	KindString = "string"
+	KindInt    = "int"
+)
+
+// Key describes one setting
+type Key struct {
+	Name        string `json:"name"`
+	Env         string `json:"env"`
+	Kind        string `json:"kind"`
+	Default     string `json:"default"`
+	Description string `json:"description"`
+}
+
+// Keys lists every supported setting
+var Keys = []Key{
+	{Name: "timestamp_format", Env: "MC_TIMESTAMP_FORMAT", Kind: KindString, Default: "iso",
+		Description: "Timestamp format: short, medium, long, iso or a Go time layout"},
+	{Name: "warn_at", Env: "MC_WARN_AT", Kind: KindInt, Default: "50",
+		Description: "Note count that triggers the first large-context warning"},
+	{Name: "warn_at_2", Env: "MC_WARN_AT_2", Kind: KindInt, Default: "100",
+		Description: "Note count that triggers the second large-context warning"},
+	{Name: "warn_at_3", Env: "MC_WARN_AT_3", Kind: KindInt, Default: "200",
+		Description: "Note count after which a warning is shown every 25 notes"},
+	{Name: "bulk_limit", Env: "MC_BULK_LIMIT", Kind: KindInt, Default: "100",
+		Description: "Maximum number of contexts a bulk archive may touch"},
+	{Name: "archive_after_days", Env: "MC_ARCHIVE_AFTER_DAYS", Kind: KindInt, Default: "0",
+		Description: "gc archives stopped contexts idle this many days (0 = never)"},
+	{Name: "delete_after_days", Env: "MC_DELETE_AFTER_DAYS", Kind: KindInt, Default: "0",
+		Description: "gc moves archived contexts idle this many days to the trash (0 = never)"},
+	{Name: "trash_purge_days", Env: "MC_TRASH_PURGE_DAYS", Kind: KindInt, Default: "30",
+		Description: "Trash entries older than this many days are purged (0 = never)"},
+}
+
+// Settings holds every setting as a typed value
+type Settings struct {
+	TimestampFormat  string
+	WarnAt           int
+	WarnAt2          int
+	WarnAt3          int
+	BulkLimit        int
+	ArchiveAfterDays int
+	DeleteAfterDays  int
+	TrashPurgeDays   int
+}
+
+// Value is a resolved setting and where it came from
+type Value struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// File is the contents of config.yaml: top-level settings plus per-project overrides
+type File struct {
+	Values   map[string]string            `yaml:",inline"`
+	Projects map[string]map[string]string `yaml:"projects,omitempty"`
+}
+
+// Config resolves settings for one invocation
+type Config struct {
+	path      string
+	project   string
+	file      *File
+	overrides map[string]string
+}
+
+var current *Config
+
+// SetCurrent makes a loaded config the one returned by Current
+func SetCurrent(c *Config) {
+	current = c
+}
+
+// Current returns the config loaded for this invocation. Before one is loaded
+// only environment variables and defaults apply.
+func Current() *Config {
+	if current == nil {
+		return &Config{file: &File{}}
+	}
+	return current
+}
+
+// Get returns the typed settings of the current config
+func Get() Settings {
+	return Current().Settings()
+}
+
+// Path returns the path of the config file in a context home
+func Path(home string) string {
+	return filepath.Join(home, FileName)
+}
+
+// FindKey returns the key with the given name
+func FindKey(name string) (Key, bool) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// Load reads config.yaml from the context home. project selects the project
+// section to apply (empty for none); overrides are key=value pairs from flags.
+func Load(home, project string, overrides []string) (*Config, error) {
+	c := &Config{path: Path(home), project: project, file: &File{}}
+
+	parsed, err := parseOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	c.overrides = parsed
+
+	file, err := readFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	c.file = file
+
+	return c, nil
+}
+
+// FilePath returns the path of the file the config was loaded from
+func (c *Config) FilePath() string {
+	return c.path
+}
+
+// Project returns the project whose section applies
+func (c *Config) Project() string {
+	return c.project
+}
+
+// WithProject returns a copy of the config that applies another project's section
+func (c *Config) WithProject(project string) *Config {
+	copied := *c
+	copied.project = project
+	return &copied
+}
+
+// Lookup resolves a setting through the precedence chain
+func (c *Config) Lookup(name string) (Value, error) {
+	key, ok := FindKey(name)
+	if !ok {
+		return Value{}, fmt.Errorf("unknown config key %q (known keys: %s)", name, strings.Join(keyNames(), ", "))
+	}
+
+	if v, ok := c.overrides[key.Name]; ok && key.valid(v) {
+		return Value{Key: key.Name, Value: v, Source: SourceFlag}, nil
+	}
+	if v := os.Getenv(key.Env); v != "" && key.valid(v) {
+		return Value{Key: key.Name, Value: v, Source: SourceEnv}, nil
+	}
+	if section := c.projectSection(); section != nil {
+		if v, ok := section[key.Name]; ok && key.valid(v) {
+			return Value{Key: key.Name, Value: v, Source: SourceProject}, nil
+		}
+	}
+	if v, ok := c.file.Values[key.Name]; ok && key.valid(v) {
+		return Value{Key: key.Name, Value: v, Source: SourceHome}, nil
+	}
+	return Value{Key: key.Name, Value: key.Default, Source: SourceDefault}, nil
+}
+
+// FileValue returns a setting from the top level of config.yaml, ignoring
+// every other layer
+func (c *Config) FileValue(name string) (string, bool) {
+	v, ok := c.file.Values[name]
+	return v, ok
+}
+
+// List resolves every setting, in the order of Keys
+func (c *Config) List() []Value {
+	values := make([]Value, 0, len(Keys))
+	for _, key := range Keys {
+		v, _ := c.Lookup(key.Name)
+		values = append(values, v)
+	}
+	return values
+}
+
+// Settings resolves every setting into typed values
+func (c *Config) Settings() Settings {
+	return Settings{
+		TimestampFormat:  c.str("timestamp_format"),
+		WarnAt:           c.integer("warn_at"),
+		WarnAt2:          c.integer("warn_at_2"),
+		WarnAt3:          c.integer("warn_at_3"),
+		BulkLimit:        c.integer("bulk_limit"),
+		ArchiveAfterDays: c.integer("archive_after_days"),
+		DeleteAfterDays:  c.integer("delete_after_days"),
+		TrashPurgeDays:   c.integer("trash_purge_days"),
+	}
+}
+
+// Set stores a setting in config.yaml, in a project section when project is non-empty
+func (c *Config) Set(name, value, project string) error {
+	key, ok := FindKey(name)
+	if !ok {
+		return fmt.Errorf("unknown config key %q (known keys: %s)", name, strings.Join(keyNames(), ", "))
+	}
+	if !key.valid(value) {
+		return fmt.Errorf("invalid value %q for %s: expected %s", value, key.Name, key.Kind)
+	}
+
+	// Re-read so concurrent edits made since Load aren't lost
+	file, err := readFile(c.path)
+	if err != nil {
+		return err
+	}
+
+	if project == "" {
+		if file.Values == nil {
+			file.Values = make(map[string]string)
+		}
+		file.Values[key.Name] = value
+	} else {
+		if file.Projects == nil {
+			file.Projects = make(map[string]map[string]string)
+		}
+		if file.Projects[project] == nil {
+			file.Projects[project] = make(map[string]string)
+		}
+		file.Projects[project][key.Name] = value
+	}
+
+	if err := writeFile(c.path, file); err != nil {
+		return err
+	}
+	c.file = file
+	return nil
+}
+
+// projectSection returns the config.yaml section for the current project, matched case-insensitively
+func (c *Config) projectSection() map[string]string {
+	if c.project == "" {
+		return nil
+	}
+	for name, section := range c.file.Projects {
+		if strings.EqualFold(name, c.project) {
+			return section
+		}
+	}
+	return nil
+}
+
+func (c *Config) str(name string) string {
+	v, _ := c.Lookup(name)
+	return v.Value
+}
+
+func (c *Config) integer(name string) int {
+	v, _ := c.Lookup(name)
+	i, _ := strconv.Atoi(v.Value)
+	return i
+}
+
+// valid reports whether a raw value can be used for the key. Invalid values
+// are skipped so the next layer applies, like a malformed environment variable.
+func (k Key) valid(value string) bool {
+	if k.Kind == KindInt {
+		_, err := strconv.Atoi(value)
+		return err == nil
+	}
+	return true
+}
+
+// EnvInt reads an integer environment variable, returning defaultVal if unset or invalid
+func EnvInt(name string, defaultVal int) int {
+	if val := os.Getenv(name); val != "" {
+		if i, err := strconv.Atoi(val); err == nil {
+			return i
+		}
+	}
+	return defaultVal
+}
+
+// ValidateOverrides checks --set key=value pairs without reading config.yaml
+func ValidateOverrides(overrides []string) error {
+	_, err := parseOverrides(overrides)
+	return err
+}
+
+func parseOverrides(overrides []string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, override := range overrides {
+		name, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set %q: expected key=value", override)
+		}
+		key, found := FindKey(name)
+		if !found {
+			return nil, fmt.Errorf("unknown config key %q in --set", name)
+		}
+		if !key.valid(value) {
+			return nil, fmt.Errorf("invalid value %q for %s: expected %s", value, key.Name, key.Kind)
+		}
+		parsed[name] = value
+	}
+	return parsed, nil
+}
+
+func readFile(path string) (*File, error) {
+	file := &File{}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+func writeFile(path string, file *File) error {
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Template returns a commented config.yaml listing every key with its default
+func Template() string {
+	var sb strings.Builder
+	sb.WriteString("# my-context configuration\n")
+	sb.WriteString("# Precedence: --set flag > environment variable > project section > this file > default\n\n")
+	for _, key := range Keys {
+		sb.WriteString(fmt.Sprintf("# %s (%s)\n# %s: %s\n\n", key.Description, key.Env, key.Name, key.Default))
+	}
+	sb.WriteString("# Per-project overrides, keyed by project name (text before the first ':')\n")
+	sb.WriteString("# projects:\n#   ps-cli:\n#     timestamp_format: short\n")
+	return sb.String()
+}
+
+func keyNames() []string {
+	names := make([]string, 0, len(Keys))
+	for _, key := range Keys {
+		names = append(names, key.Name)
+	}
+	sort.Strings(names)
+	return names
+}
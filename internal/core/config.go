@@ -0,0 +1,26 @@
+package core
+
+import (
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+)
+
+// LoadConfig loads config.yaml from the context home, applying the project
+// section for the active context's project and the given --set overrides.
+func LoadConfig(overrides []string) (*config.Config, error) {
+	project := ""
+	if state, err := GetActiveContext(); err == nil && state.HasActiveContext() {
+		project = ExtractProjectName(state.GetActiveContextName())
+	}
+	return config.Load(GetContextHome(), project, overrides)
+}
+
+// currentConfig returns the config loaded for this invocation, or loads the
+// context home's config.yaml when none was loaded for it (as in tests that
+// switch MY_CONTEXT_HOME)
+func currentConfig() (*config.Config, error) {
+	cfg := config.Current()
+	if cfg.FilePath() == config.Path(GetContextHome()) {
+		return cfg, nil
+	}
+	return LoadConfig(nil)
+}
@@ -3,13 +3,11 @@ package core
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
-	"github.com/jefferycaldwell/my-context-copilot/internal/output"
 	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
 )
 
@@ -19,18 +17,10 @@ func CreateContextWithMetadata(name, createdBy, parent string, labels []string)
 		return nil, "", err
 	}
 
-	// Sanitize the name for directory use
-	sanitizedName := SanitizeContextName(name)
-
-	// Resolve duplicate directory name
-	finalDirName := resolveDuplicateName(sanitizedName)
-
-	// Preserve original name for display, append suffix if duplicate
-	displayName := name
-	if finalDirName != sanitizedName {
-		// If duplicate detected (e.g., sanitizedName_2), append to display name
-		suffix := finalDirName[len(sanitizedName):]
-		displayName = name + suffix
+	// A name already in use gets a suffix (e.g., name_2)
+	displayName, err := uniqueContextName(name)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Get current active context (if any)
@@ -39,12 +29,19 @@ func CreateContextWithMetadata(name, createdBy, parent string, labels []string)
 		return nil, "", err
 	}
 
+	journal := newJournalEntry(JournalOpStart, displayName, fmt.Sprintf("start %q", displayName))
+	journal.captureState(state)
+	journal.CreatedContext = displayName
+
 	var previousContext string
 	var transitionType intmodels.TransitionType
 
 	// Stop previous context if active
 	if state.HasActiveContext() {
 		previousContext = state.GetActiveContextName()
+		if err := journal.captureMeta(previousContext); err != nil {
+			return nil, "", fmt.Errorf("failed to read previous context: %w", err)
+		}
 		if err := stopContextInternal(previousContext); err != nil {
 			return nil, "", fmt.Errorf("failed to stop previous context: %w", err)
 		}
@@ -56,11 +53,9 @@ func CreateContextWithMetadata(name, createdBy, parent string, labels []string)
 	// Create new context with metadata
 	context := pkgmodels.NewContextWithMetadata(displayName, createdBy, parent, labels)
 
-	// Override the subdirectory path
-	context.SubdirectoryPath = GetContextDir(finalDirName)
-
-	// Create context directory
-	if err := CreateDir(context.SubdirectoryPath); err != nil {
+	// Create the context directory, named after a new ID
+	context.ID, context.SubdirectoryPath, err = allocateContextDir(displayName)
+	if err != nil {
 		return nil, "", err
 	}
 
@@ -107,6 +102,11 @@ func CreateContextWithMetadata(name, createdBy, parent string, labels []string)
 	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
 		return nil, "", fmt.Errorf("failed to log transition: %w", err)
 	}
+	journal.appended(journalLogTransitions, "", transition.ToLogLine())
+
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, "", err
+	}
 
 	return context, previousContext, nil
 }
@@ -117,18 +117,10 @@ func CreateContext(name string) (*intmodels.Context, string, error) {
 		return nil, "", err
 	}
 
-	// Sanitize the name for directory use
-	sanitizedName := SanitizeContextName(name)
-
-	// Resolve duplicate directory name
-	finalDirName := resolveDuplicateName(sanitizedName)
-
-	// Preserve original name for display, append suffix if duplicate
-	displayName := name
-	if finalDirName != sanitizedName {
-		// If duplicate detected (e.g., sanitizedName_2), append to display name
-		suffix := finalDirName[len(sanitizedName):]
-		displayName = name + suffix
+	// A name already in use gets a suffix (e.g., name_2)
+	displayName, err := uniqueContextName(name)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Get current active context (if any)
@@ -137,12 +129,19 @@ func CreateContext(name string) (*intmodels.Context, string, error) {
 		return nil, "", err
 	}
 
+	journal := newJournalEntry(JournalOpStart, displayName, fmt.Sprintf("start %q", displayName))
+	journal.captureState(state)
+	journal.CreatedContext = displayName
+
 	var previousContext string
 	var transitionType intmodels.TransitionType
 
 	// Stop previous context if active
 	if state.HasActiveContext() {
 		previousContext = state.GetActiveContextName()
+		if err := journal.captureMeta(previousContext); err != nil {
+			return nil, "", fmt.Errorf("failed to read previous context: %w", err)
+		}
 		if err := stopContextInternal(previousContext); err != nil {
 			return nil, "", fmt.Errorf("failed to stop previous context: %w", err)
 		}
@@ -154,28 +153,28 @@ func CreateContext(name string) (*intmodels.Context, string, error) {
 	// Create new context
 	now := time.Now()
 	context := &intmodels.Context{
-		Name:             displayName,
-		StartTime:        now,
-		EndTime:          nil,
-		Status:           "active",
-		SubdirectoryPath: GetContextDir(finalDirName),
+		Name:      displayName,
+		StartTime: now,
+		EndTime:   nil,
+		Status:    "active",
 	}
 
-	// Create context directory
-	if err := CreateDir(context.SubdirectoryPath); err != nil {
+	// Create the context directory, named after a new ID
+	context.ID, context.SubdirectoryPath, err = allocateContextDir(displayName)
+	if err != nil {
 		return nil, "", err
 	}
 
 	// Write meta.json
-	if err := WriteJSON(GetMetaJSONPath(finalDirName), context); err != nil {
+	if err := WriteJSON(GetMetaJSONPath(displayName), context); err != nil {
 		return nil, "", err
 	}
 
 	// Create empty log files
 	for _, path := range []string{
-		GetNotesLogPath(finalDirName),
-		GetFilesLogPath(finalDirName),
-		GetTouchLogPath(finalDirName),
+		GetNotesLogPath(displayName),
+		GetFilesLogPath(displayName),
+		GetTouchLogPath(displayName),
 	} {
 		if err := os.WriteFile(path, []byte{}, 0o600); err != nil {
 			return nil, "", err
@@ -200,25 +199,13 @@ func CreateContext(name string) (*intmodels.Context, string, error) {
 	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
 		return nil, "", err
 	}
+	journal.appended(journalLogTransitions, "", transition.ToLogLine())
 
-	return context, previousContext, nil
-}
-
-// resolveDuplicateName finds an available name by appending _2, _3, etc.
-func resolveDuplicateName(name string) string {
-	contextDir := GetContextDir(name)
-	if !FileExists(contextDir) {
-		return name
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, "", err
 	}
 
-	// Try _2, _3, _4, etc.
-	for i := 2; ; i++ {
-		candidateName := fmt.Sprintf("%s_%d", name, i)
-		contextDir = GetContextDir(candidateName)
-		if !FileExists(contextDir) {
-			return candidateName
-		}
-	}
+	return context, previousContext, nil
 }
 
 // StopContext stops the currently active context
@@ -233,6 +220,12 @@ func StopContext() (*intmodels.Context, error) {
 	}
 
 	contextName := state.GetActiveContextName()
+	journal := newJournalEntry(JournalOpStop, contextName, fmt.Sprintf("stop %q", contextName))
+	journal.captureState(state)
+	if err := journal.captureMeta(contextName); err != nil {
+		return nil, err
+	}
+
 	if err := stopContextInternal(contextName); err != nil {
 		return nil, err
 	}
@@ -260,29 +253,51 @@ func StopContext() (*intmodels.Context, error) {
 	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
 		return nil, err
 	}
+	journal.appended(journalLogTransitions, "", transition.ToLogLine())
+
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, err
+	}
 
 	return &context, nil
 }
 
 // stopContextInternal stops a context without clearing state (used internally)
 func stopContextInternal(contextName string) error {
-	// Read current context
-	var context intmodels.Context
-	if err := ReadJSON(GetMetaJSONPath(contextName), &context); err != nil {
+	return updateContextMeta(contextName, func(context *pkgmodels.ContextWithMetadata) error {
+		// Update to stopped
+		now := time.Now()
+		context.EndTime = &now
+		context.Status = "stopped"
+		return nil
+	})
+}
+
+// updateContextMeta reads meta.json, applies fn and writes it back.
+// Going through ContextWithMetadata keeps labels, parent and other metadata intact.
+func updateContextMeta(contextName string, fn func(*pkgmodels.ContextWithMetadata) error) error {
+	metaPath := GetMetaJSONPath(contextName)
+
+	var context pkgmodels.ContextWithMetadata
+	if err := ReadJSON(metaPath, &context); err != nil {
 		return err
 	}
 
-	// Update to stopped
-	now := time.Now()
-	context.EndTime = &now
-	context.Status = "stopped"
+	if err := fn(&context); err != nil {
+		return err
+	}
 
-	// Write back
-	return WriteJSON(GetMetaJSONPath(contextName), &context)
+	return WriteJSON(metaPath, &context)
 }
 
 // AddNote adds a note to the active context
 func AddNote(text string) (*intmodels.Note, error) {
+	return AddTypedNote(text, "")
+}
+
+// AddTypedNote adds a note of the given type (see models.NoteTypes) to the
+// active context; an empty type adds a plain note
+func AddTypedNote(text, noteType string) (*intmodels.Note, error) {
 	state, err := GetActiveContext()
 	if err != nil {
 		return nil, err
@@ -295,6 +310,7 @@ func AddNote(text string) (*intmodels.Note, error) {
 	note := &intmodels.Note{
 		Timestamp:   time.Now(),
 		TextContent: text,
+		Type:        noteType,
 	}
 
 	if err := note.Validate(); err != nil {
@@ -308,6 +324,12 @@ func AddNote(text string) (*intmodels.Note, error) {
 		return nil, err
 	}
 
+	journal := newJournalEntry(JournalOpNote, contextName, fmt.Sprintf("note in %q", contextName))
+	journal.appended(journalLogNotes, contextName, note.ToLogLine())
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, err
+	}
+
 	return note, nil
 }
 
@@ -340,6 +362,12 @@ func AddFile(filePath string) (*intmodels.FileAssociation, error) {
 		return nil, err
 	}
 
+	journal := newJournalEntry(JournalOpFile, contextName, fmt.Sprintf("file %s in %q", normalizedPath, contextName))
+	journal.appended(journalLogFiles, contextName, file.ToLogLine())
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, err
+	}
+
 	return file, nil
 }
 
@@ -380,6 +408,7 @@ func GetContextWithMetadata(contextName string) (*pkgmodels.ContextWithMetadata,
 		}
 		// Convert old context to extended context with empty metadata
 		context = pkgmodels.ContextWithMetadata{
+			ID:               oldContext.ID,
 			Name:             oldContext.Name,
 			StartTime:        oldContext.StartTime,
 			EndTime:          oldContext.EndTime,
@@ -520,7 +549,7 @@ func ListContexts() ([]*intmodels.Context, error) {
 	contexts := make([]*intmodels.Context, 0, len(dirs))
 	for _, dir := range dirs {
 		var context intmodels.Context
-		if err := ReadJSON(GetMetaJSONPath(dir), &context); err != nil {
+		if err := ReadJSON(contextMetaPathInDir(dir), &context); err != nil {
 			continue // Skip contexts with invalid meta.json
 		}
 		contexts = append(contexts, &context)
@@ -556,150 +585,106 @@ func GetTransitions() ([]*intmodels.ContextTransition, error) {
 	return transitions, nil
 }
 
-// ExportContext exports a context to a markdown file
-func ExportContext(contextName, outputPath string, asJSON bool) (string, error) {
-	// Load context data
-	ctx, notes, files, touches, err := GetContext(contextName)
-	if err != nil {
-		return "", fmt.Errorf("context %q not found", contextName)
-	}
-
-	// Convert to model types for export
-	noteModels := make([]intmodels.Note, 0, len(notes))
-	for _, n := range notes {
-		noteModels = append(noteModels, *n)
-	}
-
-	fileModels := make([]intmodels.FileAssociation, 0, len(files))
-	for _, f := range files {
-		fileModels = append(fileModels, *f)
-	}
-
-	// Generate content based on format
-	var content string
-	var defaultExt string
-	if asJSON {
-		jsonContent, err := output.FormatExportJSON(ctx, noteModels, fileModels, len(touches))
-		if err != nil {
-			return "", fmt.Errorf("failed to generate JSON export: %w", err)
-		}
-		content = jsonContent
-		defaultExt = ".json"
-	} else {
-		content = output.FormatExportMarkdown(ctx, noteModels, fileModels, len(touches))
-		defaultExt = ".md"
-	}
-
-	// Determine output file path
-	if outputPath == "" {
-		// Default: sanitized context name in current directory
-		sanitized := SanitizeFilename(contextName)
-		outputPath = sanitized + defaultExt
-	}
-
-	// Create parent directories if needed
-	if err := CreateParentDirs(outputPath); err != nil {
-		return "", err
-	}
-
-	// Write file
-	if err := os.WriteFile(outputPath, []byte(content), 0o600); err != nil {
-		return "", fmt.Errorf("failed to write export file: %w", err)
+// ArchiveContext marks a context as archived
+func ArchiveContext(contextName string) error {
+	// Load context
+	var ctx pkgmodels.ContextWithMetadata
+	metaPath := GetMetaJSONPath(contextName)
+	if err := ReadJSON(metaPath, &ctx); err != nil {
+		return fmt.Errorf("context %q not found", contextName)
 	}
 
-	return outputPath, nil
-}
-
-// ExportAllContexts exports all contexts to separate files in a directory
-func ExportAllContexts(outputDir string, asJSON bool) ([]string, error) {
-	contexts, err := ListContexts()
+	// Validate: cannot archive active context (resume activates through state.json only)
+	active, err := isActiveContext(contextName)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if active {
+		return fmt.Errorf("cannot archive active context %q - stop it first", contextName)
 	}
 
-	if err := CreateDir(outputDir); err != nil {
-		return nil, err
+	// Check if already archived
+	if ctx.IsArchived {
+		return fmt.Errorf("context %q is already archived", contextName)
 	}
 
-	ext := ".md"
-	if asJSON {
-		ext = ".json"
+	journal := newJournalEntry(JournalOpArchive, contextName, fmt.Sprintf("archive %q", contextName))
+	if err := journal.captureMeta(contextName); err != nil {
+		return err
 	}
 
-	exportedPaths := make([]string, 0, len(contexts))
-	for _, ctx := range contexts {
-		sanitized := SanitizeFilename(ctx.Name)
-		outputPath := filepath.Join(outputDir, sanitized+ext)
+	// Set archived flag
+	ctx.IsArchived = true
 
-		path, err := ExportContext(ctx.Name, outputPath, asJSON)
-		if err != nil {
-			continue // Skip failed exports
-		}
-		exportedPaths = append(exportedPaths, path)
+	// Write updated meta.json
+	if err := WriteJSON(metaPath, &ctx); err != nil {
+		return fmt.Errorf("failed to update context: %w", err)
 	}
 
-	return exportedPaths, nil
+	return recordJournalEntry(journal)
 }
 
-// ArchiveContext marks a context as archived
-func ArchiveContext(contextName string) error {
+// UnarchiveContext clears the archived flag so the context shows up in default views again
+func UnarchiveContext(contextName string) error {
 	// Load context
-	var ctx intmodels.Context
+	var ctx pkgmodels.ContextWithMetadata
 	metaPath := GetMetaJSONPath(contextName)
 	if err := ReadJSON(metaPath, &ctx); err != nil {
 		return fmt.Errorf("context %q not found", contextName)
 	}
 
-	// Validate: cannot archive active context
-	if ctx.Status == "active" {
-		return fmt.Errorf("cannot archive active context %q - stop it first", contextName)
+	// Check if archived at all
+	if !ctx.IsArchived {
+		return fmt.Errorf("context %q is not archived", contextName)
 	}
 
-	// Check if already archived
-	if ctx.IsArchived {
-		return fmt.Errorf("context %q is already archived", contextName)
+	journal := newJournalEntry(JournalOpUnarchive, contextName, fmt.Sprintf("unarchive %q", contextName))
+	if err := journal.captureMeta(contextName); err != nil {
+		return err
 	}
 
-	// Set archived flag
-	ctx.IsArchived = true
+	// Clear archived flag
+	ctx.IsArchived = false
 
 	// Write updated meta.json
 	if err := WriteJSON(metaPath, &ctx); err != nil {
 		return fmt.Errorf("failed to update context: %w", err)
 	}
 
-	return nil
+	return recordJournalEntry(journal)
 }
 
-// DeleteContext permanently removes a context and all its data
-func DeleteContext(contextName string, force, confirmed bool) error {
+// DeleteContext moves a context and all its data to the trash.
+// Trashed contexts can be restored until they are purged.
+func DeleteContext(contextName string, force, confirmed bool) (*TrashEntry, error) {
 	// Load context
 	var ctx intmodels.Context
 	metaPath := GetMetaJSONPath(contextName)
 	if err := ReadJSON(metaPath, &ctx); err != nil {
-		return fmt.Errorf("context %q not found", contextName)
+		return nil, fmt.Errorf("context %q not found", contextName)
 	}
 
 	// Validate: cannot delete active context
 	if ctx.Status == "active" {
-		return fmt.Errorf("cannot delete active context %q - stop it first", contextName)
+		return nil, fmt.Errorf("cannot delete active context %q - stop it first", contextName)
 	}
 
 	// Require confirmation unless force or already confirmed
 	if !force && !confirmed {
-		return fmt.Errorf("deletion requires confirmation")
+		return nil, fmt.Errorf("deletion requires confirmation")
 	}
 
-	// Remove context directory
-	// Use sanitized name to get the actual directory path (e.g., "demo: Test" → "demo__Test")
-	sanitizedName := SanitizeContextName(contextName)
-	actualDir := filepath.Join(GetContextHome(), sanitizedName)
+	reason := "delete"
+	if force {
+		reason = "delete --force"
+	}
 
-	if err := os.RemoveAll(actualDir); err != nil {
-		return fmt.Errorf("failed to delete context directory %q: %w", actualDir, err)
+	entry, err := TrashContext(contextName, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete context %q: %w", contextName, err)
 	}
 
-	return nil
+	return entry, nil
 }
 
 // LoadContext reads a context by name (Sprint 2: for backward compatibility testing)
@@ -797,6 +782,11 @@ func AddTags(contextName string, tags []string) ([]string, error) {
 		return nil, fmt.Errorf("context %q not found", contextName)
 	}
 
+	journal := newJournalEntry(JournalOpTag, contextName, fmt.Sprintf("tag %q", contextName))
+	if err := journal.captureMeta(contextName); err != nil {
+		return nil, err
+	}
+
 	// Track which tags were actually added (not duplicates)
 	var added []string
 	existingTags := make(map[string]bool)
@@ -824,6 +814,13 @@ func AddTags(contextName string, tags []string) ([]string, error) {
 		return nil, fmt.Errorf("failed to update context: %w", err)
 	}
 
+	if len(added) > 0 {
+		journal.Summary = fmt.Sprintf("tag %q with %s", contextName, strings.Join(added, ", "))
+		if err := recordJournalEntry(journal); err != nil {
+			return nil, err
+		}
+	}
+
 	return added, nil
 }
 
@@ -835,6 +832,11 @@ func RemoveTags(contextName string, tags []string) ([]string, error) {
 		return nil, fmt.Errorf("context %q not found", contextName)
 	}
 
+	journal := newJournalEntry(JournalOpUntag, contextName, fmt.Sprintf("untag %q", contextName))
+	if err := journal.captureMeta(contextName); err != nil {
+		return nil, err
+	}
+
 	// Build set of tags to remove
 	toRemove := make(map[string]bool)
 	for _, tag := range tags {
@@ -861,6 +863,13 @@ func RemoveTags(contextName string, tags []string) ([]string, error) {
 		return nil, fmt.Errorf("failed to update context: %w", err)
 	}
 
+	if len(removed) > 0 {
+		journal.Summary = fmt.Sprintf("remove tags %s from %q", strings.Join(removed, ", "), contextName)
+		if err := recordJournalEntry(journal); err != nil {
+			return nil, err
+		}
+	}
+
 	return removed, nil
 }
 
@@ -885,9 +894,8 @@ func GetAllTags() (map[string]int, error) {
 	tagCounts := make(map[string]int)
 
 	for _, dir := range dirs {
-		// Try to load as ContextWithMetadata
-		ctx, _, _, _, err := GetContextWithMetadata(dir)
-		if err != nil {
+		var ctx pkgmodels.ContextWithMetadata
+		if err := ReadJSON(contextMetaPathInDir(dir), &ctx); err != nil {
 			continue // Skip contexts that can't be loaded
 		}
 
@@ -908,6 +916,11 @@ func SetParent(childName, parentName string) error {
 		return fmt.Errorf("child context %q not found", childName)
 	}
 
+	journal := newJournalEntry(JournalOpLink, childName, fmt.Sprintf("link %q to parent %q", childName, parentName))
+	if err := journal.captureMeta(childName); err != nil {
+		return err
+	}
+
 	// Update parent
 	ctx.Metadata.Parent = parentName
 
@@ -922,7 +935,7 @@ func SetParent(childName, parentName string) error {
 		return fmt.Errorf("failed to update context: %w", err)
 	}
 
-	return nil
+	return recordJournalEntry(journal)
 }
 
 // ClearParent removes the parent relationship from a context
@@ -933,6 +946,11 @@ func ClearParent(contextName string) error {
 		return fmt.Errorf("context %q not found", contextName)
 	}
 
+	journal := newJournalEntry(JournalOpUnlink, contextName, fmt.Sprintf("unlink %q from parent %q", contextName, ctx.Metadata.Parent))
+	if err := journal.captureMeta(contextName); err != nil {
+		return err
+	}
+
 	// Clear parent
 	ctx.Metadata.Parent = ""
 
@@ -942,7 +960,7 @@ func ClearParent(contextName string) error {
 		return fmt.Errorf("failed to update context: %w", err)
 	}
 
-	return nil
+	return recordJournalEntry(journal)
 }
 
 // GetChildren returns all contexts that have the given context as their parent
@@ -955,9 +973,8 @@ func GetChildren(parentName string) ([]string, error) {
 	var children []string
 
 	for _, dir := range dirs {
-		// Try to load as ContextWithMetadata
-		ctx, _, _, _, err := GetContextWithMetadata(dir)
-		if err != nil {
+		var ctx pkgmodels.ContextWithMetadata
+		if err := ReadJSON(contextMetaPathInDir(dir), &ctx); err != nil {
 			continue // Skip contexts that can't be loaded
 		}
 
@@ -972,8 +989,9 @@ func GetChildren(parentName string) ([]string, error) {
 
 // GetContextTree builds a tree structure starting from a root context
 type ContextTreeNode struct {
-	Name     string
-	Children []*ContextTreeNode
+	Name     string             `json:"name"`
+	State    string             `json:"state,omitempty"` // Workflow state, if set
+	Children []*ContextTreeNode `json:"children,omitempty"`
 }
 
 func GetContextTree(rootName string) (*ContextTreeNode, error) {
@@ -994,6 +1012,7 @@ func buildTreeNode(name string, visited map[string]bool) (*ContextTreeNode, erro
 	visited[name] = true
 
 	node := &ContextTreeNode{Name: name}
+	node.State, _ = GetContextState(name)
 
 	// Get children
 	children, err := GetChildren(name)
@@ -1013,6 +1032,23 @@ func buildTreeNode(name string, visited map[string]bool) (*ContextTreeNode, erro
 	return node, nil
 }
 
+// FilterTreeByState prunes a tree to nodes in the given workflow state and the
+// ancestors that lead to them. It returns nil if no node matches.
+func FilterTreeByState(node *ContextTreeNode, state string) *ContextTreeNode {
+	var children []*ContextTreeNode
+	for _, child := range node.Children {
+		if kept := FilterTreeByState(child, state); kept != nil {
+			children = append(children, kept)
+		}
+	}
+
+	if len(children) == 0 && !strings.EqualFold(node.State, state) {
+		return nil
+	}
+
+	return &ContextTreeNode{Name: node.Name, State: node.State, Children: children}
+}
+
 // GetRootContexts returns all contexts that don't have a parent
 func GetRootContexts() ([]string, error) {
 	dirs, err := ListContextDirs()
@@ -1023,9 +1059,8 @@ func GetRootContexts() ([]string, error) {
 	var roots []string
 
 	for _, dir := range dirs {
-		// Try to load as ContextWithMetadata
-		ctx, _, _, _, err := GetContextWithMetadata(dir)
-		if err != nil {
+		var ctx pkgmodels.ContextWithMetadata
+		if err := ReadJSON(contextMetaPathInDir(dir), &ctx); err != nil {
 			continue // Skip contexts that can't be loaded
 		}
 
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// BuildDigest collects the time spent, lifecycle and workflow state changes,
+// notes and files of every context active in [from, to), grouped by project.
+// An empty project includes all projects. Time is taken from transitions.log,
+// so contexts deleted or trashed since are still listed with the time spent in them.
+func BuildDigest(from, to time.Time, project string) (*output.Digest, error) {
+	transitions, err := GetTransitions()
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	inWindow := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	spent := make(map[string]time.Duration)
+	sessions := make(map[string][]intmodels.Session)
+	for _, s := range buildSessions(transitions, time.Now()) {
+		if c, ok := s.Clip(from, to); ok {
+			spent[s.Context] += c.Duration()
+			sessions[s.Context] = append(sessions[s.Context], c)
+		}
+	}
+
+	// Transitions name contexts as they were called at the time. As in
+	// buildSessions, a rename or merge applies to the changes recorded before
+	// it, so a later context reusing the old name keeps its own changes.
+	type contextChange struct {
+		context string // Context the change belongs to
+		other   string // Context named at the end of Detail, if any
+		output.DigestStateChange
+	}
+	var recorded []*contextChange
+	addChange := func(name, other string, at time.Time, change, detail string) {
+		recorded = append(recorded, &contextChange{
+			context:           name,
+			other:             other,
+			DigestStateChange: output.DigestStateChange{Timestamp: at, Change: change, Detail: detail},
+		})
+	}
+	for _, t := range transitions {
+		renamed := t.TransitionType == intmodels.TransitionRename || t.TransitionType == intmodels.TransitionMerge
+		if renamed && t.PreviousContext != nil && t.NewContext != nil {
+			for _, c := range recorded {
+				if c.context == *t.PreviousContext {
+					c.context = *t.NewContext
+				}
+				if c.other == *t.PreviousContext {
+					c.other = *t.NewContext
+				}
+			}
+		}
+
+		if !inWindow(t.Timestamp) {
+			continue
+		}
+		switch t.TransitionType {
+		case intmodels.TransitionStart:
+			if t.NewContext != nil {
+				addChange(*t.NewContext, "", t.Timestamp, "started", "")
+			}
+		case intmodels.TransitionSwitch:
+			if t.PreviousContext != nil && t.NewContext != nil {
+				addChange(*t.PreviousContext, *t.NewContext, t.Timestamp, "stopped", "switched to ")
+				addChange(*t.NewContext, *t.PreviousContext, t.Timestamp, "started", "switched from ")
+			}
+		case intmodels.TransitionStop:
+			if t.PreviousContext != nil {
+				addChange(*t.PreviousContext, "", t.Timestamp, "stopped", "")
+			}
+		case intmodels.TransitionRename:
+			if t.PreviousContext != nil && t.NewContext != nil {
+				addChange(*t.NewContext, "", t.Timestamp, "renamed", "from "+*t.PreviousContext)
+			}
+		case intmodels.TransitionMerge:
+			if t.PreviousContext != nil && t.NewContext != nil {
+				addChange(*t.NewContext, "", t.Timestamp, "merged", "from "+*t.PreviousContext)
+			}
+		}
+	}
+
+	changes := make(map[string][]output.DigestStateChange)
+	for _, c := range recorded {
+		change := c.DigestStateChange
+		change.Detail += c.other
+		changes[c.context] = append(changes[c.context], change)
+	}
+
+	digest := &output.Digest{From: from, To: to, Projects: []output.DigestProject{}}
+	projects := make(map[string]*output.DigestProject)
+	var projectOrder []string
+
+	inProject := func(name string) bool {
+		return project == "" || strings.EqualFold(ExtractProjectName(name), strings.TrimSpace(project))
+	}
+	add := func(entry output.DigestContext) {
+		if entry.TimeSpent == 0 && len(entry.Notes) == 0 && len(entry.Files) == 0 && len(entry.StateChanges) == 0 {
+			return
+		}
+		sort.SliceStable(entry.StateChanges, func(i, j int) bool {
+			return entry.StateChanges[i].Timestamp.Before(entry.StateChanges[j].Timestamp)
+		})
+
+		projectName := ExtractProjectName(entry.Name)
+		p, ok := projects[projectName]
+		if !ok {
+			p = &output.DigestProject{Name: projectName}
+			projects[projectName] = p
+			projectOrder = append(projectOrder, projectName)
+		}
+		p.Contexts = append(p.Contexts, entry)
+		p.TimeSpent += entry.TimeSpent
+		digest.TimeSpent += entry.TimeSpent
+		digest.NoteCount += len(entry.Notes)
+		digest.FileCount += len(entry.Files)
+	}
+	newEntry := func(name, status string) output.DigestContext {
+		entry := output.DigestContext{
+			Name:         name,
+			Status:       status,
+			TimeSpent:    int(spent[name].Seconds()),
+			Sessions:     sessions[name],
+			Notes:        []intmodels.Note{},
+			Files:        []intmodels.FileAssociation{},
+			StateChanges: changes[name],
+		}
+		if entry.Sessions == nil {
+			entry.Sessions = []intmodels.Session{}
+		}
+		if entry.StateChanges == nil {
+			entry.StateChanges = []output.DigestStateChange{}
+		}
+		return entry
+	}
+
+	listed := make(map[string]bool)
+	for _, ctx := range contexts {
+		listed[ctx.Name] = true
+		if !inProject(ctx.Name) {
+			continue
+		}
+
+		meta, notes, files, _, err := GetContextWithMetadata(ctx.Name)
+		if err != nil {
+			continue // Skip contexts that can't be read
+		}
+
+		entry := newEntry(ctx.Name, ctx.Status)
+		// Only the latest workflow state change is recorded
+		if at := meta.Metadata.StateChangedAt; at != nil && inWindow(*at) {
+			entry.StateChanges = append(entry.StateChanges, output.DigestStateChange{Timestamp: *at, Change: "state", Detail: meta.Metadata.State})
+		}
+		for _, n := range notes {
+			if inWindow(n.Timestamp) {
+				entry.Notes = append(entry.Notes, *n)
+			}
+		}
+		for _, f := range files {
+			if inWindow(f.Timestamp) {
+				entry.Files = append(entry.Files, *f)
+			}
+		}
+		add(entry)
+	}
+
+	// Contexts that are gone keep the time and changes logged for them; their
+	// notes and files went with them
+	trashed := make(map[string]bool)
+	if entries, err := ListTrash(); err == nil {
+		for _, e := range entries {
+			trashed[e.ContextName] = true
+		}
+	}
+	var gone []string
+	for name := range spent {
+		if !listed[name] {
+			gone = append(gone, name)
+		}
+	}
+	for name := range changes {
+		if _, ok := spent[name]; !ok && !listed[name] {
+			gone = append(gone, name)
+		}
+	}
+	sort.Strings(gone)
+	for _, name := range gone {
+		if !inProject(name) {
+			continue
+		}
+		status := "deleted"
+		if trashed[name] {
+			status = "trashed"
+		}
+		add(newEntry(name, status))
+	}
+
+	// Most time spent first, by name on ties
+	for _, name := range projectOrder {
+		p := projects[name]
+		sort.SliceStable(p.Contexts, func(i, j int) bool {
+			if p.Contexts[i].TimeSpent != p.Contexts[j].TimeSpent {
+				return p.Contexts[i].TimeSpent > p.Contexts[j].TimeSpent
+			}
+			return p.Contexts[i].Name < p.Contexts[j].Name
+		})
+		digest.Projects = append(digest.Projects, *p)
+	}
+	sort.SliceStable(digest.Projects, func(i, j int) bool {
+		if digest.Projects[i].TimeSpent != digest.Projects[j].TimeSpent {
+			return digest.Projects[i].TimeSpent > digest.Projects[j].TimeSpent
+		}
+		return digest.Projects[i].Name < digest.Projects[j].Name
+	})
+
+	return digest, nil
+}
@@ -0,0 +1,280 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// GetExporter returns the exporter for a format name, or for a template when
+// template is set (a file path or the name of a user or built-in template)
+func GetExporter(format, template string) (output.Exporter, error) {
+	if template != "" {
+		return output.LoadTemplate(GetContextHome(), template)
+	}
+	return output.GetExporter(format)
+}
+
+// exportLoader loads export documents, linking each one to its child contexts.
+// Documents are cached so a context shared by several exports is read once.
+type exportLoader struct {
+	children    map[string][]string
+	transitions []*intmodels.ContextTransition
+	docs        map[string]*output.ExportDocument
+	loading     map[string]bool
+}
+
+func newExportLoader() (*exportLoader, error) {
+	dirs, err := ListContextDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	// Index parent links from meta.json alone rather than loading every context
+	children := make(map[string][]string)
+	for _, dir := range dirs {
+		var ctx pkgmodels.ContextWithMetadata
+		if err := ReadJSON(contextMetaPathInDir(dir), &ctx); err != nil {
+			continue // Skip contexts with invalid meta.json
+		}
+		if ctx.Metadata.Parent != "" {
+			children[ctx.Metadata.Parent] = append(children[ctx.Metadata.Parent], ctx.Name)
+		}
+	}
+
+	transitions, err := GetTransitions()
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportLoader{
+		children:    children,
+		transitions: transitions,
+		docs:        make(map[string]*output.ExportDocument),
+		loading:     make(map[string]bool),
+	}, nil
+}
+
+func (l *exportLoader) load(contextName string) (*output.ExportDocument, error) {
+	if doc, ok := l.docs[contextName]; ok {
+		return doc, nil
+	}
+
+	ctx, notes, files, touches, err := GetContextWithMetadata(contextName)
+	if err != nil {
+		return nil, fmt.Errorf("context %q not found", contextName)
+	}
+
+	// Convert to model values for export
+	doc := &output.ExportDocument{
+		Context: &intmodels.Context{
+			Name:             ctx.Name,
+			StartTime:        ctx.StartTime,
+			EndTime:          ctx.EndTime,
+			Status:           ctx.Status,
+			SubdirectoryPath: ctx.SubdirectoryPath,
+			IsArchived:       ctx.IsArchived,
+		},
+		Metadata:    ctx.Metadata,
+		Notes:       make([]intmodels.Note, 0, len(notes)),
+		Files:       make([]intmodels.FileAssociation, 0, len(files)),
+		Touches:     make([]intmodels.TouchEvent, 0, len(touches)),
+		Transitions: []intmodels.ContextTransition{},
+	}
+	for _, n := range notes {
+		doc.Notes = append(doc.Notes, *n)
+	}
+	for _, f := range files {
+		doc.Files = append(doc.Files, *f)
+	}
+	for _, t := range touches {
+		doc.Touches = append(doc.Touches, *t)
+	}
+	for _, t := range l.transitions {
+		if (t.PreviousContext != nil && *t.PreviousContext == ctx.Name) || (t.NewContext != nil && *t.NewContext == ctx.Name) {
+			doc.Transitions = append(doc.Transitions, *t)
+		}
+	}
+
+	// Link children, skipping any that would form a cycle
+	l.loading[contextName] = true
+	for _, childName := range l.children[contextName] {
+		if l.loading[childName] {
+			continue
+		}
+		child, err := l.load(childName)
+		if err != nil {
+			continue // Skip children that can't be loaded
+		}
+		doc.Children = append(doc.Children, child)
+	}
+	delete(l.loading, contextName)
+
+	l.docs[contextName] = doc
+	return doc, nil
+}
+
+// LoadExportDocument reads a context, its entries and its child contexts for an exporter
+func LoadExportDocument(contextName string) (*output.ExportDocument, error) {
+	loader, err := newExportLoader()
+	if err != nil {
+		return nil, err
+	}
+	return loader.load(contextName)
+}
+
+// ExportContext exports a context to a file with the given exporter
+func ExportContext(contextName, outputPath string, exporter output.Exporter) (string, error) {
+	doc, err := LoadExportDocument(contextName)
+	if err != nil {
+		return "", err
+	}
+
+	// Determine output file path
+	if outputPath == "" {
+		// Default: sanitized context name in current directory
+		outputPath = SanitizeFilename(contextName) + exporter.Extension()
+	}
+
+	return writeExport(exporter, []*output.ExportDocument{doc}, outputPath)
+}
+
+// ExportAllContexts exports all contexts to separate files in a directory
+func ExportAllContexts(outputDir string, exporter output.Exporter) ([]string, error) {
+	contexts, err := ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CreateDir(outputDir); err != nil {
+		return nil, err
+	}
+
+	loader, err := newExportLoader()
+	if err != nil {
+		return nil, err
+	}
+
+	exportedPaths := make([]string, 0, len(contexts))
+	for _, ctx := range contexts {
+		doc, err := loader.load(ctx.Name)
+		if err != nil {
+			continue // Skip failed exports
+		}
+
+		outputPath := filepath.Join(outputDir, SanitizeFilename(ctx.Name)+exporter.Extension())
+		path, err := writeExport(exporter, []*output.ExportDocument{doc}, outputPath)
+		if err != nil {
+			continue // Skip failed exports
+		}
+		exportedPaths = append(exportedPaths, path)
+	}
+
+	return exportedPaths, nil
+}
+
+// ExportCombined exports all contexts, oldest first, into a single document with a table of contents
+func ExportCombined(outputPath string, exporter output.Exporter) (string, error) {
+	contexts, err := ListContexts()
+	if err != nil {
+		return "", err
+	}
+	if len(contexts) == 0 {
+		return "", fmt.Errorf("no contexts to export")
+	}
+
+	loader, err := newExportLoader()
+	if err != nil {
+		return "", err
+	}
+
+	docs := make([]*output.ExportDocument, 0, len(contexts))
+	for i := len(contexts) - 1; i >= 0; i-- {
+		doc, err := loader.load(contexts[i].Name)
+		if err != nil {
+			continue // Skip unreadable contexts, as ExportAllContexts does
+		}
+		docs = append(docs, doc)
+	}
+
+	if outputPath == "" {
+		outputPath = "my-context-export" + exporter.Extension()
+	}
+
+	return writeExport(exporter, docs, outputPath)
+}
+
+// ExportTree exports a context and all of its descendants, as shown by 'tree',
+// into one nested document
+func ExportTree(rootName, outputPath string, exporter output.Exporter) (string, error) {
+	tree, err := GetContextTree(rootName)
+	if err != nil {
+		return "", err
+	}
+
+	loader, err := newExportLoader()
+	if err != nil {
+		return "", err
+	}
+
+	root, err := loader.loadTree(tree)
+	if err != nil {
+		return "", err
+	}
+
+	if outputPath == "" {
+		outputPath = SanitizeFilename(rootName) + "-tree" + exporter.Extension()
+	}
+
+	content, err := exporter.RenderTree(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate %s export: %w", exporter.Name(), err)
+	}
+	return outputPath, writeExportFile(outputPath, content)
+}
+
+// loadTree loads the documents of a context tree, with children in tree order
+func (l *exportLoader) loadTree(node *ContextTreeNode) (*output.ExportDocument, error) {
+	loaded, err := l.load(node.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := *loaded
+	doc.Children = nil
+	for _, childNode := range node.Children {
+		child, err := l.loadTree(childNode)
+		if err != nil {
+			continue // Skip children that can't be loaded, such as cycle markers
+		}
+		doc.Children = append(doc.Children, child)
+	}
+	return &doc, nil
+}
+
+// writeExport renders documents and writes them to outputPath
+func writeExport(exporter output.Exporter, docs []*output.ExportDocument, outputPath string) (string, error) {
+	content, err := exporter.Render(docs)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate %s export: %w", exporter.Name(), err)
+	}
+	return outputPath, writeExportFile(outputPath, content)
+}
+
+func writeExportFile(outputPath, content string) error {
+	// Create parent directories if needed
+	if err := CreateParentDirs(outputPath); err != nil {
+		return err
+	}
+
+	// Write file
+	if err := os.WriteFile(outputPath, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/signal"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// HandoffOptions describes a handoff being made
+type HandoffOptions struct {
+	To        string   // Receiving team (required)
+	From      string   // Handing-off team or person
+	Summary   string   // Where things stand
+	Questions []string // Open questions for the receiving team
+}
+
+// HandoffResult describes what a handoff or accept changed
+type HandoffResult struct {
+	Context    string             `json:"context"`
+	Handoff    *pkgmodels.Handoff `json:"handoff"`
+	WasActive  bool               `json:"was_active"`           // Handoff stopped the context
+	AckSignal  string             `json:"ack_signal,omitempty"` // Signal raised by accept for the sender
+	Superseded string             `json:"superseded,omitempty"` // Context stopped so the accepted one could resume
+}
+
+// HandoffSignalName returns the signal raised when a context is handed to a team.
+// Receiving teams can wait on "handoff-<team>-*" to hear about every handoff.
+func HandoffSignalName(team, contextName string) string {
+	return fmt.Sprintf("handoff-%s-%s", SanitizeContextName(team), SanitizeContextName(contextName))
+}
+
+// HandoffAcceptedSignalName returns the signal raised when a handoff is accepted
+func HandoffAcceptedSignalName(contextName string) string {
+	return fmt.Sprintf("handoff-accepted-%s", SanitizeContextName(contextName))
+}
+
+// HandoffContext stops a context, records a structured handoff note and handoff
+// state in meta.json, and raises a signal for the receiving team.
+func HandoffContext(contextName string, opts HandoffOptions) (*HandoffResult, error) {
+	opts.To = strings.TrimSpace(opts.To)
+	if opts.To == "" {
+		return nil, fmt.Errorf("handoff needs a receiving team (--to)")
+	}
+
+	var ctx pkgmodels.ContextWithMetadata
+	if err := ReadJSON(GetMetaJSONPath(contextName), &ctx); err != nil {
+		return nil, fmt.Errorf("context %q not found", contextName)
+	}
+	if ctx.IsArchived {
+		return nil, fmt.Errorf("cannot hand off archived context %q - unarchive it first", contextName)
+	}
+	if ctx.Metadata.Handoff.IsPending() {
+		return nil, fmt.Errorf("context %q is already handed off to %s - it must be accepted first", contextName, ctx.Metadata.Handoff.To)
+	}
+
+	result := &HandoffResult{Context: contextName}
+
+	state, err := GetActiveContext()
+	if err != nil {
+		return nil, err
+	}
+	if state.GetActiveContextName() == contextName {
+		if _, err := StopContext(); err != nil {
+			return nil, fmt.Errorf("failed to stop %q: %w", contextName, err)
+		}
+		result.WasActive = true
+	}
+
+	_, _, files, _, err := GetContextWithMetadata(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	handoff := &pkgmodels.Handoff{
+		From:      opts.From,
+		To:        opts.To,
+		Status:    pkgmodels.HandoffPending,
+		Summary:   opts.Summary,
+		Questions: opts.Questions,
+		Files:     uniqueFilePaths(files),
+		Signal:    HandoffSignalName(opts.To, contextName),
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := appendContextNote(contextName, formatHandoffNote(handoff)); err != nil {
+		return nil, err
+	}
+
+	if err := updateContextMeta(contextName, func(ctx *pkgmodels.ContextWithMetadata) error {
+		ctx.Metadata.Handoff = handoff
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record handoff: %w", err)
+	}
+
+	message := handoff.Summary
+	if message == "" {
+		message = fmt.Sprintf("%q handed off to %s", contextName, handoff.To)
+	}
+	if err := raiseSignal(handoff.Signal, pkgmodels.SignalPayload{
+		Message: message,
+		Sender:  handoff.From,
+		Context: contextName,
+		Data:    map[string]string{"to": handoff.To},
+	}); err != nil {
+		return nil, err
+	}
+
+	result.Handoff = handoff
+	return result, nil
+}
+
+// AcceptHandoff resumes a handed-off context for the receiving side, records who
+// accepted it, clears the handoff signal and raises an acknowledgement signal.
+// Any other active context is stopped first, like start does, and undo reverts
+// the acceptance (signals excepted).
+func AcceptHandoff(contextName, acceptedBy string) (*HandoffResult, error) {
+	var ctx pkgmodels.ContextWithMetadata
+	if err := ReadJSON(GetMetaJSONPath(contextName), &ctx); err != nil {
+		return nil, fmt.Errorf("context %q not found", contextName)
+	}
+	if !ctx.Metadata.Handoff.IsPending() {
+		return nil, fmt.Errorf("context %q has no pending handoff", contextName)
+	}
+
+	result := &HandoffResult{Context: contextName}
+
+	state, err := GetActiveContext()
+	if err != nil {
+		return nil, err
+	}
+
+	journal := newJournalEntry(JournalOpHandoffAccept, contextName, fmt.Sprintf("accept handoff of %q", contextName))
+	journal.captureState(state)
+	if err := journal.captureMeta(contextName); err != nil {
+		return nil, err
+	}
+
+	transitionType := intmodels.TransitionStart
+	if state.HasActiveContext() && state.GetActiveContextName() != contextName {
+		result.Superseded = state.GetActiveContextName()
+		if err := journal.captureMeta(result.Superseded); err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", result.Superseded, err)
+		}
+		if err := stopContextInternal(result.Superseded); err != nil {
+			return nil, fmt.Errorf("failed to stop %q: %w", result.Superseded, err)
+		}
+		transitionType = intmodels.TransitionSwitch
+	}
+
+	now := time.Now()
+	handoff := ctx.Metadata.Handoff
+	handoff.Status = pkgmodels.HandoffAccepted
+	handoff.AcceptedAt = &now
+	handoff.AcceptedBy = acceptedBy
+
+	if err := updateContextMeta(contextName, func(ctx *pkgmodels.ContextWithMetadata) error {
+		ctx.Status = "active"
+		ctx.EndTime = nil
+		ctx.Metadata.Handoff = handoff
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record handoff: %w", err)
+	}
+
+	if err := SetActiveContext(contextName); err != nil {
+		return nil, fmt.Errorf("failed to activate context: %w", err)
+	}
+
+	transition := &intmodels.ContextTransition{
+		Timestamp:      now,
+		NewContext:     &contextName,
+		TransitionType: transitionType,
+	}
+	if transitionType == intmodels.TransitionSwitch {
+		transition.PreviousContext = &result.Superseded
+	}
+	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
+		return nil, fmt.Errorf("failed to log transition: %w", err)
+	}
+	journal.appended(journalLogTransitions, "", transition.ToLogLine())
+
+	note := fmt.Sprintf("HANDOFF ACCEPTED by %s", handoff.To)
+	if acceptedBy != "" && acceptedBy != handoff.To {
+		note = fmt.Sprintf("HANDOFF ACCEPTED by %s (%s)", handoff.To, acceptedBy)
+	}
+	line, err := appendContextNote(contextName, note)
+	if err != nil {
+		return nil, err
+	}
+	journal.appended(journalLogNotes, contextName, line)
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, err
+	}
+
+	manager, err := signal.NewManager(GetSignalsDir())
+	if err != nil {
+		return nil, err
+	}
+	if manager.SignalExists(handoff.Signal) {
+		if err := manager.ClearSignal(handoff.Signal); err != nil {
+			return nil, err
+		}
+	}
+
+	result.AckSignal = HandoffAcceptedSignalName(contextName)
+	if err := raiseSignal(result.AckSignal, pkgmodels.SignalPayload{
+		Message: note,
+		Sender:  acceptedBy,
+		Context: contextName,
+		Data:    map[string]string{"from": handoff.From, "to": handoff.To},
+	}); err != nil {
+		return nil, err
+	}
+
+	result.Handoff = handoff
+	return result, nil
+}
+
+// PendingHandoffs returns contexts waiting to be accepted, oldest handoff first.
+// A non-empty team keeps only handoffs addressed to it.
+func PendingHandoffs(team string) ([]*pkgmodels.ContextWithMetadata, error) {
+	dirs, err := ListContextDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*pkgmodels.ContextWithMetadata
+	for _, dir := range dirs {
+		var ctx pkgmodels.ContextWithMetadata
+		if err := ReadJSON(contextMetaPathInDir(dir), &ctx); err != nil {
+			continue // Skip contexts with invalid meta.json
+		}
+		if !ctx.Metadata.Handoff.IsPending() {
+			continue
+		}
+		if team != "" && ctx.Metadata.Handoff.To != team {
+			continue
+		}
+		pending = append(pending, &ctx)
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Metadata.Handoff.CreatedAt.Before(pending[j].Metadata.Handoff.CreatedAt)
+	})
+	return pending, nil
+}
+
+// formatHandoffNote renders the structured note written when a context is handed off
+func formatHandoffNote(h *pkgmodels.Handoff) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("HANDOFF TO %s", h.To))
+	if h.From != "" {
+		sb.WriteString(fmt.Sprintf(" from %s", h.From))
+	}
+	if h.Summary != "" {
+		sb.WriteString(fmt.Sprintf("\nSummary: %s", h.Summary))
+	}
+	if len(h.Questions) > 0 {
+		sb.WriteString("\nOpen questions:")
+		for _, q := range h.Questions {
+			sb.WriteString(fmt.Sprintf("\n- %s", q))
+		}
+	}
+	if len(h.Files) > 0 {
+		sb.WriteString("\nFiles:")
+		for _, f := range h.Files {
+			sb.WriteString(fmt.Sprintf("\n- %s", f))
+		}
+	}
+	return sb.String()
+}
+
+// appendContextNote adds a note to any context, active or not, and returns the line written
+func appendContextNote(contextName, text string) (string, error) {
+	note := &intmodels.Note{
+		Timestamp:   time.Now(),
+		TextContent: text,
+	}
+	if err := note.Validate(); err != nil {
+		return "", err
+	}
+	line := note.ToLogLine()
+	return line, AppendLog(GetNotesLogPath(contextName), line)
+}
+
+// raiseSignal creates a global signal, replacing one left over from an earlier round
+func raiseSignal(name string, payload pkgmodels.SignalPayload) error {
+	manager, err := signal.NewManager(GetSignalsDir())
+	if err != nil {
+		return err
+	}
+	if manager.SignalExists(name) {
+		if err := manager.ClearSignal(name); err != nil {
+			return err
+		}
+	}
+	if _, err := manager.CreateSignalWithPayload(name, payload, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// uniqueFilePaths returns associated file paths in first-seen order
+func uniqueFilePaths(files []*intmodels.FileAssociation) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, file := range files {
+		path := DenormalizePath(file.FilePath)
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
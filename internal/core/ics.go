@@ -0,0 +1,67 @@
+package core
+
+import (
+	"strings"
+	"time"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// DefaultICSPath is where export-ics writes when no --to is given
+const DefaultICSPath = "my-context.ics"
+
+// GetCalendarEvents returns the sessions that ran at or after from (all
+// sessions when from is zero), each with the notes written during it. An empty
+// project includes all projects.
+func GetCalendarEvents(from time.Time, project string) ([]output.CalendarEvent, error) {
+	sessions, err := GetSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	notesByContext := make(map[string][]*intmodels.Note)
+	events := []output.CalendarEvent{}
+	for _, s := range sessions {
+		if s.End.Before(from) {
+			continue
+		}
+		projectName := ExtractProjectName(s.Context)
+		if project != "" && !strings.EqualFold(projectName, strings.TrimSpace(project)) {
+			continue
+		}
+
+		notes, ok := notesByContext[s.Context]
+		if !ok {
+			// Deleted contexts keep their sessions, without notes
+			_, notes, _, _, _ = GetContext(s.Context)
+			notesByContext[s.Context] = notes
+		}
+
+		event := output.CalendarEvent{Session: s, Project: projectName}
+		for _, n := range notes {
+			if s.Contains(n.Timestamp) {
+				event.Notes = append(event.Notes, *n)
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ExportICS writes the sessions selected as for GetCalendarEvents to an
+// iCalendar file and returns its path and the number of events
+func ExportICS(from time.Time, project, outputPath string) (string, int, error) {
+	events, err := GetCalendarEvents(from, project)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if outputPath == "" {
+		outputPath = DefaultICSPath
+	}
+	if err := writeExportFile(outputPath, output.FormatICS(events)); err != nil {
+		return "", 0, err
+	}
+	return outputPath, len(events), nil
+}
@@ -0,0 +1,321 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Each context has a short ID that never changes, even on rename. The context's
+// directory is named after its ID, and index.json in the context home maps
+// display names to IDs so the name-based helpers can find the directory.
+
+const (
+	contextIndexFile    = "index.json"
+	contextIndexVersion = 1
+
+	// contextIDLength and contextIDAlphabet give about a billion IDs, spelled
+	// without the easily confused i, l, o and u
+	contextIDLength   = 6
+	contextIDAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+)
+
+// contextIndex is the content of index.json
+type contextIndex struct {
+	Version  int               `json:"version"`
+	Contexts map[string]string `json:"contexts"` // Display name → ID
+}
+
+// indexCache holds the index last read or written, so the path helpers don't
+// read index.json on every call. It is keyed by path because the context home
+// can change (tests point MY_CONTEXT_HOME elsewhere). Cached indexes are never
+// modified; updates read index.json afresh and replace the cache when saved.
+var indexCache struct {
+	sync.Mutex
+	path  string
+	index *contextIndex
+}
+
+// GetContextIndexPath returns the path to the index.json file
+func GetContextIndexPath() string {
+	return filepath.Join(GetContextHome(), contextIndexFile)
+}
+
+// readContextIndex reads index.json, first moving any directories from before
+// IDs existed into ID directories
+func readContextIndex() (*contextIndex, error) {
+	index, err := loadContextIndex()
+	if err != nil {
+		return nil, err
+	}
+	if index.Version >= contextIndexVersion {
+		return index, nil
+	}
+	if err := migrateContextDirs(index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// cachedContextIndex returns the index, reading index.json only the first time
+// in a process (or after the context home changes). The result must not be modified.
+func cachedContextIndex() (*contextIndex, error) {
+	indexCache.Lock()
+	path, index := indexCache.path, indexCache.index
+	indexCache.Unlock()
+	if index != nil && path == GetContextIndexPath() {
+		return index, nil
+	}
+	return reloadContextIndex()
+}
+
+// reloadContextIndex reads index.json into the cache, picking up contexts
+// another process has created since it was last read
+func reloadContextIndex() (*contextIndex, error) {
+	index, err := readContextIndex()
+	if err != nil {
+		return nil, err
+	}
+	setCachedContextIndex(index)
+	return index, nil
+}
+
+func setCachedContextIndex(index *contextIndex) {
+	indexCache.Lock()
+	defer indexCache.Unlock()
+	indexCache.path = GetContextIndexPath()
+	indexCache.index = index
+}
+
+func loadContextIndex() (*contextIndex, error) {
+	index := &contextIndex{Contexts: map[string]string{}}
+	data, err := os.ReadFile(GetContextIndexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", contextIndexFile, err)
+	}
+	if index.Contexts == nil {
+		index.Contexts = map[string]string{}
+	}
+	return index, nil
+}
+
+func writeContextIndex(index *contextIndex) error {
+	if err := EnsureContextHome(); err != nil {
+		return err
+	}
+	index.Version = contextIndexVersion
+	if err := WriteJSON(GetContextIndexPath(), index); err != nil {
+		return err
+	}
+	setCachedContextIndex(index)
+	return nil
+}
+
+// updateContextIndex applies change to the index as it is in index.json and
+// saves it, refreshing the cache
+func updateContextIndex(change func(index *contextIndex) error) error {
+	index, err := readContextIndex()
+	if err != nil {
+		return err
+	}
+	if err := change(index); err != nil {
+		return err
+	}
+	return writeContextIndex(index)
+}
+
+// lookupContextID returns the ID of the context with the given display name.
+// A name missing from the cache is looked up again in index.json.
+func lookupContextID(contextName string) (string, bool) {
+	index, err := cachedContextIndex()
+	if err != nil {
+		return "", false
+	}
+	if id, ok := index.Contexts[contextName]; ok {
+		return id, true
+	}
+	if index, err = reloadContextIndex(); err != nil {
+		return "", false
+	}
+	id, ok := index.Contexts[contextName]
+	return id, ok
+}
+
+// contextDirFor returns the directory of the named context. A name that isn't
+// in the index gets a path that never exists, so it can't alias an ID directory.
+func contextDirFor(contextName string) string {
+	if id, ok := lookupContextID(contextName); ok {
+		return GetContextDir(id)
+	}
+	return filepath.Join(GetContextHome(), ".unindexed", SanitizeContextName(contextName))
+}
+
+// contextMetaPathInDir returns the meta.json path inside a directory from ListContextDirs
+func contextMetaPathInDir(dir string) string {
+	return filepath.Join(GetContextDir(dir), "meta.json")
+}
+
+// GetContextID returns the ID of the named context
+func GetContextID(contextName string) (string, error) {
+	id, ok := lookupContextID(contextName)
+	if !ok {
+		return "", fmt.Errorf("context %q not found", contextName)
+	}
+	return id, nil
+}
+
+// ContextNameOrID returns the display name a command-line argument refers to:
+// the argument itself when a context has that name, or the name of the context
+// with that ID. Anything else is returned unchanged for the caller to report.
+func ContextNameOrID(arg string) string {
+	for _, load := range []func() (*contextIndex, error){cachedContextIndex, reloadContextIndex} {
+		index, err := load()
+		if err != nil {
+			return arg
+		}
+		if _, ok := index.Contexts[arg]; ok {
+			return arg
+		}
+		for name, id := range index.Contexts {
+			if id == arg {
+				return name
+			}
+		}
+	}
+	return arg
+}
+
+// newContextID returns a random ID not used by any directory in the context home
+func newContextID() (string, error) {
+	buf := make([]byte, contextIDLength)
+	for attempt := 0; attempt < 100; attempt++ {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to generate context ID: %w", err)
+		}
+		id := make([]byte, contextIDLength)
+		for i, b := range buf {
+			id[i] = contextIDAlphabet[int(b)%len(contextIDAlphabet)]
+		}
+		if !FileExists(GetContextDir(string(id))) {
+			return string(id), nil
+		}
+	}
+	return "", fmt.Errorf("failed to find an unused context ID")
+}
+
+// uniqueContextName returns name, or name with _2, _3, ... appended if a
+// context already has it
+func uniqueContextName(name string) (string, error) {
+	index, err := readContextIndex()
+	if err != nil {
+		return "", err
+	}
+	candidate := name
+	for i := 2; ; i++ {
+		if _, taken := index.Contexts[candidate]; !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+}
+
+// allocateContextDir gives a new context an ID, registers its name and creates
+// its directory
+func allocateContextDir(contextName string) (id, dir string, err error) {
+	id, err = newContextID()
+	if err != nil {
+		return "", "", err
+	}
+	dir = GetContextDir(id)
+	if err := CreateDir(dir); err != nil {
+		return "", "", err
+	}
+	if err := updateContextIndex(func(index *contextIndex) error {
+		if _, taken := index.Contexts[contextName]; taken {
+			return fmt.Errorf("context %q already exists", contextName)
+		}
+		index.Contexts[contextName] = id
+		return nil
+	}); err != nil {
+		os.Remove(dir)
+		return "", "", err
+	}
+	return id, dir, nil
+}
+
+// migrateContextDirs moves context directories named after their context (the
+// layout before IDs) to ID directories, recording the ID in meta.json, and
+// rebuilds the index from what it finds
+func migrateContextDirs(index *contextIndex) error {
+	dirs, err := listContextHomeDirs()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 && !FileExists(GetContextHome()) {
+		// Nothing to migrate; the index is written with the first context
+		index.Version = contextIndexVersion
+		return nil
+	}
+	// Sorted so that when two directories claim the same name the result is repeatable
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		metaPath := contextMetaPathInDir(dir)
+		data, err := os.ReadFile(metaPath)
+		if err != nil {
+			continue // Not a context
+		}
+		// Edit meta.json generically so fields this version doesn't know survive
+		var meta map[string]interface{}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			continue // Skip contexts with invalid meta.json, as ListContexts does
+		}
+		name, _ := meta["name"].(string)
+		if name == "" {
+			name = dir
+		}
+		id, _ := meta["id"].(string)
+
+		if owner, taken := index.Contexts[name]; taken && owner != id {
+			renamed := name
+			for i := 2; ; i++ {
+				renamed = fmt.Sprintf("%s_%d", name, i)
+				if _, taken := index.Contexts[renamed]; !taken {
+					break
+				}
+			}
+			name = renamed
+		}
+
+		if id == "" || (id != dir && FileExists(GetContextDir(id))) {
+			if id, err = newContextID(); err != nil {
+				return err
+			}
+		}
+		if dir != id {
+			if err := os.Rename(GetContextDir(dir), GetContextDir(id)); err != nil {
+				return fmt.Errorf("failed to move context %q to its ID directory: %w", name, err)
+			}
+		}
+
+		meta["id"] = id
+		meta["name"] = name
+		meta["subdirectory_path"] = GetContextDir(id)
+		if err := WriteJSON(contextMetaPathInDir(id), meta); err != nil {
+			return fmt.Errorf("failed to record ID of context %q: %w", name, err)
+		}
+		index.Contexts[name] = id
+	}
+
+	return writeContextIndex(index)
+}
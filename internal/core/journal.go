@@ -0,0 +1,294 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// MaxJournalEntries bounds how many operations can be undone
+const MaxJournalEntries = 100
+
+// Journal operation names
+const (
+	JournalOpStart         = "start"
+	JournalOpStop          = "stop"
+	JournalOpNote          = "note"
+	JournalOpFile          = "file"
+	JournalOpTag           = "tag"
+	JournalOpUntag         = "untag"
+	JournalOpLink          = "link"
+	JournalOpUnlink        = "unlink"
+	JournalOpArchive       = "archive"
+	JournalOpUnarchive     = "unarchive"
+	JournalOpState         = "state"
+	JournalOpNoteDone      = "note done"
+	JournalOpResume        = "resume"
+	JournalOpSwitch        = "switch"
+	JournalOpHandoffAccept = "handoff accept"
+)
+
+// Log kinds a journal entry can have appended to
+const (
+	journalLogNotes       = "notes"
+	journalLogFiles       = "files"
+	journalLogTransitions = "transitions"
+)
+
+// JournalEntry records everything needed to revert one mutating operation
+type JournalEntry struct {
+	Timestamp      time.Time                  `json:"timestamp"`
+	Op             string                     `json:"op"`
+	Context        string                     `json:"context"`
+	Summary        string                     `json:"summary"`
+	StateBefore    *models.AppState           `json:"state_before,omitempty"`    // Restored when set (start/stop/resume/switch)
+	CreatedContext string                     `json:"created_context,omitempty"` // Context created by the operation
+	MetaBefore     map[string]json.RawMessage `json:"meta_before,omitempty"`     // meta.json contents keyed by context name
+	Appended       []JournalAppend            `json:"appended,omitempty"`        // Log lines written by the operation
+	Replaced       []JournalReplace           `json:"replaced,omitempty"`        // Log lines rewritten by the operation
+}
+
+// JournalAppend is a single log line written by an operation
+type JournalAppend struct {
+	Log     string `json:"log"`               // notes, files or transitions
+	Context string `json:"context,omitempty"` // Empty for transitions
+	Line    string `json:"line"`
+}
+
+// JournalReplace is a log line an operation rewrote in place
+type JournalReplace struct {
+	Log     string `json:"log"` // notes
+	Context string `json:"context"`
+	Index   int    `json:"index"` // Line number in the log, from 0
+	Old     string `json:"old"`
+	New     string `json:"new"`
+}
+
+// GetJournalPath returns the path to the operation journal
+func GetJournalPath() string {
+	return filepath.Join(GetContextHome(), "journal.json")
+}
+
+// newJournalEntry starts a journal entry for an operation about to run
+func newJournalEntry(op, contextName, summary string) *JournalEntry {
+	return &JournalEntry{
+		Timestamp: time.Now(),
+		Op:        op,
+		Context:   contextName,
+		Summary:   summary,
+	}
+}
+
+// captureState remembers state.json so undo can restore the previously active context
+func (e *JournalEntry) captureState(state *models.AppState) {
+	snapshot := *state
+	e.StateBefore = &snapshot
+}
+
+// captureMeta remembers a context's meta.json as it is before the operation changes it
+func (e *JournalEntry) captureMeta(contextName string) error {
+	data, err := os.ReadFile(GetMetaJSONPath(contextName))
+	if err != nil {
+		return err
+	}
+	if e.MetaBefore == nil {
+		e.MetaBefore = make(map[string]json.RawMessage)
+	}
+	e.MetaBefore[contextName] = json.RawMessage(data)
+	return nil
+}
+
+// appended records a log line written by the operation
+func (e *JournalEntry) appended(log, contextName, line string) {
+	e.Appended = append(e.Appended, JournalAppend{Log: log, Context: contextName, Line: line})
+}
+
+// replaced records a log line the operation rewrote
+func (e *JournalEntry) replaced(log, contextName string, index int, oldLine, newLine string) {
+	e.Replaced = append(e.Replaced, JournalReplace{Log: log, Context: contextName, Index: index, Old: oldLine, New: newLine})
+}
+
+// ReadJournal returns recorded operations, oldest first
+func ReadJournal() ([]*JournalEntry, error) {
+	path := GetJournalPath()
+	if !FileExists(path) {
+		return []*JournalEntry{}, nil
+	}
+
+	var entries []*JournalEntry
+	if err := ReadJSON(path, &entries); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// writeJournal replaces the journal, keeping only the most recent entries
+func writeJournal(entries []*JournalEntry) error {
+	if len(entries) > MaxJournalEntries {
+		entries = entries[len(entries)-MaxJournalEntries:]
+	}
+	return WriteJSON(GetJournalPath(), entries)
+}
+
+// recordJournalEntry appends a completed operation to the journal
+func recordJournalEntry(entry *JournalEntry) error {
+	entries, err := ReadJournal()
+	if err != nil {
+		return err
+	}
+	if err := writeJournal(append(entries, entry)); err != nil {
+		return fmt.Errorf("failed to record operation in journal: %w", err)
+	}
+	return nil
+}
+
+// UndoLast reverts the most recent journaled operation and removes it from the journal.
+// Reverting writes files directly, so it never adds journal entries of its own.
+func UndoLast() (*JournalEntry, error) {
+	entries, err := ReadJournal()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+
+	entry := entries[len(entries)-1]
+	if err := revertJournalEntry(entry); err != nil {
+		return nil, fmt.Errorf("failed to undo %s: %w", entry.Summary, err)
+	}
+
+	if err := writeJournal(entries[:len(entries)-1]); err != nil {
+		return nil, fmt.Errorf("failed to update journal: %w", err)
+	}
+
+	return entry, nil
+}
+
+// revertJournalEntry applies the inverse of a journaled operation
+func revertJournalEntry(entry *JournalEntry) error {
+	// Remove written lines, newest first
+	for i := len(entry.Appended) - 1; i >= 0; i-- {
+		appended := entry.Appended[i]
+		if err := removeLastLogLine(journalLogPath(appended), appended.Line); err != nil {
+			return err
+		}
+	}
+
+	// Put rewritten lines back, newest first
+	for i := len(entry.Replaced) - 1; i >= 0; i-- {
+		replaced := entry.Replaced[i]
+		path := journalLogPath(JournalAppend{Log: replaced.Log, Context: replaced.Context})
+		if err := replaceLogLine(path, replaced.Index, replaced.New, replaced.Old); err != nil {
+			return err
+		}
+	}
+
+	// Restore the active context first: the created context can't be trashed while active
+	if entry.StateBefore != nil {
+		state := *entry.StateBefore
+		state.LastUpdated = time.Now()
+		if err := WriteJSON(GetStateFilePath(), &state); err != nil {
+			return fmt.Errorf("failed to restore active context: %w", err)
+		}
+	}
+
+	// A context created by the operation goes to the trash, so undo itself can be undone
+	if entry.CreatedContext != "" && FileExists(GetMetaJSONPath(entry.CreatedContext)) {
+		if err := stopContextInternal(entry.CreatedContext); err != nil {
+			return err
+		}
+		if _, err := TrashContext(entry.CreatedContext, "undo start"); err != nil {
+			return err
+		}
+	}
+
+	for contextName, data := range entry.MetaBefore {
+		metaPath := GetMetaJSONPath(contextName)
+		if !FileExists(metaPath) {
+			continue // Deleted since - nothing to restore into
+		}
+		if err := writeFileAtomic(metaPath, data); err != nil {
+			return fmt.Errorf("failed to restore %q: %w", contextName, err)
+		}
+	}
+
+	return nil
+}
+
+// journalLogPath resolves the file a journaled log line was written to
+func journalLogPath(appended JournalAppend) string {
+	switch appended.Log {
+	case journalLogNotes:
+		return GetNotesLogPath(appended.Context)
+	case journalLogFiles:
+		return GetFilesLogPath(appended.Context)
+	default:
+		return GetTransitionsLogPath()
+	}
+}
+
+// removeLastLogLine deletes the last occurrence of line from a log file.
+// A missing file or line means it is already gone, which is not an error.
+func removeLastLogLine(path, line string) error {
+	return editLastLogLine(path, line, func(lines []string, i int) []string {
+		return append(lines[:i], lines[i+1:]...)
+	})
+}
+
+// replaceLogLine replaces line index of a log file, which must still be
+// oldLine: identical lines (same second, same text) can't be told apart by
+// content. If the line has moved, the last occurrence of oldLine is replaced.
+// A missing file or line is not an error, as for removeLastLogLine.
+func replaceLogLine(path string, index int, oldLine, newLine string) error {
+	lines, err := ReadLog(path)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(lines) || lines[index] != oldLine {
+		return editLastLogLine(path, oldLine, func(lines []string, i int) []string {
+			lines[i] = newLine
+			return lines
+		})
+	}
+
+	lines[index] = newLine
+	return writeFileAtomic(path, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// editLastLogLine rewrites a log file with edit applied at the last occurrence of line
+func editLastLogLine(path, line string, edit func(lines []string, i int) []string) error {
+	lines, err := ReadLog(path)
+	if err != nil {
+		return err
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i] != line {
+			continue
+		}
+		lines = edit(lines, i)
+
+		content := strings.Join(lines, "\n")
+		if len(lines) > 0 {
+			content += "\n"
+		}
+		return writeFileAtomic(path, []byte(content))
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data through a temp file and rename, like WriteJSON
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,488 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// MergeResult describes the outcome of merging two contexts
+type MergeResult struct {
+	Into            string        `json:"into"`
+	Sources         []string      `json:"sources"`
+	NoteCount       int           `json:"note_count"`
+	FileCount       int           `json:"file_count"`
+	TouchCount      int           `json:"touch_count"`
+	Labels          []string      `json:"labels"`
+	ChildrenUpdated []string      `json:"children_updated"`
+	Trashed         []*TrashEntry `json:"trashed"`
+}
+
+// SplitResult describes the outcome of splitting a context
+type SplitResult struct {
+	Source     string `json:"source"`
+	Into       string `json:"into"`
+	AfterNote  int    `json:"after_note"`
+	NoteCount  int    `json:"note_count"`  // Notes moved to the new context
+	FileCount  int    `json:"file_count"`  // Files moved to the new context
+	TouchCount int    `json:"touch_count"` // Touches moved to the new context
+}
+
+// timedLine is a raw log line with its parsed timestamp
+type timedLine struct {
+	Timestamp time.Time
+	Line      string
+	Parsed    bool // False for a line that didn't parse; it carries the timestamp of the line before it
+}
+
+// readTimedLog reads a log in order, keeping raw lines (so escaping is preserved)
+// alongside timestamps. Lines that don't parse, such as the start marker, are
+// kept too, dated like the line before them so they stay with it when merged.
+func readTimedLog(path string, parse func(string) (time.Time, error)) ([]timedLine, error) {
+	lines, err := ReadLog(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var timed []timedLine
+	var last time.Time
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		ts, err := parse(line)
+		if err != nil {
+			timed = append(timed, timedLine{Timestamp: last, Line: line})
+			continue
+		}
+		last = ts
+		timed = append(timed, timedLine{Timestamp: ts, Line: line, Parsed: true})
+	}
+	return timed, nil
+}
+
+// parsedLines returns the entries of a log that parsed
+func parsedLines(entries []timedLine) []timedLine {
+	var parsed []timedLine
+	for _, entry := range entries {
+		if entry.Parsed {
+			parsed = append(parsed, entry)
+		}
+	}
+	return parsed
+}
+
+func noteTimestamp(line string) (time.Time, error) {
+	note, err := intmodels.ParseNoteLogLine(line)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return note.Timestamp, nil
+}
+
+func fileTimestamp(line string) (time.Time, error) {
+	file, err := intmodels.ParseFileLogLine(line)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return file.Timestamp, nil
+}
+
+func touchTimestamp(line string) (time.Time, error) {
+	touch, err := intmodels.ParseTouchLogLine(line)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return touch.Timestamp, nil
+}
+
+// interleave merges log entries from several contexts in timestamp order
+func interleave(parts ...[]timedLine) []string {
+	var all []timedLine
+	for _, part := range parts {
+		all = append(all, part...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Timestamp.Before(all[j].Timestamp)
+	})
+
+	lines := make([]string, 0, len(all))
+	for _, entry := range all {
+		lines = append(lines, entry.Line)
+	}
+	return lines
+}
+
+// writeLogLines replaces a log file with the given lines
+func writeLogLines(path string, lines []string) error {
+	content := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		content += "\n"
+	}
+	return writeFileAtomic(path, []byte(content))
+}
+
+// contextLogs holds the timestamped entries of one context
+type contextLogs struct {
+	notes, files, touches []timedLine
+}
+
+// readContextLogs reads every line of a context's logs, including lines that don't parse
+func readContextLogs(contextName string) (*contextLogs, error) {
+	notes, err := readTimedLog(GetNotesLogPath(contextName), noteTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	files, err := readTimedLog(GetFilesLogPath(contextName), fileTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	touches, err := readTimedLog(GetTouchLogPath(contextName), touchTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &contextLogs{notes: notes, files: files, touches: touches}, nil
+}
+
+// writeStoppedContext creates a context, with a new ID, from existing data without touching state.json
+func writeStoppedContext(ctx *pkgmodels.ContextWithMetadata, notes, files, touches []string) error {
+	var err error
+	ctx.ID, ctx.SubdirectoryPath, err = allocateContextDir(ctx.Name)
+	if err != nil {
+		return err
+	}
+	if err := WriteJSON(GetMetaJSONPath(ctx.Name), ctx); err != nil {
+		return fmt.Errorf("failed to write context metadata: %w", err)
+	}
+	if err := writeLogLines(GetNotesLogPath(ctx.Name), notes); err != nil {
+		return err
+	}
+	if err := writeLogLines(GetFilesLogPath(ctx.Name), files); err != nil {
+		return err
+	}
+	return writeLogLines(GetTouchLogPath(ctx.Name), touches)
+}
+
+// loadStoppedContext loads a context for merge/split, rejecting the active one
+func loadStoppedContext(contextName string) (*pkgmodels.ContextWithMetadata, error) {
+	ctx, _, _, _, err := GetContextWithMetadata(contextName)
+	if err != nil {
+		return nil, fmt.Errorf("context %q not found", contextName)
+	}
+	active, err := isActiveContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, fmt.Errorf("context %q is active - stop it first", contextName)
+	}
+	return ctx, nil
+}
+
+// MergeContexts combines two stopped contexts into one, interleaving notes, files and
+// touches by timestamp. Labels are unioned and children of either source are re-linked.
+// The sources are moved to the trash, so a merge can be reverted with `trash restore`;
+// if the merge fails partway they are restored. A merge transition per source
+// attributes the sources' sessions to the merged context.
+// into may be the name of one of the sources.
+func MergeContexts(a, b, into string) (*MergeResult, error) {
+	into = strings.TrimSpace(into)
+	if into == "" {
+		return nil, fmt.Errorf("merged context name cannot be empty")
+	}
+	if a == b {
+		return nil, fmt.Errorf("cannot merge context %q with itself", a)
+	}
+
+	ctxA, err := loadStoppedContext(a)
+	if err != nil {
+		return nil, err
+	}
+	ctxB, err := loadStoppedContext(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if into != a && into != b {
+		if _, err := FindContextByName(into); err == nil {
+			return nil, fmt.Errorf("context %q already exists", into)
+		}
+	}
+
+	logsA, err := readContextLogs(a)
+	if err != nil {
+		return nil, err
+	}
+	logsB, err := readContextLogs(b)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &pkgmodels.ContextWithMetadata{
+		Name:      into,
+		StartTime: ctxA.StartTime,
+		EndTime:   ctxA.EndTime,
+		Status:    "stopped",
+		Metadata: pkgmodels.ContextMetadata{
+			CreatedBy: ctxA.Metadata.CreatedBy,
+			Parent:    ctxA.Metadata.Parent,
+			Labels:    mergeLabels(ctxA.Metadata.Labels, ctxB.Metadata.Labels),
+		},
+	}
+	if ctxB.StartTime.Before(merged.StartTime) {
+		merged.StartTime = ctxB.StartTime
+	}
+	if ctxB.EndTime != nil && (merged.EndTime == nil || ctxB.EndTime.After(*merged.EndTime)) {
+		merged.EndTime = ctxB.EndTime
+	}
+	if merged.Metadata.CreatedBy == "" {
+		merged.Metadata.CreatedBy = ctxB.Metadata.CreatedBy
+	}
+	if merged.Metadata.Parent == "" {
+		merged.Metadata.Parent = ctxB.Metadata.Parent
+	}
+	// A source can't be the parent of the merged context
+	if merged.Metadata.Parent == a || merged.Metadata.Parent == b {
+		merged.Metadata.Parent = ""
+	}
+
+	notes := interleave(logsA.notes, logsB.notes)
+	files := interleave(logsA.files, logsB.files)
+	touches := interleave(logsA.touches, logsB.touches)
+
+	result := &MergeResult{
+		Into:            into,
+		Sources:         []string{a, b},
+		NoteCount:       len(parsedLines(logsA.notes)) + len(parsedLines(logsB.notes)),
+		FileCount:       len(parsedLines(logsA.files)) + len(parsedLines(logsB.files)),
+		TouchCount:      len(parsedLines(logsA.touches)) + len(parsedLines(logsB.touches)),
+		Labels:          append([]string{}, merged.Metadata.Labels...),
+		ChildrenUpdated: []string{},
+	}
+
+	// Move sources out of the way first so into may reuse one of their names.
+	// From here on a failure puts everything back (see rollbackMerge).
+	relinked := make(map[string]string) // Child -> the source it belonged to
+	for _, source := range []string{a, b} {
+		entry, err := TrashContext(source, fmt.Sprintf("merged into %q", into))
+		if err != nil {
+			return nil, rollbackMerge(err, merged, result.Trashed, relinked)
+		}
+		result.Trashed = append(result.Trashed, entry)
+	}
+
+	if err := writeStoppedContext(merged, notes, files, touches); err != nil {
+		return nil, rollbackMerge(fmt.Errorf("failed to write merged context: %w", err), merged, result.Trashed, relinked)
+	}
+
+	// Children of either source now belong to the merged context
+	for _, source := range []string{a, b} {
+		children, err := GetChildren(source)
+		if err != nil {
+			return nil, rollbackMerge(err, merged, result.Trashed, relinked)
+		}
+		for _, child := range children {
+			if child == into {
+				continue
+			}
+			if err := updateContextMeta(child, func(ctx *pkgmodels.ContextWithMetadata) error {
+				ctx.Metadata.Parent = into
+				return nil
+			}); err != nil {
+				return nil, rollbackMerge(fmt.Errorf("failed to update parent of %q: %w", child, err), merged, result.Trashed, relinked)
+			}
+			relinked[child] = source
+			result.ChildrenUpdated = append(result.ChildrenUpdated, child)
+		}
+	}
+
+	// Sessions and history of the sources now belong to the merged context
+	now := time.Now()
+	for _, source := range []string{a, b} {
+		if source == into {
+			continue
+		}
+		transition := &intmodels.ContextTransition{
+			Timestamp:       now,
+			PreviousContext: &source,
+			NewContext:      &into,
+			TransitionType:  intmodels.TransitionMerge,
+		}
+		if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
+			return nil, rollbackMerge(fmt.Errorf("failed to log transition: %w", err), merged, result.Trashed, relinked)
+		}
+	}
+
+	return result, nil
+}
+
+// rollbackMerge undoes a failed merge: children go back to their source, a
+// partly written merged context is removed and the sources come out of the
+// trash. It returns cause, noting anything that could not be put back.
+func rollbackMerge(cause error, merged *pkgmodels.ContextWithMetadata, trashed []*TrashEntry, relinked map[string]string) error {
+	var failures []string
+
+	for child, source := range relinked {
+		if err := updateContextMeta(child, func(ctx *pkgmodels.ContextWithMetadata) error {
+			ctx.Metadata.Parent = source
+			return nil
+		}); err != nil {
+			failures = append(failures, fmt.Sprintf("re-link %q to %q: %v", child, source, err))
+		}
+	}
+
+	if merged.ID != "" {
+		if err := os.RemoveAll(GetContextDir(merged.ID)); err != nil {
+			failures = append(failures, fmt.Sprintf("remove %q: %v", merged.Name, err))
+		}
+		if err := updateContextIndex(func(index *contextIndex) error {
+			if index.Contexts[merged.Name] == merged.ID {
+				delete(index.Contexts, merged.Name)
+			}
+			return nil
+		}); err != nil {
+			failures = append(failures, fmt.Sprintf("unregister %q: %v", merged.Name, err))
+		}
+	}
+
+	for i := len(trashed) - 1; i >= 0; i-- {
+		if _, err := RestoreFromTrash(trashed[i].ID); err != nil {
+			failures = append(failures, fmt.Sprintf("restore %q (trash restore %s): %v", trashed[i].ContextName, trashed[i].ID, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%w; rolling back also failed: %s", cause, strings.Join(failures, "; "))
+	}
+	return cause
+}
+
+// mergeLabels returns the union of two label lists, preserving first-seen order
+func mergeLabels(a, b []string) []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, label := range append(append([]string{}, a...), b...) {
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// SplitContext moves every note after the given note ID (1-based, as shown by `show`),
+// plus files and touches recorded after that note, into a new stopped context linked
+// to the original as its child.
+func SplitContext(contextName string, afterNote int, into string) (*SplitResult, error) {
+	into = strings.TrimSpace(into)
+	if into == "" {
+		return nil, fmt.Errorf("new context name cannot be empty")
+	}
+
+	ctx, err := loadStoppedContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := FindContextByName(into); err == nil {
+		return nil, fmt.Errorf("context %q already exists", into)
+	}
+
+	noteLines, err := readTimedLog(GetNotesLogPath(contextName), noteTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	notes := parsedLines(noteLines)
+	if len(notes) < 2 {
+		return nil, fmt.Errorf("context %q has %d note(s) and can't be split: there must be a note after the split point", contextName, len(notes))
+	}
+	if afterNote < 1 || afterNote >= len(notes) {
+		return nil, fmt.Errorf("note ID must be between 1 and %d (the last note cannot be split after)", len(notes)-1)
+	}
+
+	cutoff := notes[afterNote-1].Timestamp
+	files, err := readTimedLog(GetFilesLogPath(contextName), fileTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	touches, err := readTimedLog(GetTouchLogPath(contextName), touchTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lines keep their order; notes are moved by number rather than by time
+	keptNotes, movedNotes := []string{}, []string{}
+	number := 0
+	for _, note := range noteLines {
+		if note.Parsed {
+			number++
+		}
+		if note.Parsed && number > afterNote {
+			movedNotes = append(movedNotes, note.Line)
+		} else {
+			keptNotes = append(keptNotes, note.Line)
+		}
+	}
+	keptFiles, movedFiles := splitAfter(files, cutoff)
+	keptTouches, movedTouches := splitAfter(touches, cutoff)
+
+	// New context covers the moved entries and keeps the original's labels
+	start := notes[afterNote].Timestamp
+	split := &pkgmodels.ContextWithMetadata{
+		Name:      into,
+		StartTime: start,
+		EndTime:   ctx.EndTime,
+		Status:    "stopped",
+		Metadata: pkgmodels.ContextMetadata{
+			CreatedBy: ctx.Metadata.CreatedBy,
+			Parent:    contextName,
+			Labels:    append([]string{}, ctx.Metadata.Labels...),
+		},
+	}
+	if split.EndTime == nil || split.EndTime.Before(start) {
+		end := notes[len(notes)-1].Timestamp
+		split.EndTime = &end
+	}
+
+	if err := writeStoppedContext(split, movedNotes, movedFiles, movedTouches); err != nil {
+		return nil, fmt.Errorf("failed to write split context: %w", err)
+	}
+
+	// Original keeps everything up to and including the split note
+	if err := writeLogLines(GetNotesLogPath(contextName), keptNotes); err != nil {
+		return nil, err
+	}
+	if err := writeLogLines(GetFilesLogPath(contextName), keptFiles); err != nil {
+		return nil, err
+	}
+	if err := writeLogLines(GetTouchLogPath(contextName), keptTouches); err != nil {
+		return nil, err
+	}
+
+	return &SplitResult{
+		Source:     contextName,
+		Into:       into,
+		AfterNote:  afterNote,
+		NoteCount:  len(movedNotes),
+		FileCount:  len(movedFiles),
+		TouchCount: len(movedTouches),
+	}, nil
+}
+
+// splitAfter partitions entries around a cutoff, keeping their order; unparsed
+// lines always stay behind
+func splitAfter(entries []timedLine, cutoff time.Time) ([]string, []string) {
+	kept, moved := []string{}, []string{}
+	for _, entry := range entries {
+		if entry.Parsed && entry.Timestamp.After(cutoff) {
+			moved = append(moved, entry.Line)
+		} else {
+			kept = append(kept, entry.Line)
+		}
+	}
+	return kept, moved
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// Todo is a todo note with where to find it: note done takes Ref
+type Todo struct {
+	Context   string          `json:"context"`
+	ContextID string          `json:"context_id"`
+	Number    int             `json:"number"` // Position in the context's notes, as numbered by show
+	Ref       string          `json:"ref"`    // <context-id>#<number>
+	Note      *intmodels.Note `json:"note"`
+}
+
+// ParseNoteRef splits a note reference into a context and note number. A
+// reference is a note number as shown by show (3 or #3), which refers to the
+// active context, or <context>#<number> where context is anything
+// ResolveContext accepts: a name, ID, prefix, glob or @{-N}.
+func ParseNoteRef(ref string) (contextName string, number int, err error) {
+	contextPart, numberPart := "", strings.TrimPrefix(ref, "#")
+	if i := strings.LastIndex(ref, "#"); i > 0 {
+		contextPart, numberPart = ref[:i], ref[i+1:]
+	}
+
+	number, err = strconv.Atoi(numberPart)
+	if err != nil || number < 1 {
+		return "", 0, fmt.Errorf("invalid note reference %q (expected a note number like 3, or <context>#3)", ref)
+	}
+
+	if contextPart != "" {
+		ctx, err := ResolveContext(contextPart, nil)
+		if err != nil {
+			return "", 0, err
+		}
+		return ctx.Name, number, nil
+	}
+
+	state, err := GetActiveContext()
+	if err != nil {
+		return "", 0, err
+	}
+	if !state.HasActiveContext() {
+		return "", 0, fmt.Errorf("no active context; refer to the note as <context>#%d", number)
+	}
+	return state.GetActiveContextName(), number, nil
+}
+
+// CountNotes returns how many notes a context has, counted as show numbers
+// them: lines that aren't notes, such as the start marker, don't count
+func CountNotes(contextName string) (int, error) {
+	lines, err := ReadLog(GetNotesLogPath(contextName))
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, line := range lines {
+		if _, err := intmodels.ParseNoteLogLine(line); err == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MarkNoteDone closes the todo with the given number in a context by
+// rewriting its log line. Undo reopens it.
+func MarkNoteDone(contextName string, number int) (*intmodels.Note, error) {
+	if _, err := LoadContext(contextName); err != nil {
+		return nil, err
+	}
+
+	logPath := GetNotesLogPath(contextName)
+	lines, err := ReadLog(logPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Number the notes as show does: valid lines only
+	count := 0
+	for i, line := range lines {
+		note, err := intmodels.ParseNoteLogLine(line)
+		if err != nil {
+			continue
+		}
+		count++
+		if count != number {
+			continue
+		}
+
+		if note.Type != intmodels.NoteTypeTodo {
+			kind := "a plain note"
+			if note.Type != "" {
+				kind = "a " + note.Type
+			}
+			return nil, fmt.Errorf("note #%d in %q is %s, not a todo", number, contextName, kind)
+		}
+		if note.Done {
+			return nil, fmt.Errorf("todo #%d in %q is already done", number, contextName)
+		}
+
+		note.Done = true
+		newLine := note.ToLogLine()
+		if err := replaceLogLine(logPath, i, line, newLine); err != nil {
+			return nil, err
+		}
+
+		journal := newJournalEntry(JournalOpNoteDone, contextName, fmt.Sprintf("todo #%d done in %q", number, contextName))
+		journal.replaced(journalLogNotes, contextName, i, line, newLine)
+		if err := recordJournalEntry(journal); err != nil {
+			return nil, err
+		}
+		return note, nil
+	}
+
+	return nil, fmt.Errorf("context %q has no note #%d (it has %d)", contextName, number, count)
+}
+
+// ListTodos returns todo notes across all contexts, grouped by context in the
+// order ListContexts returns them, and in note order within one. Done todos
+// are included only when includeDone is set, and archived contexts are skipped
+// unless includeArchived is set.
+func ListTodos(includeDone, includeArchived bool) ([]*Todo, error) {
+	contexts, err := ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	todos := []*Todo{}
+	for _, ctx := range contexts {
+		if ctx.IsArchived && !includeArchived {
+			continue
+		}
+		_, notes, _, _, err := GetContext(ctx.Name)
+		if err != nil {
+			continue // Skip contexts that can't be read, as ListContexts does
+		}
+		for i, note := range notes {
+			if note.Type != intmodels.NoteTypeTodo || note.Done && !includeDone {
+				continue
+			}
+			todos = append(todos, &Todo{
+				Context:   ctx.Name,
+				ContextID: ctx.ID,
+				Number:    i + 1,
+				Ref:       fmt.Sprintf("%s#%d", ctx.ID, i+1),
+				Note:      note,
+			})
+		}
+	}
+
+	return todos, nil
+}
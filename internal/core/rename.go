@@ -0,0 +1,174 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// RenameResult describes what a rename touched
+type RenameResult struct {
+	OldName         string   `json:"old_name"`
+	NewName         string   `json:"new_name"`
+	Directory       string   `json:"directory"`
+	WasActive       bool     `json:"was_active"`
+	ChildrenUpdated []string `json:"children_updated"`
+}
+
+// RenameContext changes a context's display name and everything that refers to it:
+// the index, meta.json, children's parent references, state.json and the journal.
+// The directory is named after the context's ID and stays where it is. A rename
+// transition is logged so history can connect the old and new names.
+func RenameContext(oldName, newName string) (*RenameResult, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("new context name cannot be empty")
+	}
+	if newName == oldName {
+		return nil, fmt.Errorf("context is already named %q", oldName)
+	}
+
+	if _, err := LoadContext(oldName); err != nil {
+		return nil, err
+	}
+	if _, err := FindContextByName(newName); err == nil {
+		return nil, fmt.Errorf("context %q already exists", newName)
+	}
+
+	dir := contextDirFor(oldName)
+	if err := updateContextIndex(func(index *contextIndex) error {
+		index.Contexts[newName] = index.Contexts[oldName]
+		delete(index.Contexts, oldName)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update context index: %w", err)
+	}
+
+	if err := updateContextMeta(newName, func(ctx *pkgmodels.ContextWithMetadata) error {
+		ctx.Name = newName
+		ctx.SubdirectoryPath = dir
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update context metadata: %w", err)
+	}
+
+	result := &RenameResult{
+		OldName:         oldName,
+		NewName:         newName,
+		Directory:       dir,
+		ChildrenUpdated: []string{},
+	}
+
+	// Repoint children at the new name
+	children, err := GetChildren(oldName)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		if err := updateContextMeta(child, func(ctx *pkgmodels.ContextWithMetadata) error {
+			ctx.Metadata.Parent = newName
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update parent of %q: %w", child, err)
+		}
+		result.ChildrenUpdated = append(result.ChildrenUpdated, child)
+	}
+
+	// Keep the active context pointing at the renamed context
+	state, err := GetActiveContext()
+	if err != nil {
+		return nil, err
+	}
+	if state.GetActiveContextName() == oldName {
+		result.WasActive = true
+		if err := SetActiveContext(newName); err != nil {
+			return nil, fmt.Errorf("failed to update active context: %w", err)
+		}
+	}
+
+	// Log transition so history stays readable across the rename
+	transition := &intmodels.ContextTransition{
+		Timestamp:       time.Now(),
+		PreviousContext: &oldName,
+		NewContext:      &newName,
+		TransitionType:  intmodels.TransitionRename,
+	}
+	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
+		return nil, fmt.Errorf("failed to log transition: %w", err)
+	}
+
+	if err := renameInJournal(oldName, newName); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// renameInJournal rewrites journal references so earlier operations stay undoable
+func renameInJournal(oldName, newName string) error {
+	entries, err := ReadJournal()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Context == oldName {
+			entry.Context = newName
+		}
+		if entry.CreatedContext == oldName {
+			entry.CreatedContext = newName
+		}
+		if err := renameInMetaSnapshots(entry, oldName, newName); err != nil {
+			return err
+		}
+		for i := range entry.Appended {
+			if entry.Appended[i].Context == oldName {
+				entry.Appended[i].Context = newName
+			}
+		}
+		if entry.StateBefore != nil && entry.StateBefore.GetActiveContextName() == oldName {
+			renamed := newName
+			entry.StateBefore.ActiveContext = &renamed
+		}
+	}
+
+	if err := writeJournal(entries); err != nil {
+		return fmt.Errorf("failed to update journal: %w", err)
+	}
+	return nil
+}
+
+// renameInMetaSnapshots updates saved meta.json copies so restoring one never brings back the old name
+func renameInMetaSnapshots(entry *JournalEntry, oldName, newName string) error {
+	renamed := make(map[string]json.RawMessage, len(entry.MetaBefore))
+	for contextName, data := range entry.MetaBefore {
+		var ctx pkgmodels.ContextWithMetadata
+		if err := json.Unmarshal(data, &ctx); err != nil {
+			return fmt.Errorf("failed to read journal snapshot of %q: %w", contextName, err)
+		}
+
+		if contextName == oldName {
+			contextName = newName
+			ctx.Name = newName
+			ctx.SubdirectoryPath = contextDirFor(newName)
+		}
+		if ctx.Metadata.Parent == oldName {
+			ctx.Metadata.Parent = newName
+		}
+
+		updated, err := json.MarshalIndent(&ctx, "", "  ")
+		if err != nil {
+			return err
+		}
+		renamed[contextName] = updated
+	}
+
+	if len(renamed) > 0 {
+		entry.MetaBefore = renamed
+	}
+	return nil
+}
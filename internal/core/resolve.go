@@ -0,0 +1,240 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// AmbiguousContextError is returned by ResolveContext when a query matches
+// more than one context. Matches are ordered best first.
+type AmbiguousContextError struct {
+	Query   string
+	Matches []*models.Context
+}
+
+func (e *AmbiguousContextError) Error() string {
+	names := make([]string, 0, len(e.Matches))
+	for i, ctx := range e.Matches {
+		if i == 8 {
+			names = append(names, fmt.Sprintf("and %d more", len(e.Matches)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%q", ctx.Name))
+	}
+	return fmt.Sprintf("%q matches %d contexts: %s; use more of the name or the exact name",
+		e.Query, len(e.Matches), strings.Join(names, ", "))
+}
+
+// ContextNotFoundError is returned by ResolveContext when nothing matches a query
+type ContextNotFoundError struct {
+	Query string
+}
+
+func (e *ContextNotFoundError) Error() string {
+	return fmt.Sprintf("context %q not found", e.Query)
+}
+
+// previousRef matches @{-N}, the Nth context active before the current one
+var previousRef = regexp.MustCompile(`^@\{-(\d+)\}$`)
+
+// ResolveContext finds the one context a command-line argument refers to.
+// The query is tried, in order, as:
+//
+//   - "-" or "@{-N}": the previous (Nth previous) context that was active
+//   - the exact name or ID, then the name ignoring case
+//   - a glob pattern when it contains *
+//   - a prefix of the name, then a substring, ignoring case
+//   - a fuzzy match: the query's characters appear in order in the name
+//
+// The first of these that matches anything decides; if it matches more than
+// one context the result is an *AmbiguousContextError listing them.
+//
+// check, when not nil, limits the contexts a command can act on. A context
+// named exactly that check rejects is reported with check's error; for looser
+// matches rejected contexts are skipped.
+func ResolveContext(query string, check func(*models.Context) error) (*models.Context, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("context name is empty")
+	}
+
+	contexts, err := ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	if query == "-" || previousRef.MatchString(query) {
+		n := 1
+		if m := previousRef.FindStringSubmatch(query); m != nil {
+			n, _ = strconv.Atoi(m[1])
+		}
+		name, err := previousContextName(n)
+		if err != nil {
+			return nil, err
+		}
+		for _, ctx := range contexts {
+			if ctx.Name == name {
+				return checked(ctx, check)
+			}
+		}
+		return nil, fmt.Errorf("previous context %q no longer exists", name)
+	}
+
+	// An exact name or ID wins even if the command can't use it, so the error says why
+	for _, ctx := range contexts {
+		if ctx.Name == query {
+			return checked(ctx, check)
+		}
+	}
+	for _, ctx := range contexts {
+		if ctx.ID == query {
+			return checked(ctx, check)
+		}
+	}
+
+	var allowed []*models.Context
+	for _, ctx := range contexts {
+		if check == nil || check(ctx) == nil {
+			allowed = append(allowed, ctx)
+		}
+	}
+
+	lower := strings.ToLower(query)
+	tiers := []func(name string) bool{
+		func(name string) bool { return strings.EqualFold(name, query) },
+		func(name string) bool {
+			return strings.Contains(query, "*") && matchesPattern(strings.ToLower(name), strings.Split(lower, "*"))
+		},
+		func(name string) bool { return strings.HasPrefix(strings.ToLower(name), lower) },
+		func(name string) bool { return strings.Contains(strings.ToLower(name), lower) },
+	}
+	for _, matches := range tiers {
+		var found []*models.Context
+		for _, ctx := range allowed {
+			if matches(ctx.Name) {
+				found = append(found, ctx)
+			}
+		}
+		if len(found) > 0 {
+			return single(query, found)
+		}
+	}
+
+	// Fuzzy matches, best first
+	type scored struct {
+		ctx   *models.Context
+		score int
+	}
+	var fuzzy []scored
+	for _, ctx := range allowed {
+		if score, ok := FuzzyMatch(query, ctx.Name); ok {
+			fuzzy = append(fuzzy, scored{ctx, score})
+		}
+	}
+	if len(fuzzy) > 0 {
+		sort.SliceStable(fuzzy, func(i, j int) bool { return fuzzy[i].score > fuzzy[j].score })
+		found := make([]*models.Context, len(fuzzy))
+		for i, f := range fuzzy {
+			found[i] = f.ctx
+		}
+		return single(query, found)
+	}
+
+	return nil, &ContextNotFoundError{Query: query}
+}
+
+func checked(ctx *models.Context, check func(*models.Context) error) (*models.Context, error) {
+	if check != nil {
+		if err := check(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
+func single(query string, found []*models.Context) (*models.Context, error) {
+	if len(found) == 1 {
+		return found[0], nil
+	}
+	return nil, &AmbiguousContextError{Query: query, Matches: found}
+}
+
+// previousContextName returns the nth most recent context that was active,
+// not counting the active one. Renamed contexts are returned by their current name.
+func previousContextName(n int) (string, error) {
+	if n < 1 {
+		return "", fmt.Errorf("invalid previous context reference @{-%d}", n)
+	}
+
+	sessions, err := GetSessions()
+	if err != nil {
+		return "", err
+	}
+	state, err := GetActiveContext()
+	if err != nil {
+		return "", err
+	}
+	active := state.GetActiveContextName()
+
+	seen := map[string]bool{active: true}
+	count := 0
+	for i := len(sessions) - 1; i >= 0; i-- {
+		name := sessions[i].Context
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		count++
+		if count == n {
+			return name, nil
+		}
+	}
+
+	if n == 1 {
+		return "", fmt.Errorf("no previous context")
+	}
+	return "", fmt.Errorf("only %d previous context(s) in history", count)
+}
+
+// FuzzyMatch reports whether the characters of query appear in order in name,
+// ignoring case, and scores the match: consecutive characters and characters
+// at the start of words score higher, gaps and longer names lower.
+func FuzzyMatch(query, name string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	n := []rune(name)
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	score, qi, last := 0, 0, -1
+	for i, r := range n {
+		if qi == len(q) {
+			break
+		}
+		if unicode.ToLower(r) != q[qi] {
+			continue
+		}
+		if last >= 0 {
+			if last == i-1 {
+				score += 5
+			} else {
+				score -= i - last - 1
+			}
+		}
+		if i == 0 || !unicode.IsLetter(n[i-1]) && !unicode.IsDigit(n[i-1]) || unicode.IsUpper(r) && unicode.IsLower(n[i-1]) {
+			score += 8
+		}
+		score += 1
+		last = i
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score - len(n)/8, true
+}
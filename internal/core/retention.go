@@ -0,0 +1,228 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+)
+
+// RetentionPolicy controls how the gc command cleans up old contexts. It is
+// read from the archive_after_days, delete_after_days and trash_purge_days
+// config keys.
+type RetentionPolicy struct {
+	ArchiveAfterDays int `json:"archive_after_days"` // Archive stopped contexts idle this many days (0 = never)
+	DeleteAfterDays  int `json:"delete_after_days"`  // Move archived contexts idle this many days to trash (0 = never)
+	TrashPurgeDays   int `json:"trash_purge_days"`   // Permanently remove trash entries older than this (0 = never)
+}
+
+// GC action types
+const (
+	GCActionArchive = "archive"
+	GCActionTrash   = "trash"
+)
+
+// GCAction describes a single change the retention policy calls for
+type GCAction struct {
+	ContextName  string    `json:"context_name"`
+	Action       string    `json:"action"` // "archive" or "trash"
+	LastActivity time.Time `json:"last_activity"`
+	IdleDays     int       `json:"idle_days"`
+}
+
+// legacyRetentionPolicyPath returns the path of the retention.json file older
+// versions stored the policy in
+func legacyRetentionPolicyPath() string {
+	return filepath.Join(GetContextHome(), "retention.json")
+}
+
+// LoadRetentionPolicy reads the retention policy from the config, moving a
+// retention.json left by an older version into config.yaml first
+func LoadRetentionPolicy() (*RetentionPolicy, error) {
+	cfg, err := currentConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := migrateRetentionPolicy(cfg); err != nil {
+		return nil, err
+	}
+
+	settings := cfg.Settings()
+	return &RetentionPolicy{
+		ArchiveAfterDays: settings.ArchiveAfterDays,
+		DeleteAfterDays:  settings.DeleteAfterDays,
+		TrashPurgeDays:   settings.TrashPurgeDays,
+	}, nil
+}
+
+// SaveRetentionPolicy stores the retention policy in config.yaml
+func SaveRetentionPolicy(policy *RetentionPolicy) error {
+	if policy.ArchiveAfterDays < 0 || policy.DeleteAfterDays < 0 || policy.TrashPurgeDays < 0 {
+		return fmt.Errorf("retention days cannot be negative")
+	}
+
+	if err := EnsureContextHome(); err != nil {
+		return err
+	}
+	cfg, err := currentConfig()
+	if err != nil {
+		return err
+	}
+
+	return setRetentionKeys(cfg, policy, false)
+}
+
+// migrateRetentionPolicy copies the values of a legacy retention.json into
+// config.yaml, unless config.yaml already sets them, and removes the file
+func migrateRetentionPolicy(cfg *config.Config) error {
+	path := legacyRetentionPolicyPath()
+	if !FileExists(path) {
+		return nil
+	}
+
+	var legacy RetentionPolicy
+	if err := ReadJSON(path, &legacy); err != nil {
+		return fmt.Errorf("failed to read retention policy: %w", err)
+	}
+	if err := setRetentionKeys(cfg, &legacy, true); err != nil {
+		return fmt.Errorf("failed to move retention.json into %s: %w", config.FileName, err)
+	}
+	return os.Remove(path)
+}
+
+// setRetentionKeys writes a policy to the top level of config.yaml. With
+// keepExisting it leaves keys config.yaml already sets alone.
+func setRetentionKeys(cfg *config.Config, policy *RetentionPolicy, keepExisting bool) error {
+	values := []struct {
+		key  string
+		days int
+	}{
+		{"archive_after_days", policy.ArchiveAfterDays},
+		{"delete_after_days", policy.DeleteAfterDays},
+		{"trash_purge_days", policy.TrashPurgeDays},
+	}
+	for _, v := range values {
+		if _, ok := cfg.FileValue(v.key); ok && keepExisting {
+			continue
+		}
+		if err := cfg.Set(v.key, strconv.Itoa(v.days), ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLastActivity returns the most recent timestamp recorded in a context
+// (end time, notes, file associations or touches)
+func GetLastActivity(contextName string) (time.Time, error) {
+	ctx, notes, files, touches, err := GetContext(contextName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("context %q not found", contextName)
+	}
+
+	last := ctx.StartTime
+	if ctx.EndTime != nil && ctx.EndTime.After(last) {
+		last = *ctx.EndTime
+	}
+
+	for _, note := range notes {
+		if note.Timestamp.After(last) {
+			last = note.Timestamp
+		}
+	}
+	for _, file := range files {
+		if file.Timestamp.After(last) {
+			last = file.Timestamp
+		}
+	}
+	for _, touch := range touches {
+		if touch.Timestamp.After(last) {
+			last = touch.Timestamp
+		}
+	}
+
+	return last, nil
+}
+
+// PlanGC works out which contexts the retention policy would archive or trash.
+// Stopped contexts are archived first; they only become eligible for the trash
+// on a later run, once they are archived and idle past DeleteAfterDays.
+func PlanGC(policy *RetentionPolicy, now time.Time) ([]GCAction, error) {
+	contexts, err := ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := GetActiveContext()
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []GCAction
+	for _, ctx := range contexts {
+		// Never touch the running context
+		if ctx.Name == state.GetActiveContextName() {
+			continue
+		}
+
+		lastActivity, err := GetLastActivity(ctx.Name)
+		if err != nil {
+			continue // Skip contexts we cannot read
+		}
+		idle := now.Sub(lastActivity)
+		idleDays := int(idle.Hours() / 24)
+
+		action := GCAction{
+			ContextName:  ctx.Name,
+			LastActivity: lastActivity,
+			IdleDays:     idleDays,
+		}
+
+		switch {
+		case ctx.IsArchived && policy.DeleteAfterDays > 0 && idle >= daysToDuration(policy.DeleteAfterDays):
+			action.Action = GCActionTrash
+		case !ctx.IsArchived && policy.ArchiveAfterDays > 0 && idle >= daysToDuration(policy.ArchiveAfterDays):
+			action.Action = GCActionArchive
+		default:
+			continue
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// ApplyGC executes planned actions and returns the ones that succeeded plus per-context errors
+func ApplyGC(actions []GCAction) ([]GCAction, map[string]error) {
+	applied := make([]GCAction, 0, len(actions))
+	failures := make(map[string]error)
+
+	for _, action := range actions {
+		var err error
+		switch action.Action {
+		case GCActionArchive:
+			err = ArchiveContext(action.ContextName)
+		case GCActionTrash:
+			_, err = TrashContext(action.ContextName, fmt.Sprintf("gc: archived and idle %d days", action.IdleDays))
+		default:
+			err = fmt.Errorf("unknown gc action %q", action.Action)
+		}
+
+		if err != nil {
+			failures[action.ContextName] = err
+			continue
+		}
+		applied = append(applied, action)
+	}
+
+	return applied, failures
+}
+
+// daysToDuration converts a whole number of days to a duration
+func daysToDuration(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// GetSessions reconstructs the intervals during which each context was active
+// from the transitions log, oldest first. Sessions of renamed contexts carry the
+// current name, and those of merged contexts the name of the context they were
+// merged into. A session still running ends now and is marked Ongoing.
+func GetSessions() ([]models.Session, error) {
+	transitions, err := GetTransitions()
+	if err != nil {
+		return nil, err
+	}
+	return buildSessions(transitions, time.Now()), nil
+}
+
+// GetSessionsBetween returns the sessions overlapping [from, to), clipped to it
+func GetSessionsBetween(from, to time.Time) ([]models.Session, error) {
+	sessions, err := GetSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	var clipped []models.Session
+	for _, s := range sessions {
+		if c, ok := s.Clip(from, to); ok {
+			clipped = append(clipped, c)
+		}
+	}
+	return clipped, nil
+}
+
+func buildSessions(transitions []*models.ContextTransition, now time.Time) []models.Session {
+	var sessions []models.Session
+	var open *models.Session
+
+	closeOpen := func(at time.Time) {
+		if open != nil {
+			open.End = at
+			sessions = append(sessions, *open)
+			open = nil
+		}
+	}
+
+	for _, t := range transitions {
+		switch t.TransitionType {
+		case models.TransitionStart, models.TransitionSwitch:
+			closeOpen(t.Timestamp)
+			if t.NewContext != nil {
+				open = &models.Session{Context: *t.NewContext, Start: t.Timestamp}
+			}
+		case models.TransitionStop:
+			closeOpen(t.Timestamp)
+		case models.TransitionRename, models.TransitionMerge:
+			if t.PreviousContext == nil || t.NewContext == nil {
+				continue
+			}
+			// Earlier sessions are reported under the name the context has now
+			for i := range sessions {
+				if sessions[i].Context == *t.PreviousContext {
+					sessions[i].Context = *t.NewContext
+				}
+			}
+			if open != nil && open.Context == *t.PreviousContext {
+				open.Context = *t.NewContext
+			}
+		}
+	}
+
+	if open != nil {
+		open.End = now
+		open.Ongoing = true
+		sessions = append(sessions, *open)
+	}
+	return sessions
+}
+
+// ParseSince resolves a --since value to the start of a window ending now. It
+// accepts "today", "yesterday" and "week" (midnight six days ago), a number of
+// days such as "3d", a Go duration such as "36h", a date (2006-01-02) or an
+// RFC 3339 timestamp.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch v := strings.ToLower(strings.TrimSpace(value)); {
+	case v == "today":
+		return midnight, nil
+	case v == "yesterday":
+		return midnight.AddDate(0, 0, -1), nil
+	case v == "week":
+		return midnight.AddDate(0, 0, -6), nil
+	case strings.HasSuffix(v, "d"):
+		if days, err := strconv.Atoi(strings.TrimSuffix(v, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	default:
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return now.Add(-d), nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q (use today, yesterday, week, 3d, 36h, 2006-01-02 or an RFC 3339 time)", value)
+}
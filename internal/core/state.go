@@ -61,6 +61,16 @@ func ClearActiveContext() error {
 	return WriteJSON(GetStateFilePath(), state)
 }
 
+// isActiveContext reports whether state.json names contextName as the active
+// context. meta.json's status is not enough: resume only updates state.json.
+func isActiveContext(contextName string) (bool, error) {
+	state, err := GetActiveContext()
+	if err != nil {
+		return false, err
+	}
+	return state.GetActiveContextName() == contextName, nil
+}
+
 // FindContextByName finds a context by its display name (not directory name)
 func FindContextByName(name string) (*models.Context, error) {
 	contexts, err := ListContexts()
@@ -77,25 +87,6 @@ func FindContextByName(name string) (*models.Context, error) {
 	return nil, fmt.Errorf("context %q not found", name)
 }
 
-// GetNoteCount returns the number of notes in a context
-func GetNoteCount(contextName string) (int, error) {
-	notesPath := GetNotesLogPath(contextName)
-	lines, err := ReadLog(notesPath)
-	if err != nil {
-		return 0, err
-	}
-
-	// Count non-empty lines (each represents a note)
-	count := 0
-	for _, line := range lines {
-		if line != "" {
-			count++
-		}
-	}
-
-	return count, nil
-}
-
 // GetLastActiveTime returns the most recent time when the context was active
 func GetLastActiveTime(contextName string) (time.Time, error) {
 	transitions, err := GetTransitions()
@@ -153,6 +144,84 @@ func GetMostRecentStopped() (*models.Context, error) {
 	return stoppedContexts[0], nil
 }
 
+// ResumeContext makes a stopped context the active context again and logs a
+// start transition
+func ResumeContext(contextName string) error {
+	state, err := GetActiveContext()
+	if err != nil {
+		return err
+	}
+
+	journal := newJournalEntry(JournalOpResume, contextName, fmt.Sprintf("resume %q", contextName))
+	journal.captureState(state)
+
+	if err := SetActiveContext(contextName); err != nil {
+		return fmt.Errorf("failed to activate context: %w", err)
+	}
+
+	name := contextName
+	transition := &models.ContextTransition{
+		Timestamp:      time.Now(),
+		NewContext:     &name,
+		TransitionType: models.TransitionStart,
+	}
+	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
+		return fmt.Errorf("failed to log transition: %w", err)
+	}
+	journal.appended(journalLogTransitions, "", transition.ToLogLine())
+
+	return recordJournalEntry(journal)
+}
+
+// SwitchContext makes an existing context the active one, stopping the
+// previously active context, and logs a switch transition (a start when no
+// context was active). It returns the previously active context. If stopping
+// the previous context fails it stays active.
+func SwitchContext(contextName string) (string, error) {
+	var context models.Context
+	if err := ReadJSON(GetMetaJSONPath(contextName), &context); err != nil {
+		return "", fmt.Errorf("failed to read context %q: %w", contextName, err)
+	}
+
+	state, err := GetActiveContext()
+	if err != nil {
+		return "", err
+	}
+	if !state.HasActiveContext() {
+		return "", ResumeContext(contextName)
+	}
+
+	previousContext := state.GetActiveContextName()
+	journal := newJournalEntry(JournalOpSwitch, contextName, fmt.Sprintf("switch from %q to %q", previousContext, contextName))
+	journal.captureState(state)
+	if err := journal.captureMeta(previousContext); err != nil {
+		return "", fmt.Errorf("failed to read previous context: %w", err)
+	}
+
+	if err := SetActiveContext(contextName); err != nil {
+		return "", fmt.Errorf("failed to activate context: %w", err)
+	}
+	if err := stopContextInternal(previousContext); err != nil {
+		if restoreErr := SetActiveContext(previousContext); restoreErr != nil {
+			return "", fmt.Errorf("failed to stop previous context: %w (and failed to restore it: %v)", err, restoreErr)
+		}
+		return "", fmt.Errorf("failed to stop previous context: %w", err)
+	}
+
+	transition := &models.ContextTransition{
+		Timestamp:       time.Now(),
+		PreviousContext: &previousContext,
+		NewContext:      &contextName,
+		TransitionType:  models.TransitionSwitch,
+	}
+	if err := AppendLog(GetTransitionsLogPath(), transition.ToLogLine()); err != nil {
+		return previousContext, fmt.Errorf("failed to log transition: %w", err)
+	}
+	journal.appended(journalLogTransitions, "", transition.ToLogLine())
+
+	return previousContext, recordJournalEntry(journal)
+}
+
 // FindContextsByPattern finds contexts matching a pattern (supports glob-style wildcards)
 func FindContextsByPattern(pattern string) ([]*models.Context, error) {
 	allContexts, err := ListContexts()
@@ -160,9 +160,10 @@ func FileExists(path string) bool {
 	return err == nil
 }
 
-// GetContextDir returns the directory path for a context name
-func GetContextDir(contextName string) string {
-	return filepath.Join(GetContextHome(), contextName)
+// GetContextDir returns the path of a directory in the context home; context
+// directories are named after the context's ID
+func GetContextDir(dirName string) string {
+	return filepath.Join(GetContextHome(), dirName)
 }
 
 // SanitizeContextName sanitizes a context name by replacing invalid characters
@@ -177,8 +178,17 @@ func SanitizeContextName(name string) string {
 	return sanitized
 }
 
-// ListContextDirs returns all context directory names
+// ListContextDirs returns all context directory names (context IDs)
 func ListContextDirs() ([]string, error) {
+	// Reading the index moves directories from before IDs into place first
+	if _, err := cachedContextIndex(); err != nil {
+		return nil, err
+	}
+	return listContextHomeDirs()
+}
+
+// listContextHomeDirs returns the directories in the context home that may hold contexts
+func listContextHomeDirs() ([]string, error) {
 	home := GetContextHome()
 
 	entries, err := os.ReadDir(home)
@@ -191,7 +201,8 @@ func ListContextDirs() ([]string, error) {
 
 	var dirs []string
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// Skip hidden directories (e.g., .trash) and the signals directory - they never hold live contexts
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") && entry.Name() != signalsDirName {
 			dirs = append(dirs, entry.Name())
 		}
 	}
@@ -199,6 +210,19 @@ func ListContextDirs() ([]string, error) {
 	return dirs, nil
 }
 
+// signalsDirName is the directory holding signal files, both in the context home and in a context
+const signalsDirName = "signals"
+
+// GetSignalsDir returns the directory holding global signal files
+func GetSignalsDir() string {
+	return filepath.Join(GetContextHome(), signalsDirName)
+}
+
+// GetContextSignalsDir returns the directory holding signals scoped to one context
+func GetContextSignalsDir(contextName string) string {
+	return filepath.Join(contextDirFor(contextName), signalsDirName)
+}
+
 // GetStateFilePath returns the path to the state.json file
 func GetStateFilePath() string {
 	return filepath.Join(GetContextHome(), "state.json")
@@ -211,26 +235,22 @@ func GetTransitionsLogPath() string {
 
 // GetMetaJSONPath returns the path to a context's meta.json file
 func GetMetaJSONPath(contextName string) string {
-	sanitized := SanitizeContextName(contextName)
-	return filepath.Join(GetContextDir(sanitized), "meta.json")
+	return filepath.Join(contextDirFor(contextName), "meta.json")
 }
 
 // GetNotesLogPath returns the path to a context's notes.log file
 func GetNotesLogPath(contextName string) string {
-	sanitized := SanitizeContextName(contextName)
-	return filepath.Join(GetContextDir(sanitized), "notes.log")
+	return filepath.Join(contextDirFor(contextName), "notes.log")
 }
 
 // GetFilesLogPath returns the path to a context's files.log file
 func GetFilesLogPath(contextName string) string {
-	sanitized := SanitizeContextName(contextName)
-	return filepath.Join(GetContextDir(sanitized), "files.log")
+	return filepath.Join(contextDirFor(contextName), "files.log")
 }
 
 // GetTouchLogPath returns the path to a context's touch.log file
 func GetTouchLogPath(contextName string) string {
-	sanitized := SanitizeContextName(contextName)
-	return filepath.Join(GetContextDir(sanitized), "touch.log")
+	return filepath.Join(contextDirFor(contextName), "touch.log")
 }
 
 // SanitizeFilename sanitizes a filename by replacing invalid characters
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// GetTimeline returns a row for every context that was active or had notes
+// written in [from, to), ordered by first activity. An empty project includes
+// all projects.
+func GetTimeline(from, to time.Time, project string) ([]output.TimelineRow, error) {
+	sessions, err := GetSessionsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	inProject := func(name string) bool {
+		return project == "" || strings.EqualFold(ExtractProjectName(name), strings.TrimSpace(project))
+	}
+
+	rows := make(map[string]*output.TimelineRow)
+	first := make(map[string]time.Time)
+	row := func(name string, at time.Time) *output.TimelineRow {
+		r, ok := rows[name]
+		if !ok {
+			r = &output.TimelineRow{Context: name, Sessions: []intmodels.Session{}, Notes: []intmodels.Note{}}
+			rows[name] = r
+			first[name] = at
+		} else if at.Before(first[name]) {
+			first[name] = at
+		}
+		return r
+	}
+
+	for _, s := range sessions {
+		if inProject(s.Context) {
+			r := row(s.Context, s.Start)
+			r.Sessions = append(r.Sessions, s)
+		}
+	}
+
+	for _, ctx := range contexts {
+		if !inProject(ctx.Name) {
+			continue
+		}
+		// Skip contexts whose whole lifetime is outside the window
+		if ctx.StartTime.After(to) || (ctx.EndTime != nil && ctx.EndTime.Before(from)) {
+			if _, ok := rows[ctx.Name]; !ok {
+				continue
+			}
+		}
+		_, notes, _, _, err := GetContext(ctx.Name)
+		if err != nil {
+			continue // Skip contexts that can't be read
+		}
+		for _, n := range notes {
+			if !n.Timestamp.Before(from) && n.Timestamp.Before(to) {
+				r := row(ctx.Name, n.Timestamp)
+				r.Notes = append(r.Notes, *n)
+			}
+		}
+	}
+
+	timeline := make([]output.TimelineRow, 0, len(rows))
+	for _, r := range rows {
+		timeline = append(timeline, *r)
+	}
+	sort.SliceStable(timeline, func(i, j int) bool {
+		a, b := first[timeline[i].Context], first[timeline[j].Context]
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return timeline[i].Context < timeline[j].Context
+	})
+	return timeline, nil
+}
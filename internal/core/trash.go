@@ -0,0 +1,257 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultTrashPurgeDays is how long trashed contexts are kept when no policy says otherwise
+const DefaultTrashPurgeDays = 30
+
+// TrashEntry describes a context sitting in the trash
+type TrashEntry struct {
+	ID          string    `json:"id"`           // Trash directory name, unique per deletion
+	ContextName string    `json:"context_name"` // Display name at the time of deletion
+	OriginalDir string    `json:"original_dir"` // Directory name inside the context home (the context ID)
+	DeletedAt   time.Time `json:"deleted_at"`   // When the context was moved to the trash
+	Reason      string    `json:"reason"`       // Why it was trashed (e.g., "delete", "gc: idle 120 days")
+	NoteCount   int       `json:"note_count"`   // Number of notes at deletion time
+	Path        string    `json:"path"`         // Full path to the trashed directory
+}
+
+// GetTrashDir returns the directory holding trashed contexts
+func GetTrashDir() string {
+	return filepath.Join(GetContextHome(), ".trash")
+}
+
+// getTrashMetaPath returns the path to the trash.json file of a trash entry
+func getTrashMetaPath(trashDir string) string {
+	return filepath.Join(trashDir, "trash.json")
+}
+
+// TrashContext moves a stopped context into the trash directory instead of removing it.
+// The reason is recorded alongside the context so `trash list` can explain it later.
+func TrashContext(contextName, reason string) (*TrashEntry, error) {
+	ctx, err := LoadContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := isActiveContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, fmt.Errorf("cannot trash active context %q - stop it first", contextName)
+	}
+
+	if err := CreateDir(GetTrashDir()); err != nil {
+		return nil, fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	noteCount, err := CountNotes(contextName)
+	if err != nil {
+		noteCount = 0 // Continue even if we can't count notes
+	}
+
+	// Prefix with a timestamp so repeated deletes of the same name never collide
+	now := time.Now()
+	sourceDir := filepath.Dir(GetMetaJSONPath(contextName))
+	entry := &TrashEntry{
+		ID:          fmt.Sprintf("%s_%s", now.Format("20060102T150405"), filepath.Base(sourceDir)),
+		ContextName: ctx.Name,
+		OriginalDir: filepath.Base(sourceDir),
+		DeletedAt:   now,
+		Reason:      reason,
+		NoteCount:   noteCount,
+	}
+	entry.Path = filepath.Join(GetTrashDir(), entry.ID)
+
+	if FileExists(entry.Path) {
+		return nil, fmt.Errorf("trash entry %q already exists - try again", entry.ID)
+	}
+
+	if err := os.Rename(sourceDir, entry.Path); err != nil {
+		return nil, fmt.Errorf("failed to move context %q to trash: %w", contextName, err)
+	}
+
+	// The name is free for a new context; the ID stays in meta.json for a restore
+	if err := updateContextIndex(func(index *contextIndex) error {
+		delete(index.Contexts, contextName)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update context index: %w", err)
+	}
+
+	if err := WriteJSON(getTrashMetaPath(entry.Path), entry); err != nil {
+		return nil, fmt.Errorf("failed to record trash metadata: %w", err)
+	}
+
+	return entry, nil
+}
+
+// ListTrash returns all trashed contexts, most recently deleted first
+func ListTrash() ([]*TrashEntry, error) {
+	entries, err := os.ReadDir(GetTrashDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*TrashEntry{}, nil
+		}
+		return nil, err
+	}
+
+	trashed := make([]*TrashEntry, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(GetTrashDir(), e.Name())
+		var entry TrashEntry
+		if err := ReadJSON(getTrashMetaPath(dir), &entry); err != nil {
+			// Entry trashed before metadata existed - reconstruct what we can
+			info, statErr := e.Info()
+			if statErr != nil {
+				continue
+			}
+			entry = TrashEntry{
+				ContextName: e.Name(),
+				OriginalDir: e.Name(),
+				DeletedAt:   info.ModTime(),
+			}
+		}
+		entry.ID = e.Name()
+		entry.Path = dir
+		trashed = append(trashed, &entry)
+	}
+
+	sort.Slice(trashed, func(i, j int) bool {
+		return trashed[i].DeletedAt.After(trashed[j].DeletedAt)
+	})
+
+	return trashed, nil
+}
+
+// FindTrashEntry finds a trash entry by ID or by context name (most recent deletion wins)
+func FindTrashEntry(idOrName string) (*TrashEntry, error) {
+	trashed, err := ListTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range trashed {
+		if entry.ID == idOrName {
+			return entry, nil
+		}
+	}
+
+	// ListTrash is newest first, so the first name match is the latest deletion
+	for _, entry := range trashed {
+		if entry.ContextName == idOrName {
+			return entry, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no trashed context matches %q", idOrName)
+}
+
+// RestoreFromTrash moves a trashed context back into the context home
+func RestoreFromTrash(idOrName string) (*TrashEntry, error) {
+	entry, err := FindTrashEntry(idOrName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := FindContextByName(entry.ContextName); err == nil {
+		return nil, fmt.Errorf("cannot restore %q - a context with that name already exists", entry.ContextName)
+	}
+
+	// Contexts keep their ID; those trashed before IDs existed get one now
+	var meta map[string]interface{}
+	if err := ReadJSON(filepath.Join(entry.Path, "meta.json"), &meta); err != nil {
+		return nil, fmt.Errorf("failed to read trashed context %q: %w", entry.ContextName, err)
+	}
+	id, _ := meta["id"].(string)
+	if id == "" || FileExists(GetContextDir(id)) {
+		if id, err = newContextID(); err != nil {
+			return nil, err
+		}
+	}
+	targetDir := GetContextDir(id)
+
+	if err := os.Rename(entry.Path, targetDir); err != nil {
+		return nil, fmt.Errorf("failed to restore context %q: %w", entry.ContextName, err)
+	}
+
+	// Trash metadata has no meaning once the context is live again
+	if err := os.Remove(getTrashMetaPath(targetDir)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to clean up trash metadata: %w", err)
+	}
+
+	meta["id"] = id
+	meta["subdirectory_path"] = targetDir
+	if err := WriteJSON(contextMetaPathInDir(id), meta); err != nil {
+		return nil, fmt.Errorf("failed to record ID of context %q: %w", entry.ContextName, err)
+	}
+	if err := updateContextIndex(func(index *contextIndex) error {
+		index.Contexts[entry.ContextName] = id
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update context index: %w", err)
+	}
+
+	entry.Path = targetDir
+	return entry, nil
+}
+
+// EmptyTrash permanently removes trashed contexts deleted before the cutoff.
+// A zero cutoff removes everything.
+func EmptyTrash(before time.Time) ([]*TrashEntry, error) {
+	expired, err := ExpiredTrash(before)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []*TrashEntry
+	for _, entry := range expired {
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove trash entry %q: %w", entry.ID, err)
+		}
+		removed = append(removed, entry)
+	}
+
+	return removed, nil
+}
+
+// ExpiredTrash returns the trash entries EmptyTrash would remove for the cutoff
+func ExpiredTrash(before time.Time) ([]*TrashEntry, error) {
+	trashed, err := ListTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []*TrashEntry
+	for _, entry := range trashed {
+		if before.IsZero() || entry.DeletedAt.Before(before) {
+			expired = append(expired, entry)
+		}
+	}
+	return expired, nil
+}
+
+// PurgeExpiredTrash removes trashed contexts older than the retention policy allows
+func PurgeExpiredTrash() ([]*TrashEntry, error) {
+	policy, err := LoadRetentionPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.TrashPurgeDays <= 0 {
+		return nil, nil // Automatic purge disabled
+	}
+
+	return EmptyTrash(time.Now().Add(-daysToDuration(policy.TrashPurgeDays)))
+}
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// Workflow defines the states a context moves through and which moves are allowed
+type Workflow struct {
+	States      []string            `json:"states"`                // Allowed states, in their usual order
+	Transitions map[string][]string `json:"transitions,omitempty"` // Allowed next states per state (nil = any move)
+	DoneStates  []string            `json:"done_states,omitempty"` // States that mean the work is complete
+}
+
+// WorkflowConfig holds the default workflow and per-project overrides
+type WorkflowConfig struct {
+	Default  *Workflow            `json:"default,omitempty"`
+	Projects map[string]*Workflow `json:"projects,omitempty"` // Keyed by project name (see ExtractProjectName)
+}
+
+// StateChange describes a workflow state change
+type StateChange struct {
+	Context string `json:"context"`
+	From    string `json:"from,omitempty"` // Empty when the context had no state yet
+	To      string `json:"to"`
+	Forced  bool   `json:"forced,omitempty"`
+}
+
+// DefaultWorkflow returns the workflow used when workflows.json doesn't configure one
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		States: []string{"planned", "in-progress", "review", "done", "archived"},
+		Transitions: map[string][]string{
+			"planned":     {"in-progress", "archived"},
+			"in-progress": {"review", "done", "planned"},
+			"review":      {"in-progress", "done"},
+			"done":        {"archived", "in-progress"},
+			"archived":    {"in-progress"},
+		},
+		DoneStates: []string{"done", "archived"},
+	}
+}
+
+// Validate checks that transitions and done states only name known states
+func (w *Workflow) Validate() error {
+	if len(w.States) == 0 {
+		return fmt.Errorf("workflow must define at least one state")
+	}
+
+	seen := make(map[string]bool)
+	for _, state := range w.States {
+		if strings.TrimSpace(state) == "" {
+			return fmt.Errorf("workflow states cannot be empty")
+		}
+		if seen[state] {
+			return fmt.Errorf("workflow state %q is listed twice", state)
+		}
+		seen[state] = true
+	}
+
+	for from, targets := range w.Transitions {
+		if !seen[from] {
+			return fmt.Errorf("transition from unknown state %q", from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return fmt.Errorf("transition from %q to unknown state %q", from, to)
+			}
+		}
+	}
+
+	for _, state := range w.DoneStates {
+		if !seen[state] {
+			return fmt.Errorf("unknown done state %q", state)
+		}
+	}
+
+	return nil
+}
+
+// HasState reports whether the workflow defines a state
+func (w *Workflow) HasState(state string) bool {
+	return containsString(w.States, state)
+}
+
+// NextStates returns the states a context in the given state may move to.
+// A context without a state may move to any state.
+func (w *Workflow) NextStates(from string) []string {
+	if from == "" || w.Transitions == nil {
+		next := make([]string, 0, len(w.States))
+		for _, state := range w.States {
+			if state != from {
+				next = append(next, state)
+			}
+		}
+		return next
+	}
+	return w.Transitions[from]
+}
+
+// CanTransition reports whether moving from one state to another is allowed
+func (w *Workflow) CanTransition(from, to string) bool {
+	return containsString(w.NextStates(from), to)
+}
+
+// IsDone reports whether a state means the work is complete
+func (w *Workflow) IsDone(state string) bool {
+	return containsString(w.DoneStates, state)
+}
+
+// GetWorkflowsPath returns the path to the workflows.json file
+func GetWorkflowsPath() string {
+	return filepath.Join(GetContextHome(), "workflows.json")
+}
+
+// LoadWorkflowConfig reads workflows.json, returning an empty config if none exists
+func LoadWorkflowConfig() (*WorkflowConfig, error) {
+	config := &WorkflowConfig{}
+
+	path := GetWorkflowsPath()
+	if !FileExists(path) {
+		return config, nil
+	}
+
+	if err := ReadJSON(path, config); err != nil {
+		return nil, fmt.Errorf("failed to read workflows: %w", err)
+	}
+
+	if config.Default != nil {
+		if err := config.Default.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid default workflow: %w", err)
+		}
+	}
+	for project, workflow := range config.Projects {
+		if err := workflow.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid workflow for project %q: %w", project, err)
+		}
+	}
+
+	return config, nil
+}
+
+// WorkflowFor returns the workflow that applies to a context: its project's
+// workflow if configured, then the configured default, then DefaultWorkflow.
+func WorkflowFor(contextName string) (*Workflow, error) {
+	config, err := LoadWorkflowConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	project := ExtractProjectName(contextName)
+	for name, workflow := range config.Projects {
+		if strings.EqualFold(name, project) {
+			return workflow, nil
+		}
+	}
+
+	if config.Default != nil {
+		return config.Default, nil
+	}
+	return DefaultWorkflow(), nil
+}
+
+// GetContextState returns a context's workflow state, empty if none has been set
+func GetContextState(contextName string) (string, error) {
+	var ctx pkgmodels.ContextWithMetadata
+	if err := ReadJSON(GetMetaJSONPath(contextName), &ctx); err != nil {
+		return "", fmt.Errorf("context %q not found", contextName)
+	}
+	return ctx.Metadata.State, nil
+}
+
+// SetContextState moves a context to a workflow state. The state must exist in
+// the context's workflow; with force the allowed-transition check is skipped.
+func SetContextState(contextName, state string, force bool) (*StateChange, error) {
+	current, err := GetContextState(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := WorkflowFor(contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !workflow.HasState(state) {
+		return nil, fmt.Errorf("unknown state %q (workflow states: %s)", state, strings.Join(workflow.States, ", "))
+	}
+	if state == current {
+		return nil, fmt.Errorf("context %q is already %s", contextName, state)
+	}
+	if !force && !workflow.CanTransition(current, state) {
+		allowed := workflow.NextStates(current)
+		if len(allowed) == 0 {
+			return nil, fmt.Errorf("cannot move %q from %s: no transitions allowed (use --force)", contextName, current)
+		}
+		return nil, fmt.Errorf("cannot move %q from %s to %s (allowed: %s; use --force to override)",
+			contextName, current, state, strings.Join(allowed, ", "))
+	}
+
+	journal := newJournalEntry(JournalOpState, contextName, fmt.Sprintf("state %s on %q", state, contextName))
+	if err := journal.captureMeta(contextName); err != nil {
+		return nil, err
+	}
+
+	if err := updateContextMeta(contextName, func(ctx *pkgmodels.ContextWithMetadata) error {
+		now := time.Now()
+		ctx.Metadata.State = state
+		ctx.Metadata.StateChangedAt = &now
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update context: %w", err)
+	}
+
+	if err := recordJournalEntry(journal); err != nil {
+		return nil, err
+	}
+
+	return &StateChange{Context: contextName, From: current, To: state, Forced: force}, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
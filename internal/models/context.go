@@ -8,6 +8,7 @@ import (
 
 // Context represents a work session with associated metadata
 type Context struct {
+	ID               string     `json:"id,omitempty"` // Short stable ID, also the directory name
 	Name             string     `json:"name"`
 	StartTime        time.Time  `json:"start_time"`
 	EndTime          *time.Time `json:"end_time,omitempty"`
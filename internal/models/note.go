@@ -6,10 +6,49 @@ import (
 	"time"
 )
 
+// Note types set with note --type; plain notes have no type
+const (
+	NoteTypeTodo     = "todo"
+	NoteTypeDecision = "decision"
+	NoteTypeQuestion = "question"
+	NoteTypeBlocker  = "blocker"
+	NoteTypeLink     = "link"
+)
+
+// NoteTypes lists the note types in the order exports group them
+var NoteTypes = []string{NoteTypeDecision, NoteTypeTodo, NoteTypeQuestion, NoteTypeBlocker, NoteTypeLink}
+
+// noteDoneSuffix marks a closed todo in the log, as in "todo:done"
+const noteDoneSuffix = ":done"
+
 // Note represents a timestamped text entry associated with a context
 type Note struct {
 	Timestamp   time.Time `json:"timestamp"`
 	TextContent string    `json:"text_content"`
+	Type        string    `json:"type,omitempty"` // One of NoteTypes, empty for a plain note
+	Done        bool      `json:"done,omitempty"` // A todo closed with note done
+}
+
+// ValidateNoteType checks that noteType is empty or one of NoteTypes
+func ValidateNoteType(noteType string) error {
+	if noteType == "" || isNoteType(noteType) {
+		return nil
+	}
+	return fmt.Errorf("invalid note type %q (valid: %s)", noteType, strings.Join(NoteTypes, ", "))
+}
+
+func isNoteType(noteType string) bool {
+	for _, t := range NoteTypes {
+		if t == noteType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpenTodo reports whether the note is a todo not yet done
+func (n *Note) IsOpenTodo() bool {
+	return n.Type == NoteTypeTodo && !n.Done
 }
 
 // Validate checks if the note has valid data
@@ -22,6 +61,10 @@ func (n *Note) Validate() error {
 		return fmt.Errorf("note text must be 10,000 characters or less")
 	}
 
+	if err := ValidateNoteType(n.Type); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -43,9 +86,17 @@ func UnescapeNote(text string) string {
 	return result
 }
 
-// ToLogLine formats the note as a log line: timestamp|text
+// ToLogLine formats the note as a log line: timestamp|text, or
+// timestamp|type|text for a typed note (timestamp|todo:done|text once done)
 func (n *Note) ToLogLine() string {
-	return fmt.Sprintf("%s|%s", n.Timestamp.Format(time.RFC3339), n.Escape())
+	if n.Type == "" {
+		return fmt.Sprintf("%s|%s", n.Timestamp.Format(time.RFC3339), n.Escape())
+	}
+	noteType := n.Type
+	if n.Done {
+		noteType += noteDoneSuffix
+	}
+	return fmt.Sprintf("%s|%s|%s", n.Timestamp.Format(time.RFC3339), noteType, n.Escape())
 }
 
 // ParseNoteLogLine parses a log line into a Note
@@ -60,8 +111,19 @@ func ParseNoteLogLine(line string) (*Note, error) {
 		return nil, fmt.Errorf("invalid timestamp: %w", err)
 	}
 
-	return &Note{
-		Timestamp:   timestamp,
-		TextContent: UnescapeNote(parts[1]),
-	}, nil
+	note := &Note{Timestamp: timestamp}
+	text := parts[1]
+
+	// Pipes in the text are escaped, so an unescaped pipe after a known type
+	// can only be the type separator of a typed note
+	if noteType, rest, ok := strings.Cut(text, "|"); ok {
+		done := strings.HasSuffix(noteType, noteDoneSuffix)
+		noteType = strings.TrimSuffix(noteType, noteDoneSuffix)
+		if isNoteType(noteType) {
+			note.Type, note.Done, text = noteType, done, rest
+		}
+	}
+
+	note.TextContent = UnescapeNote(text)
+	return note, nil
 }
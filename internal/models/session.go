@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Session is one interval during which a context was the active context,
+// reconstructed from the transitions log
+type Session struct {
+	Context string    `json:"context"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Ongoing bool      `json:"ongoing,omitempty"` // Still active; End is the time the session was read
+}
+
+// Duration returns the length of the session
+func (s Session) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Clip returns the part of the session that falls within [from, to) and whether
+// there is any
+func (s Session) Clip(from, to time.Time) (Session, bool) {
+	if !s.End.After(from) || !s.Start.Before(to) {
+		return Session{}, false
+	}
+	if s.Start.Before(from) {
+		s.Start = from
+	}
+	if s.End.After(to) {
+		s.End = to
+		s.Ongoing = false
+	}
+	return s, true
+}
+
+// Contains reports whether t falls within the session
+func (s Session) Contains(t time.Time) bool {
+	return !t.Before(s.Start) && !t.After(s.End)
+}
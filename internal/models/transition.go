@@ -13,6 +13,8 @@ const (
 	TransitionStart  TransitionType = "start"
 	TransitionStop   TransitionType = "stop"
 	TransitionSwitch TransitionType = "switch"
+	TransitionRename TransitionType = "rename"
+	TransitionMerge  TransitionType = "merge" // A context merged into another; one per source
 )
 
 // ContextTransition represents a log entry recording a change between contexts
@@ -44,6 +46,20 @@ func (ct *ContextTransition) Validate() error {
 		if ct.NewContext == nil || *ct.NewContext == "" {
 			return fmt.Errorf("switch transition must have new_context")
 		}
+	case TransitionRename:
+		if ct.PreviousContext == nil || *ct.PreviousContext == "" {
+			return fmt.Errorf("rename transition must have previous_context (old name)")
+		}
+		if ct.NewContext == nil || *ct.NewContext == "" {
+			return fmt.Errorf("rename transition must have new_context (new name)")
+		}
+	case TransitionMerge:
+		if ct.PreviousContext == nil || *ct.PreviousContext == "" {
+			return fmt.Errorf("merge transition must have previous_context (merged source)")
+		}
+		if ct.NewContext == nil || *ct.NewContext == "" {
+			return fmt.Errorf("merge transition must have new_context (merged context)")
+		}
 	default:
 		return fmt.Errorf("invalid transition type: %s", ct.TransitionType)
 	}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"encoding/csv"
+	"strings"
+	"time"
+)
+
+// csvExporter writes one row per event (start, note, file, touch, stop)
+type csvExporter struct{}
+
+func (csvExporter) Name() string      { return "csv" }
+func (csvExporter) Extension() string { return ".csv" }
+
+func (csvExporter) Render(docs []*ExportDocument) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	if err := w.Write([]string{"context", "parent", "type", "timestamp", "text", "note_type", "done"}); err != nil {
+		return "", err
+	}
+	for _, d := range docs {
+		for _, event := range d.Events() {
+			done := ""
+			if event.Done {
+				done = "true"
+			}
+			record := []string{event.Context, event.Parent, event.Type, event.Timestamp.UTC().Format(time.RFC3339), event.Text, event.NoteType, done}
+			if err := w.Write(record); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// RenderTree writes the rows of every context in the tree, parents before their children
+func (e csvExporter) RenderTree(root *ExportDocument) (string, error) {
+	return e.Render(flattenTree(root))
+}
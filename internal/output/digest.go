@@ -0,0 +1,116 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// Digest summarizes the work done across contexts in a time window
+type Digest struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	TimeSpent int             `json:"time_spent_seconds"`
+	NoteCount int             `json:"note_count"`
+	FileCount int             `json:"file_count"`
+	Projects  []DigestProject `json:"projects"`
+}
+
+// DigestProject groups the digest entries of the contexts in one project
+type DigestProject struct {
+	Name      string          `json:"name"`
+	TimeSpent int             `json:"time_spent_seconds"`
+	Contexts  []DigestContext `json:"contexts"`
+}
+
+// DigestContext is what happened in one context during the digest window
+type DigestContext struct {
+	Name         string                   `json:"name"`
+	Status       string                   `json:"status"` // Context status, or deleted or trashed for a context that is gone
+	TimeSpent    int                      `json:"time_spent_seconds"`
+	Sessions     []models.Session         `json:"sessions"`
+	Notes        []models.Note            `json:"notes"`
+	Files        []models.FileAssociation `json:"files"`
+	StateChanges []DigestStateChange      `json:"state_changes"`
+}
+
+// DigestStateChange is a change of a context's lifecycle (started, stopped,
+// renamed) or workflow state (status set) within the digest window
+type DigestStateChange struct {
+	Timestamp time.Time `json:"timestamp"`
+	Change    string    `json:"change"` // started, stopped, renamed or state
+	Detail    string    `json:"detail,omitempty"`
+}
+
+// FormatDigestMarkdown renders a digest as a markdown summary for standups
+func FormatDigestMarkdown(d *Digest) string {
+	var sb strings.Builder
+
+	layout := digestLayout(d)
+	sb.WriteString(fmt.Sprintf("# Digest: %s – %s\n\n", d.From.Local().Format("Mon Jan 2 15:04"), d.To.Local().Format(layout)))
+
+	if len(d.Projects) == 0 {
+		sb.WriteString("No activity in this period.\n")
+		return sb.String()
+	}
+
+	contexts := 0
+	for _, p := range d.Projects {
+		contexts += len(p.Contexts)
+	}
+	sb.WriteString(fmt.Sprintf("**Time spent**: %s across %d %s in %d %s  \n",
+		formatDuration(seconds(d.TimeSpent)), contexts, plural(contexts, "context"), len(d.Projects), plural(len(d.Projects), "project")))
+	sb.WriteString(fmt.Sprintf("**Notes**: %d · **Files**: %d\n", d.NoteCount, d.FileCount))
+
+	for _, p := range d.Projects {
+		sb.WriteString(fmt.Sprintf("\n## %s (%s)\n", p.Name, formatDuration(seconds(p.TimeSpent))))
+
+		for _, c := range p.Contexts {
+			sb.WriteString(fmt.Sprintf("\n### %s (%s)\n\n", c.Name, formatDuration(seconds(c.TimeSpent))))
+
+			for _, change := range c.StateChanges {
+				line := fmt.Sprintf("- %s %s", change.Timestamp.Local().Format(layout), change.Change)
+				if change.Detail != "" {
+					line += ": " + change.Detail
+				}
+				sb.WriteString(line + "\n")
+			}
+			for _, note := range c.Notes {
+				text := strings.ReplaceAll(note.TextContent, "\n", "\n  ")
+				sb.WriteString(fmt.Sprintf("- %s %s\n", note.Timestamp.Local().Format(layout), text))
+			}
+			if len(c.Files) > 0 {
+				paths := make([]string, 0, len(c.Files))
+				for _, f := range c.Files {
+					paths = append(paths, "`"+f.FilePath+"`")
+				}
+				sb.WriteString(fmt.Sprintf("- Files: %s\n", strings.Join(paths, ", ")))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// digestLayout shows times alone when the window covers a single day, and
+// with the weekday and date otherwise
+func digestLayout(d *Digest) string {
+	from, to := d.From.Local(), d.To.Local()
+	if from.Year() == to.Year() && from.YearDay() == to.YearDay() {
+		return "15:04"
+	}
+	return "Mon Jan 2 15:04"
+}
+
+func seconds(s int) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,316 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// Version is the my-context version named in export footers; main sets it at startup
+var Version = "dev"
+
+// ExportDocument holds everything an exporter needs to render one context. It is
+// also the data model of export templates (see docs/guides/EXPORT-TEMPLATES.md).
+type ExportDocument struct {
+	Context     *models.Context
+	Metadata    pkgmodels.ContextMetadata // Creator, parent, labels, workflow state, handoff
+	Notes       []models.Note
+	Files       []models.FileAssociation
+	Touches     []models.TouchEvent
+	Transitions []models.ContextTransition // Transitions that started, stopped or renamed this context
+	Children    []*ExportDocument          // Linked child contexts, each with its own children
+}
+
+// Duration returns how long the context has run
+func (d *ExportDocument) Duration() time.Duration {
+	return d.Context.Duration()
+}
+
+// TotalDuration returns the duration of the context plus all of its descendants
+func (d *ExportDocument) TotalDuration() time.Duration {
+	total := d.Duration()
+	for _, child := range d.Children {
+		total += child.TotalDuration()
+	}
+	return total
+}
+
+// TotalNotes returns the number of notes in the context and all of its descendants
+func (d *ExportDocument) TotalNotes() int {
+	total := len(d.Notes)
+	for _, child := range d.Children {
+		total += child.TotalNotes()
+	}
+	return total
+}
+
+// NoteGroup is the notes of one type, as exports list them
+type NoteGroup struct {
+	Type  string // Empty for plain notes
+	Title string // Heading for the group, empty for plain notes
+	Notes []models.Note
+}
+
+// noteGroupTitles are the headings exports use for each note type
+var noteGroupTitles = map[string]string{
+	models.NoteTypeDecision: "Decisions",
+	models.NoteTypeTodo:     "Todos",
+	models.NoteTypeQuestion: "Questions",
+	models.NoteTypeBlocker:  "Blockers",
+	models.NoteTypeLink:     "Links",
+}
+
+// GroupNotes splits notes by type: plain notes first, then each type in the
+// order of models.NoteTypes. Empty groups are left out.
+func GroupNotes(notes []models.Note) []NoteGroup {
+	byType := make(map[string][]models.Note)
+	for _, note := range notes {
+		byType[note.Type] = append(byType[note.Type], note)
+	}
+
+	var groups []NoteGroup
+	for _, noteType := range append([]string{""}, models.NoteTypes...) {
+		if len(byType[noteType]) > 0 {
+			groups = append(groups, NoteGroup{Type: noteType, Title: noteGroupTitles[noteType], Notes: byType[noteType]})
+		}
+	}
+	return groups
+}
+
+// NoteGroups returns the context's notes grouped by type (see GroupNotes)
+func (d *ExportDocument) NoteGroups() []NoteGroup {
+	return GroupNotes(d.Notes)
+}
+
+// Exporter renders contexts into a document. Render receives one document for a
+// single-context export and several for a combined export, which should start
+// with a table of contents where the format allows one. RenderTree renders a
+// context and all of its descendants as one nested document.
+type Exporter interface {
+	Name() string      // Format name used with export --format
+	Extension() string // Default file extension, including the dot
+	Render(docs []*ExportDocument) (string, error)
+	RenderTree(root *ExportDocument) (string, error)
+}
+
+var (
+	exporters       = make(map[string]Exporter)
+	exporterAliases = make(map[string]string)
+)
+
+// RegisterExporter makes an exporter available to export --format under its name and any aliases
+func RegisterExporter(e Exporter, aliases ...string) {
+	exporters[e.Name()] = e
+	for _, alias := range aliases {
+		exporterAliases[alias] = e.Name()
+	}
+}
+
+// GetExporter returns the exporter registered under a format name or alias
+func GetExporter(format string) (Exporter, error) {
+	name := strings.ToLower(format)
+	if target, ok := exporterAliases[name]; ok {
+		name = target
+	}
+	e, ok := exporters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown export format %q (available: %s)", format, strings.Join(ExporterNames(), ", "))
+	}
+	return e, nil
+}
+
+// ExporterNames returns the registered format names in alphabetical order
+func ExporterNames() []string {
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterExporter(markdownExporter{}, "md")
+	RegisterExporter(jsonExporter{})
+	RegisterExporter(htmlExporter{}, "htm")
+	RegisterExporter(orgExporter{})
+	RegisterExporter(csvExporter{})
+	RegisterExporter(jsonlExporter{}, "ndjson")
+}
+
+// Walk calls fn for the document and each descendant, depth first, with the
+// depth below d (0 for d itself)
+func (d *ExportDocument) Walk(fn func(doc *ExportDocument, depth int)) {
+	d.walk(fn, 0)
+}
+
+func (d *ExportDocument) walk(fn func(doc *ExportDocument, depth int), depth int) {
+	fn(d, depth)
+	for _, child := range d.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// ExportEvent is one timestamped entry of a context, as written by the CSV and JSONL exporters
+type ExportEvent struct {
+	Context   string    `json:"context"`
+	Parent    string    `json:"parent,omitempty"`
+	Type      string    `json:"type"` // start, note, file, touch or stop
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"text,omitempty"` // Note text or file path
+	NoteType  string    `json:"note_type,omitempty"`
+	Done      bool      `json:"done,omitempty"` // A todo that is done
+}
+
+// Events returns the context's start, notes, files, touches and stop in time order
+func (d *ExportDocument) Events() []ExportEvent {
+	event := func(kind string, at time.Time, text string) ExportEvent {
+		return ExportEvent{Context: d.Context.Name, Parent: d.Metadata.Parent, Type: kind, Timestamp: at, Text: text}
+	}
+
+	// Log entries are stored with second precision, so match it for start and stop
+	events := []ExportEvent{event("start", d.Context.StartTime.Truncate(time.Second), "")}
+	for _, note := range d.Notes {
+		e := event("note", note.Timestamp, note.TextContent)
+		e.NoteType, e.Done = note.Type, note.Done
+		events = append(events, e)
+	}
+	for _, file := range d.Files {
+		events = append(events, event("file", file.Timestamp, file.FilePath))
+	}
+	for _, touch := range d.Touches {
+		events = append(events, event("touch", touch.Timestamp, ""))
+	}
+	if d.Context.EndTime != nil {
+		events = append(events, event("stop", d.Context.EndTime.Truncate(time.Second), ""))
+	}
+
+	// Stable so the start stays first and the stop last when timestamps tie
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events
+}
+
+// flattenTree lists a document and its descendants, depth first
+func flattenTree(root *ExportDocument) []*ExportDocument {
+	var docs []*ExportDocument
+	root.Walk(func(d *ExportDocument, _ int) {
+		docs = append(docs, d)
+	})
+	return docs
+}
+
+// anchorID turns a context name into an identifier usable as a link target
+func anchorID(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+type markdownExporter struct{}
+
+func (markdownExporter) Name() string      { return "markdown" }
+func (markdownExporter) Extension() string { return ".md" }
+
+func (markdownExporter) Render(docs []*ExportDocument) (string, error) {
+	if len(docs) == 1 {
+		d := docs[0]
+		return FormatExportMarkdown(d.Context, d.Notes, d.Files, len(d.Touches)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# my-context export\n\n")
+	sb.WriteString(fmt.Sprintf("**Exported**: %s\n\n", formatLocalTime(time.Now())))
+	sb.WriteString("## Contents\n\n")
+	for _, d := range docs {
+		sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", d.Context.Name, anchorID("Context: "+d.Context.Name)))
+	}
+	sb.WriteString("\n---\n\n")
+	for _, d := range docs {
+		sb.WriteString(FormatExportMarkdown(d.Context, d.Notes, d.Files, len(d.Touches)))
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+func (markdownExporter) RenderTree(root *ExportDocument) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Tree: %s\n\n", root.Context.Name))
+	sb.WriteString(fmt.Sprintf("**Exported**: %s\n\n", formatLocalTime(time.Now())))
+
+	// Summary table with rolled-up totals, indented to show the hierarchy
+	sb.WriteString("| Context | State | Duration | Total duration | Notes | Total notes |\n")
+	sb.WriteString("|---|---|---|---|---|---|\n")
+	root.Walk(func(d *ExportDocument, depth int) {
+		sb.WriteString(fmt.Sprintf("| %s[%s](#%s) | %s | %s | %s | %d | %d |\n",
+			strings.Repeat("&nbsp;&nbsp;", depth), d.Context.Name, anchorID(d.Context.Name),
+			d.Metadata.State, formatDuration(d.Duration()), formatDuration(d.TotalDuration()),
+			len(d.Notes), d.TotalNotes()))
+	})
+	sb.WriteString("\n")
+
+	root.Walk(func(d *ExportDocument, depth int) {
+		level := depth + 2
+		if level > 6 {
+			level = 6
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n\n", strings.Repeat("#", level), d.Context.Name))
+		if d.Metadata.State != "" {
+			sb.WriteString(fmt.Sprintf("**State**: %s  \n", d.Metadata.State))
+		}
+		sb.WriteString(fmt.Sprintf("**Duration**: %s", formatDuration(d.Duration())))
+		if len(d.Children) > 0 {
+			sb.WriteString(fmt.Sprintf(" (%s with subcontexts)", formatDuration(d.TotalDuration())))
+		}
+		sb.WriteString("  \n")
+		sb.WriteString(fmt.Sprintf("**Notes**: %d", len(d.Notes)))
+		if len(d.Children) > 0 {
+			sb.WriteString(fmt.Sprintf(" (%d in total)", d.TotalNotes()))
+		}
+		sb.WriteString("\n\n")
+
+		writeMarkdownNotes(&sb, d.Notes, "")
+		for _, file := range d.Files {
+			sb.WriteString(fmt.Sprintf("- **%s** `%s`\n", formatLocalTime(file.Timestamp), file.FilePath))
+		}
+		if len(d.Notes)+len(d.Files) > 0 {
+			sb.WriteString("\n")
+		}
+	})
+
+	sb.WriteString("---\n\n")
+	sb.WriteString(fmt.Sprintf("*Exported from my-context %s*\n", versionLabel()))
+	return sb.String(), nil
+}
+
+type jsonExporter struct{}
+
+func (jsonExporter) Name() string      { return "json" }
+func (jsonExporter) Extension() string { return ".json" }
+
+func (jsonExporter) Render(docs []*ExportDocument) (string, error) {
+	if len(docs) == 1 {
+		d := docs[0]
+		return FormatExportJSON(d.Context, d.Notes, d.Files, len(d.Touches))
+	}
+	return FormatExportJSONCombined(docs)
+}
+
+func (jsonExporter) RenderTree(root *ExportDocument) (string, error) {
+	return FormatExportJSONTree(root)
+}
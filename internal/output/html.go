@@ -0,0 +1,225 @@
+package output
+
+import (
+	"html/template"
+	"strings"
+	"time"
+)
+
+// htmlExporter writes a self-contained HTML page with inline styles and no external assets
+type htmlExporter struct{}
+
+func (htmlExporter) Name() string      { return "html" }
+func (htmlExporter) Extension() string { return ".html" }
+
+func (htmlExporter) Render(docs []*ExportDocument) (string, error) {
+	title := "my-context export"
+	if len(docs) == 1 {
+		title = "Context: " + docs[0].Context.Name
+	}
+
+	data := struct {
+		Title    string
+		Version  string
+		Exported time.Time
+		Combined bool
+		Docs     []*ExportDocument
+	}{
+		Title:    title,
+		Version:  versionLabel(),
+		Exported: time.Now(),
+		Combined: len(docs) > 1,
+		Docs:     docs,
+	}
+
+	var sb strings.Builder
+	if err := htmlTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// RenderTree nests each child context's section inside its parent's, with a
+// nested table of contents and totals rolled up from the descendants
+func (htmlExporter) RenderTree(root *ExportDocument) (string, error) {
+	data := struct {
+		Title    string
+		Version  string
+		Exported time.Time
+		Root     *ExportDocument
+	}{
+		Title:    "Tree: " + root.Context.Name,
+		Version:  versionLabel(),
+		Exported: time.Now(),
+		Root:     root,
+	}
+
+	var sb strings.Builder
+	if err := htmlTreeTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+var htmlFuncs = template.FuncMap{
+	"anchor":   anchorID,
+	"time":     formatLocalTime,
+	"duration": formatDuration,
+	"heading": func(depth int) int {
+		if depth > 4 {
+			return 6
+		}
+		return depth + 2
+	},
+	"child": func(d *ExportDocument, depth int) map[string]interface{} {
+		return map[string]interface{}{"Doc": d, "Depth": depth}
+	},
+	"inc": func(i int) int { return i + 1 },
+}
+
+// htmlHead is the shared <head> of the single, combined and tree pages
+const htmlHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+         max-width: 52rem; margin: 2rem auto; padding: 0 1rem; color: #1f2328; line-height: 1.5; }
+  h1 { border-bottom: 1px solid #d0d7de; padding-bottom: .3rem; }
+  h2 { margin-top: 2.5rem; border-bottom: 1px solid #d0d7de; padding-bottom: .2rem; }
+  h3 { margin-top: 1.5rem; font-size: 1.05rem; }
+  h4 { margin: 1rem 0 0; font-size: .95rem; color: #57606a; }
+  dl.meta { display: grid; grid-template-columns: max-content auto; gap: .2rem 1rem; }
+  dl.meta dt { font-weight: 600; color: #57606a; }
+  dl.meta dd { margin: 0; }
+  ul.entries { list-style: none; padding: 0; }
+  ul.entries li { padding: .35rem 0; border-bottom: 1px solid #eaeef2; }
+  time { color: #57606a; font-size: .85rem; margin-right: .6rem; white-space: nowrap; }
+  code { background: #f6f8fa; padding: .1rem .3rem; border-radius: 4px; }
+  .archived { display: inline-block; background: #fff8c5; border: 1px solid #d4a72c;
+              border-radius: 4px; padding: .05rem .4rem; font-size: .8rem; }
+  .none { color: #57606a; font-style: italic; }
+  nav.toc { background: #f6f8fa; border: 1px solid #d0d7de; border-radius: 6px; padding: .5rem 1.5rem; }
+  footer { margin-top: 3rem; color: #57606a; font-size: .8rem; }
+  section section { margin-left: 1.2rem; padding-left: 1rem; border-left: 3px solid #eaeef2; }
+  .totals { color: #57606a; font-size: .9rem; }
+</style>
+</head>
+<body>`
+
+// htmlNotes lists a document's notes, plain notes first and then one titled
+// list per note type, with todos as checkboxes
+const htmlNotes = `{{define "notes"}}
+{{- range .NoteGroups}}
+{{- with .Title}}
+<h4>{{.}}</h4>
+{{- end}}
+<ul class="entries">
+{{- range .Notes}}
+  <li><time>{{time .Timestamp}}</time>{{if eq .Type "todo"}}<input type="checkbox" disabled{{if .Done}} checked{{end}}> {{end}}{{.TextContent}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}`
+
+var htmlTemplate = template.Must(template.New("export").Funcs(htmlFuncs).Parse(htmlHead + htmlNotes + `
+{{- if .Combined}}
+<h1>{{.Title}}</h1>
+<nav class="toc">
+<h2 style="margin-top: .5rem; border: 0">Contents</h2>
+<ul>
+{{- range .Docs}}
+  <li><a href="#{{anchor .Context.Name}}">{{.Context.Name}}</a></li>
+{{- end}}
+</ul>
+</nav>
+{{- end}}
+{{range .Docs}}
+<section id="{{anchor .Context.Name}}">
+{{- if $.Combined}}
+<h2>{{.Context.Name}}</h2>
+{{- else}}
+<h1>Context: {{.Context.Name}}</h1>
+{{- end}}
+<dl class="meta">
+  <dt>Started</dt><dd>{{time .Context.StartTime}}</dd>
+  <dt>Ended</dt><dd>{{if .Context.EndTime}}{{time .Context.EndTime}}{{else}}Currently Active{{end}}</dd>
+  <dt>Duration</dt><dd>{{duration .Duration}}</dd>
+</dl>
+{{- if .Context.IsArchived}}
+<p><span class="archived">Archived</span></p>
+{{- end}}
+<h3>Notes</h3>
+{{- if .Notes}}
+{{- template "notes" .}}
+{{- else}}
+<p class="none">(none)</p>
+{{- end}}
+<h3>Files</h3>
+{{- if .Files}}
+<ul class="entries">
+{{- range .Files}}
+  <li><time>{{time .Timestamp}}</time><code>{{.FilePath}}</code></li>
+{{- end}}
+</ul>
+{{- else}}
+<p class="none">(none)</p>
+{{- end}}
+<h3>Activity</h3>
+{{- if .Touches}}
+<p>Total: {{len .Touches}} touches</p>
+{{- else}}
+<p class="none">(none)</p>
+{{- end}}
+</section>
+{{end}}
+<footer>Exported from my-context {{.Version}} on {{time .Exported}}</footer>
+</body>
+</html>
+`))
+
+var htmlTreeTemplate = template.Must(template.New("tree").Funcs(htmlFuncs).Parse(htmlHead + htmlNotes + `
+<h1>{{.Title}}</h1>
+<nav class="toc">
+<h2 style="margin-top: .5rem; border: 0">Contents</h2>
+{{template "toc" .Root}}
+</nav>
+{{template "node" child .Root 0}}
+<footer>Exported from my-context {{.Version}} on {{time .Exported}}</footer>
+</body>
+</html>
+{{define "toc"}}<ul>
+  <li><a href="#{{anchor .Context.Name}}">{{.Context.Name}}</a>{{with .Metadata.State}} <small>[{{.}}]</small>{{end}}
+  {{- range .Children}}{{template "toc" .}}{{end}}</li>
+</ul>{{end}}
+{{define "node"}}{{$depth := .Depth}}{{with .Doc}}
+<section id="{{anchor .Context.Name}}">
+<h{{heading $depth}}>{{.Context.Name}}</h{{heading $depth}}>
+<dl class="meta">
+  {{- with .Metadata.State}}
+  <dt>State</dt><dd>{{.}}</dd>
+  {{- end}}
+  <dt>Started</dt><dd>{{time .Context.StartTime}}</dd>
+  <dt>Ended</dt><dd>{{if .Context.EndTime}}{{time .Context.EndTime}}{{else}}Currently Active{{end}}</dd>
+  <dt>Duration</dt><dd>{{duration .Duration}}</dd>
+  <dt>Notes</dt><dd>{{len .Notes}}</dd>
+</dl>
+{{- if .Children}}
+<p class="totals">Including subcontexts: {{duration .TotalDuration}}, {{.TotalNotes}} notes</p>
+{{- end}}
+{{- if .Notes}}
+{{- template "notes" .}}
+{{- end}}
+{{- if .Files}}
+<ul class="entries">
+{{- range .Files}}
+  <li><time>{{time .Timestamp}}</time><code>{{.FilePath}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- range .Children}}{{template "node" child . (inc $depth)}}{{end}}
+</section>
+{{- end}}{{end}}
+`))
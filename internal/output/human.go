@@ -2,10 +2,10 @@ package output
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
 	"github.com/jefferycaldwell/my-context-copilot/internal/models"
 	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
 )
@@ -21,9 +21,10 @@ func PrintContextHomeHeader(homeDisplay string, contextCount int) {
 	fmt.Println()
 }
 
-// getTimestampFormat returns the timestamp format based on MC_TIMESTAMP_FORMAT env var
+// getTimestampFormat returns the timestamp format from the timestamp_format setting
+// (MC_TIMESTAMP_FORMAT or config.yaml)
 func getTimestampFormat() string {
-	format := os.Getenv("MC_TIMESTAMP_FORMAT")
+	format := config.Get().TimestampFormat
 	switch format {
 	case "short":
 		return "15:04" // HH:MM
@@ -43,6 +44,7 @@ func getTimestampFormat() string {
 // contextInfo holds extracted context information
 type contextInfo struct {
 	name        string
+	id          string
 	status      string
 	startTime   time.Time
 	duration    time.Duration
@@ -57,6 +59,7 @@ func extractContextInfo(ctx interface{}) (contextInfo, error) {
 	switch c := ctx.(type) {
 	case *pkgmodels.ContextWithMetadata:
 		info.name = c.Name
+		info.id = c.ID
 		info.status = c.Status
 		info.startTime = c.StartTime
 		info.duration = c.Duration()
@@ -64,6 +67,7 @@ func extractContextInfo(ctx interface{}) (contextInfo, error) {
 		info.metadata = c.Metadata
 	case *models.Context:
 		info.name = c.Name
+		info.id = c.ID
 		info.status = c.Status
 		info.startTime = c.StartTime
 		info.duration = c.Duration()
@@ -81,7 +85,7 @@ func formatMetadataSection(hasMetadata bool, metadata interface{}) string {
 	}
 
 	meta, ok := metadata.(pkgmodels.ContextMetadata)
-	if !ok || (meta.CreatedBy == "" && meta.Parent == "" && len(meta.Labels) == 0) {
+	if !ok || (meta.CreatedBy == "" && meta.Parent == "" && len(meta.Labels) == 0 && meta.Handoff == nil && meta.State == "") {
 		return ""
 	}
 
@@ -96,6 +100,12 @@ func formatMetadataSection(hasMetadata bool, metadata interface{}) string {
 	if len(meta.Labels) > 0 {
 		sb.WriteString(fmt.Sprintf("  Labels: %s\n", strings.Join(meta.Labels, ", ")))
 	}
+	if meta.State != "" {
+		sb.WriteString(fmt.Sprintf("  State: %s\n", meta.State))
+	}
+	if h := meta.Handoff; h != nil {
+		sb.WriteString(fmt.Sprintf("  Handoff: %s → %s (%s)\n", h.From, h.To, h.Status))
+	}
 	return sb.String()
 }
 
@@ -110,14 +120,30 @@ func formatNotesSection(notes []*models.Note) string {
 	}
 
 	timestampFormat := getTimestampFormat()
-	for _, note := range notes {
-		sb.WriteString(fmt.Sprintf("  [%s] %s\n",
+	// Number notes so they can be referenced (e.g., split --after <note-id>)
+	for i, note := range notes {
+		sb.WriteString(fmt.Sprintf("  #%d [%s] %s%s\n",
+			i+1,
 			note.Timestamp.Format(timestampFormat),
+			NoteTypeLabel(note),
 			note.TextContent))
 	}
 	return sb.String()
 }
 
+// NoteTypeLabel returns the marker shown before a typed note's text, such as
+// "TODO: " or "DONE: ", or nothing for a plain note
+func NoteTypeLabel(note *models.Note) string {
+	switch {
+	case note.Type == "":
+		return ""
+	case note.Done:
+		return "DONE: "
+	default:
+		return strings.ToUpper(note.Type) + ": "
+	}
+}
+
 // formatFilesSection formats the files section
 func formatFilesSection(files []*models.FileAssociation) string {
 	var sb strings.Builder
@@ -163,6 +189,9 @@ func FormatContext(ctx interface{}, notes []*models.Note, files []*models.FileAs
 
 	// Format header
 	sb.WriteString(fmt.Sprintf("Context: %s\n", info.name))
+	if info.id != "" {
+		sb.WriteString(fmt.Sprintf("ID: %s\n", info.id))
+	}
 	sb.WriteString(fmt.Sprintf("Status: %s\n", info.status))
 	sb.WriteString(fmt.Sprintf("Started: %s (%s ago)\n",
 		info.startTime.Format("2006-01-02 15:04:05"),
@@ -177,6 +206,24 @@ func FormatContext(ctx interface{}, notes []*models.Note, files []*models.FileAs
 	return sb.String()
 }
 
+// FormatSignalsSection formats signals scoped to a context, or nothing when there are none
+func FormatSignalsSection(signals []pkgmodels.SignalInfo) string {
+	if len(signals) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nSignals (%d):\n", len(signals)))
+	for _, sig := range signals {
+		sb.WriteString(fmt.Sprintf("  %s (created %s)", sig.Name, sig.CreatedAt))
+		if sig.Message != "" {
+			sb.WriteString(fmt.Sprintf(" - %s", sig.Message))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
 // FormatContextList formats a list of contexts for human-readable output
 func FormatContextList(contexts []*models.Context, activeContextName string) string {
 	var sb strings.Builder
@@ -198,6 +245,9 @@ func FormatContextList(contexts []*models.Context, activeContextName string) str
 
 		// Status line
 		statusLine := fmt.Sprintf("  %s %s (%s)\n", indicator, ctx.Name, ctx.Status)
+		if ctx.ID != "" {
+			statusLine = fmt.Sprintf("  %s %s (%s) [%s]\n", indicator, ctx.Name, ctx.Status, ctx.ID)
+		}
 		sb.WriteString(statusLine)
 
 		// Start time line
@@ -253,6 +303,12 @@ func FormatTransitionHistory(transitions []*models.ContextTransition) string {
 		case models.TransitionSwitch:
 			sb.WriteString(fmt.Sprintf("  %s │ SWITCH    │ %s → %s\n",
 				timestamp, *t.PreviousContext, *t.NewContext))
+		case models.TransitionRename:
+			sb.WriteString(fmt.Sprintf("  %s │ RENAME    │ %s → %s\n",
+				timestamp, *t.PreviousContext, *t.NewContext))
+		case models.TransitionMerge:
+			sb.WriteString(fmt.Sprintf("  %s │ MERGE     │ %s → %s\n",
+				timestamp, *t.PreviousContext, *t.NewContext))
 		}
 	}
 
@@ -0,0 +1,104 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// CalendarEvent is a work session as written to an iCalendar file
+type CalendarEvent struct {
+	Session models.Session
+	Project string
+	Notes   []models.Note // Notes written during the session
+}
+
+// FormatICS renders sessions as an iCalendar (RFC 5545) document with one VEVENT each
+func FormatICS(events []CalendarEvent) string {
+	stamp := icsTime(time.Now())
+
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		fmt.Sprintf("PRODID:-//my-context//my-context %s//EN", versionLabel()),
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:my-context",
+	)
+
+	for _, e := range events {
+		s := e.Session
+
+		var desc []string
+		for _, note := range e.Notes {
+			desc = append(desc, fmt.Sprintf("%s %s", note.Timestamp.Local().Format("15:04"), note.TextContent))
+		}
+		if s.Ongoing {
+			desc = append(desc, "(still active)")
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%d-%s@my-context", s.Start.Unix(), anchorID(s.Context)),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+icsTime(s.Start),
+			"DTEND:"+icsTime(s.End),
+			"SUMMARY:"+icsEscape(s.Context),
+		)
+		if e.Project != "" {
+			lines = append(lines, "CATEGORIES:"+icsEscape(e.Project))
+		}
+		if len(desc) > 0 {
+			lines = append(lines, "DESCRIPTION:"+icsEscape(strings.Join(desc, "\n")))
+		}
+		lines = append(lines, "TRANSP:OPAQUE", "END:VEVENT")
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(icsFold(line))
+		sb.WriteString("\r\n")
+	}
+	return sb.String()
+}
+
+// icsTime formats a time as an iCalendar UTC date-time
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsEscape escapes text property values
+func icsEscape(text string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(text)
+}
+
+// icsFold splits content lines longer than 75 octets, continuing them on lines
+// that start with a space, without breaking UTF-8 sequences
+func icsFold(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+
+	var sb strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		width = limit - 1 // The leading space counts toward the limit
+	}
+	sb.WriteString(line)
+	return sb.String()
+}
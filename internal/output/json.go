@@ -5,14 +5,30 @@ import (
 	"time"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
 )
 
-// JSONResponse represents a standard JSON response structure
+// JSONSchemaVersion is the version of the JSON envelope and the data schemas in
+// docs/schemas. It changes when a field is renamed or removed; adding fields
+// doesn't change it.
+const JSONSchemaVersion = "1.0"
+
+// Exit codes, also used as the error code in JSON error envelopes
+const (
+	ExitOK       = 0
+	ExitUsage    = 1 // The request can't be carried out: bad arguments, unknown or missing context, disallowed change
+	ExitFailure  = 2 // Reading or writing context data failed
+	ExitConflict = 3 // A stopped context with the requested name already exists
+)
+
+// JSONResponse is the envelope every command prints with --json
 type JSONResponse struct {
-	Command   string      `json:"command"`
-	Timestamp time.Time   `json:"timestamp"`
-	Data      interface{} `json:"data,omitempty"`
-	Error     *JSONError  `json:"error,omitempty"`
+	SchemaVersion string      `json:"schema_version"`
+	Command       string      `json:"command"` // Command path without the binary name, e.g. "tag add"
+	Timestamp     time.Time   `json:"timestamp"`
+	OK            bool        `json:"ok"`
+	Data          interface{} `json:"data,omitempty"`
+	Error         *JSONError  `json:"error,omitempty"`
 }
 
 // JSONError represents an error in JSON format
@@ -21,12 +37,24 @@ type JSONError struct {
 	Message string `json:"message"`
 }
 
+// ExitError is returned by a command that has already reported its error
+// (as a JSON envelope); main exits with Code without printing anything else.
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
 // ContextData represents context data for JSON output
 type ContextData struct {
 	Context interface{}               `json:"context"` // Can be *models.Context or *pkgmodels.ContextWithMetadata
 	Notes   []*models.Note            `json:"notes,omitempty"`
 	Files   []*models.FileAssociation `json:"files,omitempty"`
 	Touches []*models.TouchEvent      `json:"touches,omitempty"`
+	Signals []pkgmodels.SignalInfo    `json:"signals,omitempty"` // Signals scoped to the context
 }
 
 // StartData represents start command output data
@@ -51,6 +79,8 @@ type NoteData struct {
 	ContextName   string    `json:"context_name"`
 	NoteTimestamp time.Time `json:"note_timestamp"`
 	NoteText      string    `json:"note_text"`
+	NoteType      string    `json:"note_type,omitempty"`
+	NoteNumber    int       `json:"note_number,omitempty"` // Number shown by show, used by note done
 }
 
 // FileData represents file command output data
@@ -78,6 +108,7 @@ type ContextSummary struct {
 	StartTime       time.Time  `json:"start_time"`
 	EndTime         *time.Time `json:"end_time,omitempty"`
 	Status          string     `json:"status"`
+	State           string     `json:"state,omitempty"` // Workflow state, if set
 	DurationSeconds int        `json:"duration_seconds"`
 	NoteCount       int        `json:"note_count"`
 	FileCount       int        `json:"file_count"`
@@ -86,15 +117,18 @@ type ContextSummary struct {
 
 // HistoryData represents history command output data
 type HistoryData struct {
-	Transitions []*models.ContextTransition `json:"transitions"`
+	Transitions  []*models.ContextTransition `json:"transitions"`
+	SignalEvents []pkgmodels.SignalEvent     `json:"signal_events"`
 }
 
 // FormatJSON formats any data as JSON
 func FormatJSON(command string, data interface{}) (string, error) {
 	response := JSONResponse{
-		Command:   command,
-		Timestamp: time.Now(),
-		Data:      data,
+		SchemaVersion: JSONSchemaVersion,
+		Command:       command,
+		Timestamp:     time.Now(),
+		OK:            true,
+		Data:          data,
 	}
 
 	jsonData, err := json.MarshalIndent(response, "", "  ")
@@ -108,8 +142,9 @@ func FormatJSON(command string, data interface{}) (string, error) {
 // FormatJSONError formats an error as JSON
 func FormatJSONError(command string, code int, message string) (string, error) {
 	response := JSONResponse{
-		Command:   command,
-		Timestamp: time.Now(),
+		SchemaVersion: JSONSchemaVersion,
+		Command:       command,
+		Timestamp:     time.Now(),
 		Error: &JSONError{
 			Code:    code,
 			Message: message,
@@ -126,16 +161,16 @@ func FormatJSONError(command string, code int, message string) (string, error) {
 
 // ExportData represents export data structure for JSON output
 type ExportData struct {
-	Name       string                    `json:"name"`
-	StartTime  time.Time                 `json:"start_time"`
-	EndTime    *time.Time                `json:"end_time,omitempty"`
-	Status     string                    `json:"status"`
-	IsArchived bool                      `json:"is_archived"`
-	Duration   int                       `json:"duration_seconds"`
-	Notes      []models.Note             `json:"notes"`
-	Files      []models.FileAssociation  `json:"files"`
-	TouchCount int                       `json:"touch_count"`
-	ExportTime time.Time                 `json:"export_time"`
+	Name       string                   `json:"name"`
+	StartTime  time.Time                `json:"start_time"`
+	EndTime    *time.Time               `json:"end_time,omitempty"`
+	Status     string                   `json:"status"`
+	IsArchived bool                     `json:"is_archived"`
+	Duration   int                      `json:"duration_seconds"`
+	Notes      []models.Note            `json:"notes"`
+	Files      []models.FileAssociation `json:"files"`
+	TouchCount int                      `json:"touch_count"`
+	ExportTime time.Time                `json:"export_time"`
 }
 
 // FormatExportJSON formats context export data as JSON
@@ -160,3 +195,83 @@ func FormatExportJSON(ctx *models.Context, notes []models.Note, files []models.F
 
 	return string(jsonData) + "\n", nil
 }
+
+// FormatExportJSONCombined formats several contexts as one JSON document
+func FormatExportJSONCombined(docs []*ExportDocument) (string, error) {
+	combined := struct {
+		ExportTime time.Time    `json:"export_time"`
+		Contexts   []ExportData `json:"contexts"`
+	}{
+		ExportTime: time.Now(),
+		Contexts:   make([]ExportData, 0, len(docs)),
+	}
+
+	for _, d := range docs {
+		combined.Contexts = append(combined.Contexts, ExportData{
+			Name:       d.Context.Name,
+			StartTime:  d.Context.StartTime,
+			EndTime:    d.Context.EndTime,
+			Status:     d.Context.Status,
+			IsArchived: d.Context.IsArchived,
+			Duration:   int(d.Context.Duration().Seconds()),
+			Notes:      d.Notes,
+			Files:      d.Files,
+			TouchCount: len(d.Touches),
+			ExportTime: combined.ExportTime,
+		})
+	}
+
+	jsonData, err := json.MarshalIndent(combined, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonData) + "\n", nil
+}
+
+// ExportTreeData is one context of a tree export with totals over its descendants
+type ExportTreeData struct {
+	ExportData
+	State         string            `json:"state,omitempty"`
+	TotalDuration int               `json:"total_duration_seconds"`
+	TotalNotes    int               `json:"total_note_count"`
+	Children      []*ExportTreeData `json:"children"`
+}
+
+// FormatExportJSONTree formats a context hierarchy as nested JSON
+func FormatExportJSONTree(root *ExportDocument) (string, error) {
+	exportTime := time.Now()
+
+	var build func(d *ExportDocument) *ExportTreeData
+	build = func(d *ExportDocument) *ExportTreeData {
+		node := &ExportTreeData{
+			ExportData: ExportData{
+				Name:       d.Context.Name,
+				StartTime:  d.Context.StartTime,
+				EndTime:    d.Context.EndTime,
+				Status:     d.Context.Status,
+				IsArchived: d.Context.IsArchived,
+				Duration:   int(d.Duration().Seconds()),
+				Notes:      d.Notes,
+				Files:      d.Files,
+				TouchCount: len(d.Touches),
+				ExportTime: exportTime,
+			},
+			State:         d.Metadata.State,
+			TotalDuration: int(d.TotalDuration().Seconds()),
+			TotalNotes:    d.TotalNotes(),
+			Children:      make([]*ExportTreeData, 0, len(d.Children)),
+		}
+		for _, child := range d.Children {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	jsonData, err := json.MarshalIndent(build(root), "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonData) + "\n", nil
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// jsonlExporter writes one JSON object per event, one per line
+type jsonlExporter struct{}
+
+func (jsonlExporter) Name() string      { return "jsonl" }
+func (jsonlExporter) Extension() string { return ".jsonl" }
+
+func (jsonlExporter) Render(docs []*ExportDocument) (string, error) {
+	var sb strings.Builder
+	enc := json.NewEncoder(&sb)
+
+	for _, d := range docs {
+		for _, event := range d.Events() {
+			event.Timestamp = event.Timestamp.UTC()
+			if err := enc.Encode(event); err != nil {
+				return "", err
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderTree writes the events of every context in the tree, parents before their children
+func (e jsonlExporter) RenderTree(root *ExportDocument) (string, error) {
+	return e.Render(flattenTree(root))
+}
@@ -40,9 +40,7 @@ func FormatExportMarkdown(ctx *models.Context, notes []models.Note, files []mode
 	if len(notes) == 0 {
 		sb.WriteString("(none)\n\n")
 	} else {
-		for _, note := range notes {
-			sb.WriteString(fmt.Sprintf("- **%s** %s\n", formatLocalTime(note.Timestamp), note.TextContent))
-		}
+		writeMarkdownNotes(&sb, notes, "###")
 		sb.WriteString(fmt.Sprintf("\nTotal: %d notes\n\n", len(notes)))
 	}
 
@@ -72,11 +70,44 @@ func FormatExportMarkdown(ctx *models.Context, notes []models.Note, files []mode
 	sb.WriteString("---\n\n")
 
 	// Footer
-	sb.WriteString("*Exported from my-context v2.0.0*\n")
+	sb.WriteString(fmt.Sprintf("*Exported from my-context %s*\n", versionLabel()))
 
 	return sb.String()
 }
 
+// writeMarkdownNotes writes notes as a list, plain notes first and then one
+// titled list per note type. Titles are headings at the given level ("###"),
+// or bold when heading is empty. Todos are written as task list items.
+func writeMarkdownNotes(sb *strings.Builder, notes []models.Note, heading string) {
+	for i, group := range GroupNotes(notes) {
+		if group.Title != "" {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			if heading != "" {
+				sb.WriteString(fmt.Sprintf("%s %s\n\n", heading, group.Title))
+			} else {
+				sb.WriteString(fmt.Sprintf("**%s**\n\n", group.Title))
+			}
+		}
+		for _, note := range group.Notes {
+			box := ""
+			if note.Type == models.NoteTypeTodo {
+				box = "[ ] "
+				if note.Done {
+					box = "[x] "
+				}
+			}
+			sb.WriteString(fmt.Sprintf("- %s**%s** %s\n", box, formatLocalTime(note.Timestamp), note.TextContent))
+		}
+	}
+}
+
+// versionLabel returns Version with a leading "v", as in "v3.1.0"
+func versionLabel() string {
+	return "v" + strings.TrimPrefix(Version, "v")
+}
+
 // formatLocalTime converts UTC time to local timezone and formats human-readable
 func formatLocalTime(t time.Time) string {
 	local := t.Local()
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// orgExporter writes an Emacs Org-mode document
+type orgExporter struct{}
+
+func (orgExporter) Name() string      { return "org" }
+func (orgExporter) Extension() string { return ".org" }
+
+func (orgExporter) Render(docs []*ExportDocument) (string, error) {
+	var sb strings.Builder
+
+	if len(docs) == 1 {
+		sb.WriteString(fmt.Sprintf("#+TITLE: Context: %s\n", docs[0].Context.Name))
+		sb.WriteString("#+OPTIONS: toc:nil\n")
+	} else {
+		// Org builds the table of contents from the headings on export
+		sb.WriteString("#+TITLE: my-context export\n")
+		sb.WriteString("#+OPTIONS: toc:1\n")
+	}
+	sb.WriteString(fmt.Sprintf("#+DATE: %s\n\n", orgTimestamp(time.Now())))
+
+	for _, d := range docs {
+		writeOrgContext(&sb, d, 1)
+	}
+	return sb.String(), nil
+}
+
+// RenderTree nests each child context as a subheading of its parent
+func (orgExporter) RenderTree(root *ExportDocument) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("#+TITLE: Tree: %s\n", root.Context.Name))
+	sb.WriteString("#+OPTIONS: toc:t\n")
+	sb.WriteString(fmt.Sprintf("#+DATE: %s\n\n", orgTimestamp(time.Now())))
+
+	root.Walk(func(d *ExportDocument, depth int) {
+		writeOrgContext(&sb, d, depth+1)
+	})
+	return sb.String(), nil
+}
+
+// writeOrgContext writes a context as a heading at the given level, with its
+// notes, files and activity as subheadings
+func writeOrgContext(sb *strings.Builder, d *ExportDocument, level int) {
+	ctx := d.Context
+	stars := strings.Repeat("*", level)
+
+	sb.WriteString(fmt.Sprintf("%s %s\n", stars, orgEscape(ctx.Name)))
+	sb.WriteString("  :PROPERTIES:\n")
+	sb.WriteString(fmt.Sprintf("  :CUSTOM_ID: %s\n", anchorID(ctx.Name)))
+	sb.WriteString(fmt.Sprintf("  :STARTED:  %s\n", orgTimestamp(ctx.StartTime)))
+	if ctx.EndTime != nil {
+		sb.WriteString(fmt.Sprintf("  :ENDED:    %s\n", orgTimestamp(*ctx.EndTime)))
+	}
+	sb.WriteString(fmt.Sprintf("  :DURATION: %s\n", formatDuration(ctx.Duration())))
+	sb.WriteString(fmt.Sprintf("  :STATUS:   %s\n", ctx.Status))
+	if d.Metadata.State != "" {
+		sb.WriteString(fmt.Sprintf("  :STATE:    %s\n", d.Metadata.State))
+	}
+	if ctx.IsArchived {
+		sb.WriteString("  :ARCHIVED: t\n")
+	}
+	if len(d.Children) > 0 {
+		sb.WriteString(fmt.Sprintf("  :TOTAL_DURATION: %s\n", formatDuration(d.TotalDuration())))
+		sb.WriteString(fmt.Sprintf("  :TOTAL_NOTES: %d\n", d.TotalNotes()))
+	}
+	sb.WriteString("  :END:\n\n")
+
+	sb.WriteString(stars + "* Notes\n")
+	if len(d.Notes) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, group := range d.NoteGroups() {
+		if group.Title != "" {
+			sb.WriteString(fmt.Sprintf("%s** %s\n", stars, group.Title))
+		}
+		for _, note := range group.Notes {
+			box := ""
+			if note.Type == models.NoteTypeTodo {
+				box = "[ ] "
+				if note.Done {
+					box = "[X] "
+				}
+			}
+			text := strings.ReplaceAll(orgEscape(note.TextContent), "\n", "\n  ")
+			sb.WriteString(fmt.Sprintf("- %s%s %s\n", box, orgTimestamp(note.Timestamp), text))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(stars + "* Files\n")
+	if len(d.Files) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, file := range d.Files {
+		sb.WriteString(fmt.Sprintf("- %s [[file:%s]]\n", orgTimestamp(file.Timestamp), file.FilePath))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(stars + "* Activity\n")
+	if len(d.Touches) == 0 {
+		sb.WriteString("(none)\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Total: %d touches\n\n", len(d.Touches)))
+	}
+}
+
+// orgTimestamp formats an inactive Org timestamp in local time, e.g. [2025-10-22 Wed 14:03]
+func orgTimestamp(t time.Time) string {
+	return t.Local().Format("[2006-01-02 Mon 15:04]")
+}
+
+// orgEscape keeps user text from being read as a heading or keyword line
+func orgEscape(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "*") || strings.HasPrefix(line, "#+") {
+			lines[i] = "," + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
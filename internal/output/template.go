@@ -0,0 +1,177 @@
+package output
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// TemplateDir is the directory in the context home searched for user templates
+const TemplateDir = "templates"
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// TemplateInfo describes an export template that export --template can use
+type TemplateInfo struct {
+	Name   string `json:"name"`
+	Source string `json:"source"` // "user" or "builtin"
+	Path   string `json:"path"`
+}
+
+// TemplateData is what an export template is executed with: the context's
+// ExportDocument plus details about the export itself
+type TemplateData struct {
+	*ExportDocument
+	ExportedAt time.Time
+	Version    string
+}
+
+// templateExporter renders contexts with a user-defined text/template
+type templateExporter struct {
+	name string
+	ext  string
+	tmpl *template.Template
+}
+
+func (e *templateExporter) Name() string      { return "template:" + e.name }
+func (e *templateExporter) Extension() string { return e.ext }
+
+// Render executes the template once per context; a combined export concatenates the results
+func (e *templateExporter) Render(docs []*ExportDocument) (string, error) {
+	var sb strings.Builder
+	for i, d := range docs {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		data := TemplateData{ExportDocument: d, ExportedAt: time.Now(), Version: versionLabel()}
+		if err := e.tmpl.Execute(&sb, data); err != nil {
+			return "", fmt.Errorf("template %s: %w", e.name, err)
+		}
+	}
+	return sb.String(), nil
+}
+
+// RenderTree executes the template once with the root; templates reach the
+// rest of the tree through .Children
+func (e *templateExporter) RenderTree(root *ExportDocument) (string, error) {
+	return e.Render([]*ExportDocument{root})
+}
+
+// LoadTemplate returns an exporter for a template given as a file path, or as the
+// name of a template in <home>/templates/ or one of the built-in templates
+func LoadTemplate(home, nameOrPath string) (Exporter, error) {
+	if info, err := os.Stat(nameOrPath); err == nil && !info.IsDir() {
+		return parseTemplateFile(nameOrPath, os.ReadFile)
+	}
+
+	for _, t := range ListTemplates(home) {
+		if t.Name == nameOrPath {
+			if t.Source == "builtin" {
+				return parseTemplateFile(t.Path, builtinTemplates.ReadFile)
+			}
+			return parseTemplateFile(t.Path, os.ReadFile)
+		}
+	}
+
+	return nil, fmt.Errorf("template %q not found (see 'my-context export --list-templates')", nameOrPath)
+}
+
+// ListTemplates returns the user templates in <home>/templates/ followed by the
+// built-in templates they don't override
+func ListTemplates(home string) []TemplateInfo {
+	var templates []TemplateInfo
+	seen := make(map[string]bool)
+
+	userDir := filepath.Join(home, TemplateDir)
+	if entries, err := os.ReadDir(userDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmpl") {
+				continue
+			}
+			name, _ := templateName(entry.Name())
+			templates = append(templates, TemplateInfo{Name: name, Source: "user", Path: filepath.Join(userDir, entry.Name())})
+			seen[name] = true
+		}
+	}
+
+	entries, _ := builtinTemplates.ReadDir("templates")
+	for _, entry := range entries {
+		name, _ := templateName(entry.Name())
+		if seen[name] {
+			continue
+		}
+		templates = append(templates, TemplateInfo{Name: name, Source: "builtin", Path: "templates/" + entry.Name()})
+	}
+
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates
+}
+
+func parseTemplateFile(path string, read func(string) ([]byte, error)) (Exporter, error) {
+	content, err := read(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	name, ext := templateName(filepath.Base(path))
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	return &templateExporter{name: name, ext: ext, tmpl: tmpl}, nil
+}
+
+// templateName splits a template file name such as "standup.md.tmpl" into its
+// name ("standup") and the extension of the files it produces (".md")
+func templateName(filename string) (string, string) {
+	base := strings.TrimSuffix(filename, ".tmpl")
+	ext := filepath.Ext(base)
+	if ext == "" {
+		return base, ".txt"
+	}
+	return strings.TrimSuffix(base, ext), ext
+}
+
+// templateFuncs are the functions available to export templates
+var templateFuncs = template.FuncMap{
+	"duration": formatDuration,
+	"time":     formatLocalTime,
+	"date":     func(t time.Time) string { return t.Local().Format("2006-01-02") },
+	"join":     strings.Join,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"since": func(window string, notes []models.Note) ([]models.Note, error) {
+		d, err := time.ParseDuration(window)
+		if err != nil {
+			return nil, err
+		}
+		cutoff := time.Now().Add(-d)
+		var recent []models.Note
+		for _, note := range notes {
+			if note.Timestamp.After(cutoff) {
+				recent = append(recent, note)
+			}
+		}
+		return recent, nil
+	},
+	"ofType": func(noteType string, notes []models.Note) []models.Note {
+		var typed []models.Note
+		for _, note := range notes {
+			if note.Type == noteType {
+				typed = append(typed, note)
+			}
+		}
+		return typed
+	},
+}
@@ -0,0 +1,193 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// TimelineRow is one context in the timeline chart
+type TimelineRow struct {
+	Context  string           `json:"context"`
+	Sessions []models.Session `json:"sessions"` // Clipped to the chart window
+	Notes    []models.Note    `json:"notes"`
+}
+
+// TimeSpent returns the total length of the row's sessions
+func (r TimelineRow) TimeSpent() time.Duration {
+	var total time.Duration
+	for _, s := range r.Sessions {
+		total += s.Duration()
+	}
+	return total
+}
+
+// TimelineOptions controls how the timeline chart is drawn
+type TimelineOptions struct {
+	Width int  // Total width in columns
+	Color bool // Color bars with ANSI escape codes
+	ASCII bool // Draw with plain ASCII instead of box-drawing characters
+}
+
+// timelineGlyphs are the characters a chart is drawn with
+type timelineGlyphs struct {
+	bar, note, rule, axis, ellipsis, dash, approx string
+}
+
+var (
+	unicodeGlyphs = timelineGlyphs{bar: "█", note: "◆", rule: "│", axis: "┴", ellipsis: "…", dash: "–", approx: "≈"}
+	asciiGlyphs   = timelineGlyphs{bar: "#", note: "*", rule: "|", axis: "+", ellipsis: "~", dash: "-", approx: "~"}
+)
+
+// timelineColors are the ANSI colors rows cycle through
+var timelineColors = []string{"36", "32", "33", "35", "34", "31"}
+
+const (
+	timelineMinLabel = 8
+	timelineMaxLabel = 28
+	timelineMinChart = 10
+)
+
+// FormatTimeline renders a Gantt-style chart of the window [from, to): one row
+// per context with bars for its sessions and markers for its notes, above a
+// time axis and a legend
+func FormatTimeline(rows []TimelineRow, from, to time.Time, opts TimelineOptions) string {
+	g := unicodeGlyphs
+	if opts.ASCII {
+		g = asciiGlyphs
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Timeline: %s %s %s\n\n", from.Local().Format("Mon Jan 2 15:04"), g.dash, to.Local().Format("Mon Jan 2 15:04")))
+	if len(rows) == 0 {
+		sb.WriteString("No activity in this period.\n")
+		return sb.String()
+	}
+
+	// Layout: label, space, rule, chart cells, rule, space, total
+	labelWidth := timelineMinLabel
+	for _, r := range rows {
+		if n := utf8.RuneCountInString(r.Context); n > labelWidth {
+			labelWidth = n
+		}
+	}
+	if labelWidth > timelineMaxLabel {
+		labelWidth = timelineMaxLabel
+	}
+	const totalWidth = 8
+	cells := opts.Width - labelWidth - totalWidth - 4
+	if cells < timelineMinChart {
+		cells = timelineMinChart
+	}
+	span := to.Sub(from)
+	if span <= 0 {
+		span = time.Minute
+	}
+	cellSpan := span / time.Duration(cells)
+	cellOf := func(t time.Time) int {
+		c := int(t.Sub(from) / cellSpan)
+		if c < 0 {
+			c = 0
+		}
+		if c >= cells {
+			c = cells - 1
+		}
+		return c
+	}
+
+	for i, r := range rows {
+		line := make([]string, cells)
+		for c := range line {
+			line[c] = " "
+		}
+		for _, s := range r.Sessions {
+			// A session shorter than a cell still gets one; one ending on a
+			// cell boundary doesn't spill into the next
+			last := cellOf(s.End)
+			if s.End.After(s.Start) {
+				last = cellOf(s.End.Add(-time.Nanosecond))
+			}
+			for c := cellOf(s.Start); c <= last; c++ {
+				line[c] = g.bar
+			}
+		}
+		for _, n := range r.Notes {
+			if !n.Timestamp.Before(from) && n.Timestamp.Before(to) {
+				line[cellOf(n.Timestamp)] = g.note
+			}
+		}
+
+		chart := strings.Join(line, "")
+		if opts.Color {
+			color := timelineColors[i%len(timelineColors)]
+			chart = "\033[" + color + "m" + chart + "\033[0m"
+			if strings.Contains(chart, g.note) {
+				chart = strings.ReplaceAll(chart, g.note, "\033[1;37m"+g.note+"\033["+color+"m")
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s%s%s %*s\n",
+			padLabel(r.Context, labelWidth, g.ellipsis), g.rule, chart, g.rule,
+			totalWidth, FormatDuration(r.TimeSpent())))
+	}
+
+	// Axis with tick labels that don't overlap
+	layout, step := timelineTicks(span, cells)
+	axis := []rune(strings.Repeat(" ", cells))
+	labels := []rune(strings.Repeat(" ", cells+len(layout)+1))
+	// Ticks fall on local clock boundaries, counted from midnight
+	local := from.Local()
+	tick := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	next := 0 // First cell free for a label
+	for ; tick.Before(to); tick = tick.Add(step) {
+		if tick.Before(from) {
+			continue
+		}
+		c := cellOf(tick)
+		if c < next {
+			continue
+		}
+		label := []rune(tick.Local().Format(layout))
+		axis[c] = []rune(g.axis)[0]
+		copy(labels[c:], label)
+		next = c + len(label) + 1
+	}
+	indent := strings.Repeat(" ", labelWidth+1)
+	sb.WriteString(fmt.Sprintf("%s%s%s%s\n", indent, g.rule, string(axis), g.rule))
+	sb.WriteString(fmt.Sprintf("%s %s\n", indent, strings.TrimRight(string(labels), " ")))
+
+	sb.WriteString(fmt.Sprintf("\n%s active  %s note  (one column %s %s)\n", g.bar, g.note, g.approx, FormatDuration(cellSpan)))
+	return sb.String()
+}
+
+// timelineTicks picks the label layout and the smallest tick step whose labels
+// fit side by side in the chart
+func timelineTicks(span time.Duration, cells int) (string, time.Duration) {
+	steps := []time.Duration{
+		15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour, 3 * time.Hour,
+		6 * time.Hour, 12 * time.Hour, 24 * time.Hour, 48 * time.Hour, 7 * 24 * time.Hour,
+	}
+	for _, step := range steps {
+		layout := "15:04"
+		if step >= 24*time.Hour {
+			layout = "Mon 2"
+		}
+		ticks := int(span/step) + 1
+		if ticks*(len(layout)+2) <= cells {
+			return layout, step
+		}
+	}
+	return "Jan 2", 30 * 24 * time.Hour
+}
+
+// padLabel fits a context name into width columns, shortening it with an ellipsis
+func padLabel(name string, width int, ellipsis string) string {
+	runes := []rune(name)
+	if len(runes) > width {
+		return string(runes[:width-1]) + ellipsis
+	}
+	return name + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,95 @@
+package signal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// auditLogName is the append-only record of signal events, kept in the signals directory
+const auditLogName = "signals.log"
+
+// AuditLogPath returns the path to the audit log of a signals directory
+func AuditLogPath(signalsDir string) string {
+	return filepath.Join(signalsDir, auditLogName)
+}
+
+// recordEvent appends an event for a signal to the audit log, one JSON object per line.
+// The acting user defaults to the signal's sender for creates and $USER otherwise.
+func (m *Manager) recordEvent(event string, signal *models.Signal) error {
+	actor := os.Getenv("USER")
+	if event == models.SignalEventCreate && signal.Sender != "" {
+		actor = signal.Sender
+	}
+
+	entry := models.SignalEvent{
+		Timestamp:     time.Now(),
+		Event:         event,
+		Signal:        signal.Name,
+		Actor:         actor,
+		SignalPayload: signal.SignalPayload,
+	}
+
+	line, err := json.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(AuditLogPath(m.signalsDir), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to record signal %s: %w", event, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record signal %s: %w", event, err)
+	}
+	return nil
+}
+
+// History returns recorded signal events, oldest first.
+// A non-empty pattern keeps only events for signals matching it (globs allowed).
+func (m *Manager) History(pattern string) ([]models.SignalEvent, error) {
+	return ReadAuditLog(AuditLogPath(m.signalsDir), pattern)
+}
+
+// ReadAuditLog reads a signal audit log, skipping lines it can't parse.
+// A missing log means no events have been recorded yet.
+func ReadAuditLog(path, pattern string) ([]models.SignalEvent, error) {
+	pattern = normalizePattern(pattern)
+	events := []models.SignalEvent{}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return events, nil
+		}
+		return nil, fmt.Errorf("failed to read signal history: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event models.SignalEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue // Skip malformed lines
+		}
+		if pattern != "" {
+			if ok, _ := filepath.Match(pattern, event.Signal); !ok {
+				continue
+			}
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read signal history: %w", err)
+	}
+
+	return events, nil
+}
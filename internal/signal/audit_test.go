@@ -0,0 +1,73 @@
+package signal
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryRecordsSignalLifecycle(t *testing.T) {
+	manager, err := NewManager(setupTestSignalsDir(t))
+	require.NoError(t, err)
+
+	_, err = manager.CreateSignalWithPayload("deploy", models.SignalPayload{Message: "v2.4.0", Sender: "ci"}, 0)
+	require.NoError(t, err)
+	_, err = manager.CreateSignal("review")
+	require.NoError(t, err)
+	_, err = manager.CreateSignalWithPayload("nightly", models.SignalPayload{}, time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	fired, err := manager.TrySignals([]string{"deploy"}, false, true)
+	require.NoError(t, err)
+	require.Len(t, fired, 1)
+	require.NoError(t, manager.ClearSignal("review"))
+	_, err = manager.RemoveExpiredSignals()
+	require.NoError(t, err)
+
+	events, err := manager.History("")
+	require.NoError(t, err)
+	var got []string
+	for _, event := range events {
+		got = append(got, event.Event+" "+event.Signal)
+	}
+	assert.Equal(t, []string{
+		"create deploy", "create review", "create nightly",
+		"consume deploy", "clear review", "expire nightly",
+	}, got)
+
+	// Creates are attributed to the sender and the payload survives removal
+	assert.Equal(t, "ci", events[0].Actor)
+	assert.Equal(t, "v2.4.0", events[3].Message)
+
+	deployOnly, err := manager.History("dep*")
+	require.NoError(t, err)
+	assert.Len(t, deployOnly, 2)
+}
+
+func TestReadAuditLogSkipsMalformedLines(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	_, err = manager.CreateSignal("ok")
+	require.NoError(t, err)
+
+	f, err := os.OpenFile(AuditLogPath(signalsDir), os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString("not json\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	events, err := ReadAuditLog(AuditLogPath(signalsDir), "")
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	missing, err := ReadAuditLog(AuditLogPath(t.TempDir()), "")
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
@@ -2,7 +2,10 @@ package signal
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,18 +32,32 @@ func NewManager(signalsDir string) (*Manager, error) {
 
 // CreateSignal creates a new signal file
 func (m *Manager) CreateSignal(name string) (*models.Signal, error) {
+	return m.CreateSignalWithPayload(name, models.SignalPayload{}, 0)
+}
+
+// CreateSignalWithPayload creates a signal carrying a payload.
+// A ttl of zero means the signal never expires; an expired signal may be created again.
+func (m *Manager) CreateSignalWithPayload(name string, payload models.SignalPayload, ttl time.Duration) (*models.Signal, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	signal := models.NewSignal(name, m.signalsDir)
+	signal.SignalPayload = payload
+	if ttl > 0 {
+		expiresAt := signal.CreatedAt.Add(ttl)
+		signal.ExpiresAt = &expiresAt
+	}
 
-	if signal.Exists() {
+	if existing, err := models.LoadSignalFromFile(signal.Path); err == nil && !existing.IsExpired(time.Now()) {
 		return nil, fmt.Errorf("signal '%s' already exists", name)
 	}
 
 	if err := signal.Create(); err != nil {
 		return nil, fmt.Errorf("failed to create signal: %w", err)
 	}
+	if err := m.recordEvent(models.SignalEventCreate, signal); err != nil {
+		return nil, err
+	}
 
 	return signal, nil
 }
@@ -57,11 +74,28 @@ func (m *Manager) GetSignal(name string) (*models.Signal, error) {
 	}
 
 	// Load actual file information
-	return models.LoadSignalFromFile(signal.Path)
+	loaded, err := models.LoadSignalFromFile(signal.Path)
+	if err != nil {
+		return nil, err
+	}
+	if loaded.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("signal '%s' has expired", name)
+	}
+
+	return loaded, nil
 }
 
-// ListSignals returns all existing signals
+// ListSignals returns all existing signals, hiding expired ones
 func (m *Manager) ListSignals() ([]models.SignalInfo, error) {
+	return m.listSignals(false)
+}
+
+// ListAllSignals returns all existing signals, including expired ones
+func (m *Manager) ListAllSignals() ([]models.SignalInfo, error) {
+	return m.listSignals(true)
+}
+
+func (m *Manager) listSignals(includeExpired bool) ([]models.SignalInfo, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -70,6 +104,7 @@ func (m *Manager) ListSignals() ([]models.SignalInfo, error) {
 		return nil, fmt.Errorf("failed to list signal files: %w", err)
 	}
 
+	now := time.Now()
 	signals := make([]models.SignalInfo, 0, len(files))
 	for _, file := range files {
 		signal, err := models.LoadSignalFromFile(file)
@@ -77,12 +112,44 @@ func (m *Manager) ListSignals() ([]models.SignalInfo, error) {
 			// Log error but continue with other signals
 			continue
 		}
+		if !includeExpired && signal.IsExpired(now) {
+			continue
+		}
 		signals = append(signals, signal.ToInfo())
 	}
 
 	return signals, nil
 }
 
+// RemoveExpiredSignals deletes signals whose TTL has passed and returns them
+func (m *Manager) RemoveExpiredSignals() ([]models.SignalInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	files, err := utils.ListFiles(m.signalsDir, "*.signal")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signal files: %w", err)
+	}
+
+	now := time.Now()
+	removed := make([]models.SignalInfo, 0)
+	for _, file := range files {
+		signal, err := models.LoadSignalFromFile(file)
+		if err != nil || !signal.IsExpired(now) {
+			continue
+		}
+		if err := signal.Remove(); err != nil {
+			return removed, fmt.Errorf("failed to remove signal %s: %w", signal.Name, err)
+		}
+		if err := m.recordEvent(models.SignalEventExpire, signal); err != nil {
+			return removed, err
+		}
+		removed = append(removed, signal.ToInfo())
+	}
+
+	return removed, nil
+}
+
 // WaitForSignal blocks until a signal appears or timeout expires
 func (m *Manager) WaitForSignal(name string, timeout time.Duration) error {
 	m.mu.RLock()
@@ -96,11 +163,7 @@ func (m *Manager) WaitForSignal(name string, timeout time.Duration) error {
 	for {
 		select {
 		case <-ticker.C:
-			m.mu.RLock()
-			exists := signal.Exists()
-			m.mu.RUnlock()
-
-			if exists {
+			if m.SignalExists(signal.Name) {
 				return nil
 			}
 
@@ -114,6 +177,190 @@ func (m *Manager) WaitForSignal(name string, timeout time.Duration) error {
 	}
 }
 
+// ValidatePattern checks that a signal name or glob pattern is well formed
+func ValidatePattern(pattern string) error {
+	if _, err := filepath.Match(normalizePattern(pattern), ""); err != nil {
+		return fmt.Errorf("invalid signal pattern '%s': %w", pattern, err)
+	}
+	return nil
+}
+
+// normalizePattern strips a trailing .signal so "binary-updated*.signal" and "binary-updated*" match alike
+func normalizePattern(pattern string) string {
+	return strings.TrimSuffix(pattern, ".signal")
+}
+
+// MatchSignals returns unexpired signals whose name matches a glob pattern, oldest first
+func (m *Manager) MatchSignals(pattern string) ([]*models.Signal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pattern = normalizePattern(pattern)
+	files, err := utils.ListFiles(m.signalsDir, "*.signal")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signal files: %w", err)
+	}
+
+	now := time.Now()
+	var matches []*models.Signal
+	for _, file := range files {
+		signal, err := models.LoadSignalFromFile(file)
+		if err != nil || signal.IsExpired(now) {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, signal.Name); ok {
+			matches = append(matches, signal)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+
+	return matches, nil
+}
+
+// TrySignals checks whether the patterns are satisfied right now.
+// With requireAll every pattern needs a match, otherwise one is enough.
+// With consume the firing signals are claimed and removed atomically, so two
+// waiters never both act on the same signal. Returns nil when not satisfied.
+func (m *Manager) TrySignals(patterns []string, requireAll, consume bool) ([]*models.Signal, error) {
+	matches := make([][]*models.Signal, len(patterns))
+	for i, pattern := range patterns {
+		found, err := m.MatchSignals(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if requireAll && len(found) == 0 {
+			return nil, nil
+		}
+		matches[i] = found
+	}
+
+	if !consume {
+		return dedupeSignals(matches...), nil
+	}
+
+	if !requireAll {
+		// Claim the oldest signal we can win; losing a race just means trying the next
+		for _, signal := range dedupeSignals(matches...) {
+			claim, err := m.claimSignal(signal)
+			if err != nil {
+				continue
+			}
+			if err := claim.finalize(m); err != nil {
+				return nil, err
+			}
+			return []*models.Signal{signal}, nil
+		}
+		return nil, nil
+	}
+
+	// Claim one signal per pattern; if any pattern can't be claimed, put the others back
+	var claims []*signalClaim
+	claimed := make(map[string]bool)
+	for _, found := range matches {
+		var won *signalClaim
+		for _, signal := range found {
+			if claimed[signal.Name] {
+				won = &signalClaim{signal: signal} // Already claimed for an earlier pattern
+				break
+			}
+			if claim, err := m.claimSignal(signal); err == nil {
+				won = claim
+				claims = append(claims, claim)
+				claimed[signal.Name] = true
+				break
+			}
+		}
+		if won == nil {
+			for _, claim := range claims {
+				claim.release()
+			}
+			return nil, nil
+		}
+	}
+
+	fired := make([]*models.Signal, 0, len(claims))
+	for _, claim := range claims {
+		if err := claim.finalize(m); err != nil {
+			return nil, err
+		}
+		fired = append(fired, claim.signal)
+	}
+	return fired, nil
+}
+
+// WaitForSignals blocks until TrySignals is satisfied or the timeout expires
+func (m *Manager) WaitForSignals(patterns []string, requireAll, consume bool, timeout time.Duration) ([]*models.Signal, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(500 * time.Millisecond) // Check every 500ms
+	defer ticker.Stop()
+
+	for {
+		fired, err := m.TrySignals(patterns, requireAll, consume)
+		if err != nil {
+			return nil, err
+		}
+		if len(fired) > 0 {
+			return fired, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for signal '%s'", strings.Join(patterns, "', '"))
+		}
+		<-ticker.C
+	}
+}
+
+// signalClaim is a signal file renamed out of the way by one waiter
+type signalClaim struct {
+	signal    *models.Signal
+	claimPath string
+}
+
+// claimSignal atomically moves a signal aside; only one caller can win the rename
+func (m *Manager) claimSignal(signal *models.Signal) (*signalClaim, error) {
+	claimPath := filepath.Join(m.signalsDir, fmt.Sprintf(".%s.claimed-%d-%d", signal.Name, os.Getpid(), time.Now().UnixNano()))
+	if err := os.Rename(signal.Path, claimPath); err != nil {
+		return nil, fmt.Errorf("signal '%s' was consumed by another waiter", signal.Name)
+	}
+	return &signalClaim{signal: signal, claimPath: claimPath}, nil
+}
+
+// release puts a claimed signal back
+func (c *signalClaim) release() {
+	if c.claimPath != "" {
+		_ = os.Rename(c.claimPath, c.signal.Path)
+	}
+}
+
+// finalize removes a claimed signal for good and records that it was consumed
+func (c *signalClaim) finalize(m *Manager) error {
+	if c.claimPath == "" {
+		return nil
+	}
+	if err := os.Remove(c.claimPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove consumed signal '%s': %w", c.signal.Name, err)
+	}
+	return m.recordEvent(models.SignalEventConsume, c.signal)
+}
+
+// dedupeSignals flattens match lists, keeping the first occurrence of each name
+func dedupeSignals(lists ...[]*models.Signal) []*models.Signal {
+	seen := make(map[string]bool)
+	var signals []*models.Signal
+	for _, list := range lists {
+		for _, signal := range list {
+			if !seen[signal.Name] {
+				seen[signal.Name] = true
+				signals = append(signals, signal)
+			}
+		}
+	}
+	return signals
+}
+
 // ClearSignal removes a signal file
 func (m *Manager) ClearSignal(name string) error {
 	m.mu.Lock()
@@ -125,11 +372,16 @@ func (m *Manager) ClearSignal(name string) error {
 		return fmt.Errorf("signal '%s' does not exist", name)
 	}
 
+	// Load the payload first so the audit log keeps what the signal carried
+	if loaded, err := models.LoadSignalFromFile(signal.Path); err == nil {
+		signal = loaded
+	}
+
 	if err := signal.Remove(); err != nil {
 		return fmt.Errorf("failed to remove signal: %w", err)
 	}
 
-	return nil
+	return m.recordEvent(models.SignalEventClear, signal)
 }
 
 // ClearAllSignals removes all signal files (useful for cleanup)
@@ -151,16 +403,24 @@ func (m *Manager) ClearAllSignals() error {
 		if err := signal.Remove(); err != nil {
 			return fmt.Errorf("failed to remove signal %s: %w", filepath.Base(file), err)
 		}
+		if err := m.recordEvent(models.SignalEventClear, signal); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// SignalExists checks if a signal exists without loading full details
+// SignalExists checks if a signal exists and has not expired
 func (m *Manager) SignalExists(name string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	signal := models.NewSignal(name, m.signalsDir)
-	return signal.Exists()
+	if !signal.Exists() {
+		return false
+	}
+
+	loaded, err := models.LoadSignalFromFile(signal.Path)
+	return err == nil && !loaded.IsExpired(time.Now())
 }
@@ -1,10 +1,13 @@
 package signal
 
 import (
+	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -217,3 +220,153 @@ func TestClearAllSignals(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, signals, 0)
 }
+
+func TestSignalPayloadRoundTrip(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	payload := models.SignalPayload{
+		Message: "binary updated to bdac191",
+		Sender:  "deb-team",
+		Context: "ps-cli: Phase 1",
+		Data:    map[string]string{"commit": "bdac191"},
+	}
+	_, err = manager.CreateSignalWithPayload("binary-updated", payload, 0)
+	require.NoError(t, err)
+
+	signal, err := manager.GetSignal("binary-updated")
+	require.NoError(t, err)
+	assert.Equal(t, payload, signal.SignalPayload)
+	assert.Nil(t, signal.ExpiresAt)
+}
+
+func TestSignalTTL(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	_, err = manager.CreateSignalWithPayload("short-lived", models.SignalPayload{}, 50*time.Millisecond)
+	require.NoError(t, err)
+	_, err = manager.CreateSignal("forever")
+	require.NoError(t, err)
+	assert.True(t, manager.SignalExists("short-lived"))
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Expired signals are hidden but still on disk until gc
+	assert.False(t, manager.SignalExists("short-lived"))
+	signals, err := manager.ListSignals()
+	require.NoError(t, err)
+	assert.Len(t, signals, 1)
+	all, err := manager.ListAllSignals()
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	removed, err := manager.RemoveExpiredSignals()
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "short-lived", removed[0].Name)
+	assert.NoFileExists(t, filepath.Join(signalsDir, "short-lived.signal"))
+
+	// An expired name can be reused
+	_, err = manager.CreateSignalWithPayload("short-lived", models.SignalPayload{}, time.Hour)
+	assert.NoError(t, err)
+}
+
+func TestLegacySignalFile(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	// Older versions wrote only a timestamp
+	legacyPath := filepath.Join(signalsDir, "legacy.signal")
+	require.NoError(t, os.WriteFile(legacyPath, []byte(time.Now().Format(time.RFC3339)+"\n"), 0o600))
+
+	signal, err := manager.GetSignal("legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", signal.Name)
+	assert.True(t, signal.SignalPayload.IsEmpty())
+	assert.False(t, signal.CreatedAt.IsZero())
+}
+
+func TestTrySignalsGlobAnyAll(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	_, err = manager.CreateSignal("binary-updated-amd64")
+	require.NoError(t, err)
+
+	// Glob with or without the .signal suffix
+	fired, err := manager.TrySignals([]string{"binary-updated*.signal"}, false, false)
+	require.NoError(t, err)
+	require.Len(t, fired, 1)
+	assert.Equal(t, "binary-updated-amd64", fired[0].Name)
+
+	// --any is satisfied by one of several names, --all is not
+	fired, err = manager.TrySignals([]string{"binary-updated*", "tests-passed"}, false, false)
+	require.NoError(t, err)
+	assert.Len(t, fired, 1)
+	fired, err = manager.TrySignals([]string{"binary-updated*", "tests-passed"}, true, false)
+	require.NoError(t, err)
+	assert.Empty(t, fired)
+
+	_, err = manager.CreateSignal("tests-passed")
+	require.NoError(t, err)
+	fired, err = manager.TrySignals([]string{"binary-updated*", "tests-passed"}, true, true)
+	require.NoError(t, err)
+	assert.Len(t, fired, 2)
+
+	// Consumed signals are gone
+	assert.False(t, manager.SignalExists("binary-updated-amd64"))
+	assert.False(t, manager.SignalExists("tests-passed"))
+}
+
+func TestTrySignalsAllRestoresPartialClaims(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	_, err = manager.CreateSignal("ready")
+	require.NoError(t, err)
+
+	// Second pattern has no match, so "ready" must not be consumed
+	fired, err := manager.TrySignals([]string{"ready", "missing"}, true, true)
+	require.NoError(t, err)
+	assert.Empty(t, fired)
+	assert.True(t, manager.SignalExists("ready"))
+}
+
+func TestConsumeDeliversToOneWaiter(t *testing.T) {
+	signalsDir := setupTestSignalsDir(t)
+	manager, err := NewManager(signalsDir)
+	require.NoError(t, err)
+
+	_, err = manager.CreateSignal("job")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	winners := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fired, err := manager.TrySignals([]string{"job"}, false, true)
+			if err == nil && len(fired) == 1 {
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, winners)
+}
+
+func TestValidatePattern(t *testing.T) {
+	assert.NoError(t, ValidatePattern("binary-updated*"))
+	assert.Error(t, ValidatePattern("bad[pattern"))
+}
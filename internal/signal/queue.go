@@ -0,0 +1,456 @@
+package signal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
+	"github.com/jefferycaldwell/my-context-copilot/pkg/utils"
+)
+
+// Queues live next to signal files:
+//
+//	queues/<queue>/<id>.msg            one JSON message per file, IDs increase from 1
+//	queues/<queue>/consumers/<name>.json  each consumer's acknowledged cursor, written on its first receive
+//	queues/<queue>/pruned              highest message ID removed by PruneQueues
+//
+// Each message gets its ID by hard-linking a fully written temp file to the
+// next free name, so concurrent senders never share an ID and readers never
+// see a partial message.
+const (
+	queuesDirName    = "queues"
+	consumersDirName = "consumers"
+	prunedFileName   = "pruned"
+	messageExt       = ".msg"
+)
+
+// validateQueueName checks that a queue or consumer name is safe to use as a file name
+func validateQueueName(kind, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%s name cannot be empty", kind)
+	}
+	if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid %s name '%s': must not contain path separators or start with '.'", kind, name)
+	}
+	return nil
+}
+
+func (m *Manager) queueDir(queue string) string {
+	return filepath.Join(m.signalsDir, queuesDirName, queue)
+}
+
+func (m *Manager) consumerPath(queue, consumer string) string {
+	return filepath.Join(m.queueDir(queue), consumersDirName, consumer+".json")
+}
+
+func messagePath(dir string, id int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%012d%s", id, messageExt))
+}
+
+// SendMessage appends a message to a queue, creating the queue if needed
+func (m *Manager) SendMessage(queue string, payload models.SignalPayload) (*models.QueueMessage, error) {
+	if err := validateQueueName("queue", queue); err != nil {
+		return nil, err
+	}
+
+	dir := m.queueDir(queue)
+	if err := utils.EnsureDir(dir); err != nil {
+		return nil, fmt.Errorf("failed to create queue '%s': %w", queue, err)
+	}
+
+	msg := &models.QueueMessage{
+		Queue:         queue,
+		SentAt:        time.Now(),
+		SignalPayload: payload,
+	}
+
+	tmpFile, err := os.CreateTemp(dir, ".send-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write message: %w", err)
+	}
+	tmpFile.Close()
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	lastID, err := lastMessageID(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the next free ID; losing a race to another sender just means trying the one after
+	for id := lastID + 1; ; id++ {
+		msg.ID = id
+		data, err := json.MarshalIndent(msg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write message: %w", err)
+		}
+
+		if err := os.Link(tmpPath, messagePath(dir, id)); err == nil {
+			return msg, nil
+		} else if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to send message: %w", err)
+		}
+	}
+}
+
+// Receive returns up to limit messages (all when limit is 0) the consumer has not acknowledged, oldest first.
+// Messages stay pending until acknowledged, so receiving again redelivers them. The first receive
+// registers the consumer, so PruneQueues keeps messages for it from then on.
+func (m *Manager) Receive(queue, consumer string, limit int) ([]*models.QueueMessage, error) {
+	if err := validateQueueName("queue", queue); err != nil {
+		return nil, err
+	}
+	if err := validateQueueName("consumer", consumer); err != nil {
+		return nil, err
+	}
+
+	cursor, err := m.loadConsumer(queue, consumer)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.FileExists(m.consumerPath(queue, consumer)) {
+		cursor.UpdatedAt = time.Now()
+		if err := m.saveConsumer(queue, cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	ids, err := messageIDs(m.queueDir(queue))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*models.QueueMessage
+	for _, id := range ids {
+		if id <= cursor.Acked {
+			continue
+		}
+		if limit > 0 && len(messages) >= limit {
+			break
+		}
+		msg, err := loadMessage(m.queueDir(queue), id)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue // Pruned while we were reading
+			}
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// WaitForMessages blocks until the consumer has unacknowledged messages or the timeout expires
+func (m *Manager) WaitForMessages(queue, consumer string, limit int, timeout time.Duration) ([]*models.QueueMessage, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(500 * time.Millisecond) // Check every 500ms
+	defer ticker.Stop()
+
+	for {
+		messages, err := m.Receive(queue, consumer, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) > 0 {
+			return messages, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout waiting for messages on queue '%s'", queue)
+		}
+		<-ticker.C
+	}
+}
+
+// Ack acknowledges every message up to and including id for the consumer.
+// Acknowledging an ID at or below the cursor is a no-op.
+func (m *Manager) Ack(queue, consumer string, id int64) (*models.QueueConsumer, error) {
+	if err := validateQueueName("queue", queue); err != nil {
+		return nil, err
+	}
+	if err := validateQueueName("consumer", consumer); err != nil {
+		return nil, err
+	}
+	if id < 1 {
+		return nil, fmt.Errorf("invalid message ID %d", id)
+	}
+
+	dir := m.queueDir(queue)
+	if !utils.IsDir(dir) {
+		return nil, fmt.Errorf("queue '%s' does not exist", queue)
+	}
+	lastID, err := lastMessageID(dir)
+	if err != nil {
+		return nil, err
+	}
+	if id > lastID {
+		return nil, fmt.Errorf("message %d has not been sent to queue '%s' (last is %d)", id, queue, lastID)
+	}
+
+	cursor, err := m.loadConsumer(queue, consumer)
+	if err != nil {
+		return nil, err
+	}
+	if id <= cursor.Acked {
+		return cursor, nil
+	}
+
+	cursor.Acked = id
+	cursor.UpdatedAt = time.Now()
+	if err := m.saveConsumer(queue, cursor); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// ListQueues summarizes every queue with its consumers' positions
+func (m *Manager) ListQueues() ([]models.QueueInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(m.signalsDir, queuesDirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []models.QueueInfo{}, nil
+		}
+		return nil, fmt.Errorf("failed to list queues: %w", err)
+	}
+
+	queues := []models.QueueInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := m.queueInfo(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		queues = append(queues, *info)
+	}
+
+	return queues, nil
+}
+
+func (m *Manager) queueInfo(queue string) (*models.QueueInfo, error) {
+	dir := m.queueDir(queue)
+	ids, err := messageIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+	lastID, err := lastMessageID(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	consumers, err := m.loadConsumers(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &models.QueueInfo{
+		Name:      queue,
+		Messages:  len(ids),
+		LastID:    lastID,
+		Consumers: make([]models.QueueConsumerInfo, 0, len(consumers)),
+	}
+	for _, consumer := range consumers {
+		pending := 0
+		for _, id := range ids {
+			if id > consumer.Acked {
+				pending++
+			}
+		}
+		info.Consumers = append(info.Consumers, models.QueueConsumerInfo{
+			Name:    consumer.Name,
+			Acked:   consumer.Acked,
+			Pending: pending,
+		})
+	}
+
+	return info, nil
+}
+
+// PruneQueues removes messages every registered consumer has acknowledged; a consumer
+// that has received but not acknowledged holds everything after its cursor.
+// Queues nobody has consumed from yet are left alone. Returns the number removed.
+func (m *Manager) PruneQueues() (int, error) {
+	queues, err := m.ListQueues()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, queue := range queues {
+		if len(queue.Consumers) == 0 {
+			continue
+		}
+		through := queue.Consumers[0].Acked
+		for _, consumer := range queue.Consumers[1:] {
+			if consumer.Acked < through {
+				through = consumer.Acked
+			}
+		}
+
+		dir := m.queueDir(queue.Name)
+		ids, err := messageIDs(dir)
+		if err != nil {
+			return removed, err
+		}
+
+		// Record the high-water mark first so IDs keep increasing once the files are gone
+		pruned, err := prunedThrough(dir)
+		if err != nil {
+			return removed, err
+		}
+		if through > pruned {
+			if err := utils.SafeWriteFile(filepath.Join(dir, prunedFileName), []byte(strconv.FormatInt(through, 10))); err != nil {
+				return removed, fmt.Errorf("failed to update queue '%s': %w", queue.Name, err)
+			}
+		}
+
+		for _, id := range ids {
+			if id > through {
+				break
+			}
+			if err := os.Remove(messagePath(dir, id)); err != nil && !os.IsNotExist(err) {
+				return removed, fmt.Errorf("failed to remove message %d from queue '%s': %w", id, queue.Name, err)
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// loadConsumer reads a consumer's cursor; an unregistered consumer starts at zero
+func (m *Manager) loadConsumer(queue, consumer string) (*models.QueueConsumer, error) {
+	cursor := &models.QueueConsumer{Name: consumer}
+
+	data, err := os.ReadFile(m.consumerPath(queue, consumer))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cursor, nil
+		}
+		return nil, fmt.Errorf("failed to read consumer '%s': %w", consumer, err)
+	}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("failed to parse consumer '%s': %w", consumer, err)
+	}
+	return cursor, nil
+}
+
+// saveConsumer writes a consumer's cursor, registering the consumer if it is new
+func (m *Manager) saveConsumer(queue string, cursor *models.QueueConsumer) error {
+	data, err := json.MarshalIndent(cursor, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := utils.SafeWriteFile(m.consumerPath(queue, cursor.Name), data); err != nil {
+		return fmt.Errorf("failed to save consumer '%s': %w", cursor.Name, err)
+	}
+	return nil
+}
+
+// loadConsumers returns a queue's consumers sorted by name
+func (m *Manager) loadConsumers(queue string) ([]*models.QueueConsumer, error) {
+	files, err := utils.ListFiles(filepath.Join(m.queueDir(queue), consumersDirName), "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consumers: %w", err)
+	}
+
+	consumers := make([]*models.QueueConsumer, 0, len(files))
+	for _, file := range files {
+		consumer, err := m.loadConsumer(queue, strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	sort.Slice(consumers, func(i, j int) bool {
+		return consumers[i].Name < consumers[j].Name
+	})
+	return consumers, nil
+}
+
+// messageIDs returns the IDs of stored messages in ascending order
+func messageIDs(dir string) ([]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	var ids []int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, messageExt) {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSuffix(name, messageExt), 10, 64)
+		if err != nil {
+			continue // Not a message file
+		}
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// lastMessageID returns the highest ID ever used in a queue, including pruned messages
+func lastMessageID(dir string) (int64, error) {
+	last, err := prunedThrough(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	ids, err := messageIDs(dir)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) > 0 && ids[len(ids)-1] > last {
+		last = ids[len(ids)-1]
+	}
+	return last, nil
+}
+
+// prunedThrough returns the highest message ID removed by PruneQueues
+func prunedThrough(dir string) (int64, error) {
+	data, err := os.ReadFile(filepath.Join(dir, prunedFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("corrupt pruned marker in %s: %w", dir, err)
+	}
+	return id, nil
+}
+
+func loadMessage(dir string, id int64) (*models.QueueMessage, error) {
+	data, err := os.ReadFile(messagePath(dir, id))
+	if err != nil {
+		return nil, err
+	}
+
+	var msg models.QueueMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse message %d: %w", id, err)
+	}
+	return &msg, nil
+}
@@ -0,0 +1,169 @@
+package signal
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueSendReceiveAck(t *testing.T) {
+	manager, err := NewManager(setupTestSignalsDir(t))
+	require.NoError(t, err)
+
+	for _, text := range []string{"one", "two", "three"} {
+		_, err := manager.SendMessage("deb-built", models.SignalPayload{Message: text})
+		require.NoError(t, err)
+	}
+
+	// Receiving without acknowledging redelivers the same message
+	first, err := manager.Receive("deb-built", "deb-sanity", 1)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, int64(1), first[0].ID)
+	assert.Equal(t, "one", first[0].Message)
+
+	again, err := manager.Receive("deb-built", "deb-sanity", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), again[0].ID)
+
+	cursor, err := manager.Ack("deb-built", "deb-sanity", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cursor.Acked)
+
+	rest, err := manager.Receive("deb-built", "deb-sanity", 0)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "three", rest[0].Message)
+
+	// Other consumers keep their own cursor
+	other, err := manager.Receive("deb-built", "release-notes", 0)
+	require.NoError(t, err)
+	assert.Len(t, other, 3)
+
+	// Acks never move a cursor backwards or past the last message
+	cursor, err = manager.Ack("deb-built", "deb-sanity", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cursor.Acked)
+	_, err = manager.Ack("deb-built", "deb-sanity", 4)
+	assert.Error(t, err)
+}
+
+func TestQueuePruneKeepsIDsIncreasing(t *testing.T) {
+	manager, err := NewManager(setupTestSignalsDir(t))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := manager.SendMessage("jobs", models.SignalPayload{})
+		require.NoError(t, err)
+	}
+	_, err = manager.Ack("jobs", "a", 3)
+	require.NoError(t, err)
+	_, err = manager.Ack("jobs", "b", 2)
+	require.NoError(t, err)
+
+	// Only messages every consumer acknowledged are removed
+	removed, err := manager.PruneQueues()
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	queues, err := manager.ListQueues()
+	require.NoError(t, err)
+	require.Len(t, queues, 1)
+	assert.Equal(t, 1, queues[0].Messages)
+	assert.Equal(t, int64(3), queues[0].LastID)
+	assert.Equal(t, 0, queues[0].Consumers[0].Pending)
+	assert.Equal(t, 1, queues[0].Consumers[1].Pending)
+
+	_, err = manager.Ack("jobs", "b", 3)
+	require.NoError(t, err)
+	removed, err = manager.PruneQueues()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	// With every message pruned, the next one still gets a fresh ID
+	msg, err := manager.SendMessage("jobs", models.SignalPayload{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), msg.ID)
+
+	pending, err := manager.Receive("jobs", "a", 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, int64(4), pending[0].ID)
+}
+
+func TestQueuePruneKeepsUnackedMessagesForReceivers(t *testing.T) {
+	manager, err := NewManager(setupTestSignalsDir(t))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err := manager.SendMessage("jobs", models.SignalPayload{})
+		require.NoError(t, err)
+	}
+
+	// slow has received but not acknowledged; fast has acknowledged everything
+	_, err = manager.Receive("jobs", "slow", 0)
+	require.NoError(t, err)
+	_, err = manager.Receive("jobs", "fast", 0)
+	require.NoError(t, err)
+	_, err = manager.Ack("jobs", "fast", 2)
+	require.NoError(t, err)
+
+	removed, err := manager.PruneQueues()
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	pending, err := manager.Receive("jobs", "slow", 0)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+
+	// A consumer waiting before anything is sent is registered too
+	_, err = manager.Receive("builds", "waiter", 0)
+	require.NoError(t, err)
+	_, err = manager.SendMessage("builds", models.SignalPayload{})
+	require.NoError(t, err)
+	_, err = manager.Ack("builds", "other", 1)
+	require.NoError(t, err)
+	removed, err = manager.PruneQueues()
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+}
+
+func TestQueueConcurrentSendersGetUniqueIDs(t *testing.T) {
+	manager, err := NewManager(setupTestSignalsDir(t))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	ids := make(chan int64, 16)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msg, err := manager.SendMessage("busy", models.SignalPayload{})
+			if assert.NoError(t, err) {
+				ids <- msg.ID
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]bool)
+	for id := range ids {
+		assert.False(t, seen[id], "ID %d sent twice", id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, 16)
+}
+
+func TestQueueNameValidation(t *testing.T) {
+	manager, err := NewManager(setupTestSignalsDir(t))
+	require.NoError(t, err)
+
+	_, err = manager.SendMessage("../escape", models.SignalPayload{})
+	assert.Error(t, err)
+	_, err = manager.Receive("jobs", "", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+)
+
+// switchTo makes the selected context active, stopping the active one first
+func (m *Model) switchTo() {
+	e := m.selected()
+	if e == nil {
+		return
+	}
+	name := e.ctx.Name
+	if name == m.active {
+		m.setStatus("%s is already active", name)
+		return
+	}
+	if e.ctx.IsArchived {
+		m.setError("%s is archived; press a to unarchive it first", name)
+		return
+	}
+
+	m.run(func() error {
+		if _, err := core.SwitchContext(name); err != nil {
+			return err
+		}
+		m.setStatus("Switched to %s", name)
+		return nil
+	})
+}
+
+// stop stops the active context
+func (m *Model) stop() {
+	if m.active == "" {
+		m.setStatus("No active context")
+		return
+	}
+	name := m.active
+	m.run(func() error {
+		if _, err := core.StopContext(); err != nil {
+			return err
+		}
+		m.setStatus("Stopped %s", name)
+		return nil
+	})
+}
+
+// addNote adds a note to the active context, which must be the selected one
+func (m *Model) addNote() {
+	e := m.selected()
+	if e == nil {
+		return
+	}
+	if e.ctx.Name != m.active {
+		m.setError("Notes go to the active context; press s to switch to %s first", e.ctx.Name)
+		return
+	}
+	m.startInput("Note", "", func(text string) error {
+		if text == "" {
+			return fmt.Errorf("note is empty")
+		}
+		if _, err := core.AddNote(text); err != nil {
+			return err
+		}
+		m.setStatus("Note added")
+		return nil
+	})
+}
+
+// addTags adds the comma or space separated labels typed to the selected context
+func (m *Model) addTags() {
+	e := m.selected()
+	if e == nil {
+		return
+	}
+	name := e.ctx.Name
+	m.startInput("Tags for "+name, "", func(text string) error {
+		tags := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' })
+		if len(tags) == 0 {
+			return fmt.Errorf("no tags given")
+		}
+		added, err := core.AddTags(name, tags)
+		if err != nil {
+			return err
+		}
+		if len(added) == 0 {
+			m.setStatus("%s already has those tags", name)
+		} else {
+			m.setStatus("Tagged %s: %s", name, strings.Join(added, ", "))
+		}
+		return nil
+	})
+}
+
+// link sets the selected context's parent to the context typed, or removes it
+// when the prompt is left empty
+func (m *Model) link() {
+	e := m.selected()
+	if e == nil {
+		return
+	}
+	name := e.ctx.Name
+	m.startInput("Parent of "+name+" (empty to unlink)", e.ctx.Metadata.Parent, func(parent string) error {
+		if parent == "" {
+			if err := core.ClearParent(name); err != nil {
+				return err
+			}
+			m.setStatus("Unlinked %s", name)
+			return nil
+		}
+		ctx, err := core.ResolveContext(parent, nil)
+		if err != nil {
+			return err
+		}
+		if ctx.Name == name {
+			return fmt.Errorf("a context can't be its own parent")
+		}
+		if err := core.SetParent(name, ctx.Name); err != nil {
+			return err
+		}
+		m.setStatus("Linked %s under %s", name, ctx.Name)
+		return nil
+	})
+}
+
+// toggleArchive archives the selected context, or unarchives it if it is archived
+func (m *Model) toggleArchive() {
+	e := m.selected()
+	if e == nil {
+		return
+	}
+	name := e.ctx.Name
+
+	if e.ctx.IsArchived {
+		m.run(func() error {
+			if err := core.UnarchiveContext(name); err != nil {
+				return err
+			}
+			m.setStatus("Unarchived %s", name)
+			return nil
+		})
+		return
+	}
+
+	m.askConfirm(fmt.Sprintf("Archive %s? (y/n)", name), func() error {
+		if err := core.ArchiveContext(name); err != nil {
+			return err
+		}
+		m.setStatus("Archived %s", name)
+		return nil
+	})
+}
+
+func (m *Model) setError(format string, args ...interface{}) {
+	m.status = fmt.Sprintf(format, args...)
+	m.statusErr = true
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"bufio"
+)
+
+// KeyType identifies a key press
+type KeyType int
+
+const (
+	KeyRune KeyType = iota // A printable character, in Key.Rune
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyTab
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDown
+	KeyCtrlC
+	KeyUnknown
+)
+
+// Key is a single key press read from the terminal
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+// RuneKey returns the key press of a printable character
+func RuneKey(r rune) Key {
+	return Key{Type: KeyRune, Rune: r}
+}
+
+// readKey reads one key press from a terminal in raw mode, decoding the
+// escape sequences of arrow and navigation keys
+func readKey(r *bufio.Reader) (Key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+
+	switch c {
+	case '\r', '\n':
+		return Key{Type: KeyEnter}, nil
+	case 0x7f, 0x08:
+		return Key{Type: KeyBackspace}, nil
+	case '\t':
+		return Key{Type: KeyTab}, nil
+	case 0x03:
+		return Key{Type: KeyCtrlC}, nil
+	case 0x1b:
+		// A lone Esc arrives by itself; a sequence arrives in one read
+		if r.Buffered() == 0 {
+			return Key{Type: KeyEsc}, nil
+		}
+		return readEscape(r)
+	}
+
+	if c < 0x20 {
+		return Key{Type: KeyUnknown}, nil
+	}
+	return RuneKey(c), nil
+}
+
+// readEscape decodes the rest of a CSI (ESC [) or SS3 (ESC O) sequence
+func readEscape(r *bufio.Reader) (Key, error) {
+	intro, err := r.ReadByte()
+	if err != nil {
+		return Key{}, err
+	}
+	if intro != '[' && intro != 'O' {
+		return Key{Type: KeyEsc}, nil
+	}
+
+	var params []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return Key{}, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return escapeKey(string(params), b), nil
+		}
+		params = append(params, b)
+	}
+}
+
+func escapeKey(params string, final byte) Key {
+	switch final {
+	case 'A':
+		return Key{Type: KeyUp}
+	case 'B':
+		return Key{Type: KeyDown}
+	case 'C':
+		return Key{Type: KeyRight}
+	case 'D':
+		return Key{Type: KeyLeft}
+	case 'H':
+		return Key{Type: KeyHome}
+	case 'F':
+		return Key{Type: KeyEnd}
+	case '~':
+		switch params {
+		case "1", "7":
+			return Key{Type: KeyHome}
+		case "4", "8":
+			return Key{Type: KeyEnd}
+		case "5":
+			return Key{Type: KeyPgUp}
+		case "6":
+			return Key{Type: KeyPgDown}
+		}
+	}
+	return Key{Type: KeyUnknown}
+}
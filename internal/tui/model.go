@@ -0,0 +1,378 @@
+// Package tui implements 'my-context ui', a full-screen terminal browser for
+// contexts. Model holds the state and handles key presses through internal/core;
+// View renders it; Run drives both on a raw-mode terminal.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	intmodels "github.com/jefferycaldwell/my-context-copilot/internal/models"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// entry is a context as shown in the browser
+type entry struct {
+	ctx   *pkgmodels.ContextWithMetadata
+	notes []*intmodels.Note
+	files []*intmodels.FileAssociation
+}
+
+type mode int
+
+const (
+	modeBrowse  mode = iota
+	modeInput        // Typing into the prompt line
+	modeConfirm      // Waiting for y/n
+	modeHelp         // Key reference shown
+)
+
+// Model is the state of the browser
+type Model struct {
+	entries      []entry
+	visible      []int // Indices into entries that pass the filter
+	cursor       int   // Index into visible
+	offset       int   // First visible row shown in the list
+	active       string
+	filter       string
+	showArchived bool
+
+	focusDetail  bool // Keys scroll the detail pane (and it fills narrow screens)
+	detailScroll int
+
+	mode     mode
+	prompt   string
+	input    []rune
+	onInput  func(text string) error // Called with the prompt text on enter
+	onKey    func(text string)       // Called as the prompt text changes
+	onCancel func()                  // Called when the prompt is abandoned with Esc
+	confirm  func() error            // Called when a confirmation is accepted
+
+	status    string
+	statusErr bool
+	quit      bool
+
+	// Set by View so paging moves by a screenful
+	listHeight int
+}
+
+// NewModel loads all contexts into a new browser
+func NewModel() (*Model, error) {
+	m := &Model{}
+	if err := m.reload(); err != nil {
+		return nil, err
+	}
+	// Start on the active context
+	for i, idx := range m.visible {
+		if m.entries[idx].ctx.Name == m.active {
+			m.cursor = i
+		}
+	}
+	return m, nil
+}
+
+// Quit reports whether the user asked to leave
+func (m *Model) Quit() bool {
+	return m.quit
+}
+
+// reload reads every context from disk, keeping the selection where possible
+func (m *Model) reload() error {
+	selected := m.selectedName()
+
+	contexts, err := core.ListContexts()
+	if err != nil {
+		return err
+	}
+	state, err := core.GetActiveContext()
+	if err != nil {
+		return err
+	}
+	m.active = state.GetActiveContextName()
+
+	m.entries = m.entries[:0]
+	for _, c := range contexts {
+		ctx, notes, files, _, err := core.GetContextWithMetadata(c.Name)
+		if err != nil {
+			continue // Skip contexts that can't be read
+		}
+		m.entries = append(m.entries, entry{ctx: ctx, notes: notes, files: files})
+	}
+
+	m.applyFilter()
+	if selected != "" {
+		m.selectName(selected)
+	}
+	return nil
+}
+
+// applyFilter recomputes the visible contexts. Filter words must all match:
+// #label matches a label, state:x a workflow state, anything else the name.
+func (m *Model) applyFilter() {
+	words := strings.Fields(strings.ToLower(m.filter))
+
+	m.visible = m.visible[:0]
+	for i, e := range m.entries {
+		if e.ctx.IsArchived && !m.showArchived {
+			continue
+		}
+		if matchesFilter(e.ctx, words) {
+			m.visible = append(m.visible, i)
+		}
+	}
+
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.detailScroll = 0
+}
+
+func matchesFilter(ctx *pkgmodels.ContextWithMetadata, words []string) bool {
+	for _, w := range words {
+		switch {
+		case strings.HasPrefix(w, "#"):
+			found := false
+			for _, label := range ctx.Metadata.Labels {
+				if strings.EqualFold(label, w[1:]) {
+					found = true
+				}
+			}
+			if !found {
+				return false
+			}
+		case strings.HasPrefix(w, "state:"):
+			if !strings.EqualFold(ctx.Metadata.State, strings.TrimPrefix(w, "state:")) {
+				return false
+			}
+		default:
+			if !strings.Contains(strings.ToLower(ctx.Name), w) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// selected returns the context under the cursor, or nil when the list is empty
+func (m *Model) selected() *entry {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return &m.entries[m.visible[m.cursor]]
+}
+
+func (m *Model) selectedName() string {
+	if e := m.selected(); e != nil {
+		return e.ctx.Name
+	}
+	return ""
+}
+
+func (m *Model) selectName(name string) {
+	for i, idx := range m.visible {
+		if m.entries[idx].ctx.Name == name {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// HandleKey updates the model for one key press
+func (m *Model) HandleKey(k Key) {
+	switch m.mode {
+	case modeInput:
+		m.handleInput(k)
+		return
+	case modeConfirm:
+		m.mode = modeBrowse
+		if k.Type == KeyRune && (k.Rune == 'y' || k.Rune == 'Y') {
+			m.run(m.confirm)
+		} else {
+			m.setStatus("Cancelled")
+		}
+		return
+	case modeHelp:
+		m.mode = modeBrowse
+		return
+	}
+
+	m.status, m.statusErr = "", false
+	if k.Type == KeyCtrlC {
+		m.quit = true
+		return
+	}
+
+	if m.handleMove(k) {
+		return
+	}
+
+	switch k.Type {
+	case KeyEnter, KeyRight, KeyTab:
+		m.focusDetail = !m.focusDetail || k.Type == KeyRight
+		return
+	case KeyLeft, KeyEsc:
+		if m.focusDetail {
+			m.focusDetail = false
+		} else if m.filter != "" {
+			m.filter = ""
+			m.applyFilter()
+		}
+		return
+	case KeyRune:
+	default:
+		return
+	}
+
+	switch k.Rune {
+	case 'q':
+		m.quit = true
+	case '?':
+		m.mode = modeHelp
+	case 'r':
+		m.run(func() error { return nil })
+		if !m.statusErr {
+			m.setStatus("Reloaded")
+		}
+	case 'A':
+		m.showArchived = !m.showArchived
+		m.applyFilter()
+		if m.showArchived {
+			m.setStatus("Showing archived contexts")
+		} else {
+			m.setStatus("Hiding archived contexts")
+		}
+	case '/':
+		previous := m.filter
+		m.startInput("Filter (name, #label, state:x)", m.filter, nil)
+		m.onKey = func(text string) {
+			m.filter = text
+			m.applyFilter()
+		}
+		m.onCancel = func() {
+			m.filter = previous
+			m.applyFilter()
+		}
+	case 's':
+		m.switchTo()
+	case 'x':
+		m.stop()
+	case 'n':
+		m.addNote()
+	case 't':
+		m.addTags()
+	case 'L':
+		m.link()
+	case 'a':
+		m.toggleArchive()
+	}
+}
+
+// handleMove moves the cursor in the list, or scrolls the detail pane when it has focus
+func (m *Model) handleMove(k Key) bool {
+	step := 0
+	page := m.listHeight
+	if page < 1 {
+		page = 10
+	}
+
+	switch {
+	case k.Type == KeyUp || (k.Type == KeyRune && k.Rune == 'k'):
+		step = -1
+	case k.Type == KeyDown || (k.Type == KeyRune && k.Rune == 'j'):
+		step = 1
+	case k.Type == KeyPgUp:
+		step = -page
+	case k.Type == KeyPgDown:
+		step = page
+	case k.Type == KeyHome || (k.Type == KeyRune && k.Rune == 'g'):
+		step = -1 << 30
+	case k.Type == KeyEnd || (k.Type == KeyRune && k.Rune == 'G'):
+		step = 1 << 30
+	default:
+		return false
+	}
+
+	if m.focusDetail {
+		m.detailScroll += step
+		if m.detailScroll < 0 {
+			m.detailScroll = 0
+		}
+		return true
+	}
+
+	m.cursor += step
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.detailScroll = 0
+	return true
+}
+
+func (m *Model) handleInput(k Key) {
+	switch k.Type {
+	case KeyEnter:
+		m.mode = modeBrowse
+		m.onKey, m.onCancel = nil, nil
+		if submit := m.onInput; submit != nil {
+			text := strings.TrimSpace(string(m.input))
+			m.run(func() error { return submit(text) })
+		}
+		return
+	case KeyEsc, KeyCtrlC:
+		m.mode = modeBrowse
+		if m.onCancel != nil {
+			m.onCancel()
+		}
+		m.onKey, m.onCancel = nil, nil
+		m.setStatus("Cancelled")
+		return
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case KeyRune:
+		m.input = append(m.input, k.Rune)
+	}
+	if m.onKey != nil {
+		m.onKey(string(m.input))
+	}
+}
+
+func (m *Model) startInput(prompt, initial string, submit func(text string) error) {
+	m.mode = modeInput
+	m.prompt = prompt
+	m.input = []rune(initial)
+	m.onInput = submit
+	m.onKey, m.onCancel = nil, nil
+}
+
+func (m *Model) askConfirm(prompt string, action func() error) {
+	m.mode = modeConfirm
+	m.prompt = prompt
+	m.confirm = action
+}
+
+// run performs an action and reloads, reporting an error in the status line
+func (m *Model) run(action func() error) {
+	if err := action(); err != nil {
+		m.status = err.Error()
+		m.statusErr = true
+		return
+	}
+	if err := m.reload(); err != nil {
+		m.status = err.Error()
+		m.statusErr = true
+	}
+}
+
+func (m *Model) setStatus(format string, args ...interface{}) {
+	m.status = fmt.Sprintf(format, args...)
+	m.statusErr = false
+}
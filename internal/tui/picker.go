@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"golang.org/x/term"
+)
+
+// pickerRows is how many choices the picker shows at once
+const pickerRows = 10
+
+// ErrPickCancelled is returned by Pick when the user leaves without choosing
+var ErrPickCancelled = errors.New("no context selected")
+
+// Picker narrows a list of names as the user types, fuzzy matching the query
+type Picker struct {
+	items   []string
+	query   []rune
+	matches []string
+	cursor  int
+	done    bool
+	picked  string
+}
+
+// NewPicker returns a picker over items, starting with query typed
+func NewPicker(items []string, query string) *Picker {
+	p := &Picker{items: items, query: []rune(query)}
+	p.filter()
+	return p
+}
+
+// Done reports whether the user chose or cancelled; Picked is empty when cancelled
+func (p *Picker) Done() bool {
+	return p.done
+}
+
+// Picked returns the chosen item
+func (p *Picker) Picked() string {
+	return p.picked
+}
+
+// Matches returns the items matching the query, best first
+func (p *Picker) Matches() []string {
+	return p.matches
+}
+
+func (p *Picker) filter() {
+	type scored struct {
+		name  string
+		score int
+	}
+	var found []scored
+	for _, item := range p.items {
+		if score, ok := core.FuzzyMatch(string(p.query), item); ok {
+			found = append(found, scored{item, score})
+		}
+	}
+	// With no query keep the order the items came in
+	if len(p.query) > 0 {
+		sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+	}
+
+	p.matches = p.matches[:0]
+	for _, f := range found {
+		p.matches = append(p.matches, f.name)
+	}
+	if p.cursor >= len(p.matches) {
+		p.cursor = len(p.matches) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+}
+
+// HandleKey updates the picker for one key press
+func (p *Picker) HandleKey(k Key) {
+	switch k.Type {
+	case KeyEnter:
+		if len(p.matches) > 0 {
+			p.picked = p.matches[p.cursor]
+			p.done = true
+		}
+	case KeyEsc, KeyCtrlC:
+		p.done = true
+	case KeyUp:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case KeyDown, KeyTab:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case KeyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.filter()
+		}
+	case KeyRune:
+		p.query = append(p.query, k.Rune)
+		p.filter()
+	}
+}
+
+// View renders the prompt line followed by up to pickerRows choices
+func (p *Picker) View(prompt string, width int) []string {
+	lines := []string{fit(fmt.Sprintf("%s %s", prompt, string(p.query)), width)}
+
+	first := 0
+	if p.cursor >= pickerRows {
+		first = p.cursor - pickerRows + 1
+	}
+	for i := first; i < len(p.matches) && i < first+pickerRows; i++ {
+		if i == p.cursor {
+			lines = append(lines, ansiReverse+pad("> "+p.matches[i], width)+ansiReset)
+		} else {
+			lines = append(lines, fit("  "+p.matches[i], width))
+		}
+	}
+	lines = append(lines, ansiDim+fit(fmt.Sprintf("  %d/%d  ↑↓ move  enter select  esc cancel", len(p.matches), len(p.items)), width)+ansiReset)
+	return lines
+}
+
+// Pick asks the user to choose one of items on the terminal, drawing the
+// picker below the cursor and erasing it afterwards
+func Pick(in, out *os.File, prompt string, items []string, query string) (string, error) {
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return "", fmt.Errorf("choosing a context needs an interactive terminal")
+	}
+
+	oldState, err := term.MakeRaw(inFd)
+	if err != nil {
+		return "", fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer term.Restore(inFd, oldState)
+
+	fmt.Fprint(out, "\033[?25l")
+	defer fmt.Fprint(out, "\033[?25h")
+
+	p := NewPicker(items, query)
+	reader := bufio.NewReader(in)
+	for {
+		width, _, err := term.GetSize(outFd)
+		if err != nil || width < 20 {
+			width = 80
+		}
+		lines := p.View(prompt, width-1)
+		drawInline(out, lines)
+
+		k, err := readKey(reader)
+		if err != nil {
+			eraseInline(out)
+			return "", err
+		}
+		p.HandleKey(k)
+		if p.Done() {
+			eraseInline(out)
+			if p.Picked() == "" {
+				return "", ErrPickCancelled
+			}
+			return p.Picked(), nil
+		}
+	}
+}
+
+// drawInline writes lines from the start of the current line and moves back to it
+func drawInline(out io.Writer, lines []string) {
+	var sb strings.Builder
+	sb.WriteString("\r\033[J")
+	sb.WriteString(strings.Join(lines, ansiReset+"\r\n"))
+	sb.WriteString(ansiReset)
+	if len(lines) > 1 {
+		fmt.Fprintf(&sb, "\033[%dA", len(lines)-1)
+	}
+	sb.WriteString("\r")
+	io.WriteString(out, sb.String())
+}
+
+func eraseInline(out io.Writer) {
+	io.WriteString(out, "\r\033[J")
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Run shows the browser full-screen on the terminal until the user quits
+func Run(in, out *os.File) error {
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return fmt.Errorf("my-context ui needs an interactive terminal")
+	}
+
+	m, err := NewModel()
+	if err != nil {
+		return err
+	}
+
+	oldState, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer term.Restore(inFd, oldState)
+
+	// Alternate screen, hidden cursor; restored on the way out
+	fmt.Fprint(out, "\033[?1049h\033[?25l")
+	defer fmt.Fprint(out, "\033[?25h\033[?1049l")
+
+	keys := make(chan Key)
+	errs := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			k, err := readKey(reader)
+			if err != nil {
+				errs <- err
+				return
+			}
+			keys <- k
+		}
+	}()
+
+	// Redraw after every key, and when the terminal is resized
+	width, height := 0, 0
+	redraw := func() {
+		w, h, err := term.GetSize(outFd)
+		if err != nil {
+			w, h = 80, 24
+		}
+		width, height = w, h
+		draw(out, m.View(w, h))
+	}
+	redraw()
+
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for !m.Quit() {
+		select {
+		case k := <-keys:
+			m.HandleKey(k)
+			if !m.Quit() {
+				redraw()
+			}
+		case err := <-errs:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-ticker.C:
+			if w, h, err := term.GetSize(outFd); err == nil && (w != width || h != height) {
+				redraw()
+			}
+		}
+	}
+	return nil
+}
+
+// draw writes the lines from the top of the screen, clearing what they don't cover
+func draw(out io.Writer, lines []string) {
+	var sb strings.Builder
+	sb.WriteString("\033[H")
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(line)
+		sb.WriteString(ansiReset + "\033[K")
+	}
+	sb.WriteString("\033[J")
+	io.WriteString(out, sb.String())
+}
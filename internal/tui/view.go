@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+const (
+	ansiReset   = "\033[0m"
+	ansiBold    = "\033[1m"
+	ansiDim     = "\033[2m"
+	ansiReverse = "\033[7m"
+	ansiGreen   = "\033[32m"
+	ansiRed     = "\033[31m"
+	ansiYellow  = "\033[33m"
+
+	// Below this width the list and the details take turns filling the screen
+	splitMinWidth = 90
+)
+
+// helpLines is the key reference shown with ?
+var helpLines = []string{
+	"Keys",
+	"",
+	"  ↑/k ↓/j  move           PgUp PgDn g G  page, first, last",
+	"  enter    details        esc/←          back, clear filter",
+	"  /        filter by name, #label or state:x",
+	"  A        show or hide archived contexts",
+	"",
+	"  s        switch to the selected context (stops the active one)",
+	"  x        stop the active context",
+	"  n        add a note to the active context",
+	"  t        tag the selected context",
+	"  L        link the selected context to a parent",
+	"  a        archive or unarchive the selected context",
+	"  r        reload         q              quit",
+	"",
+	"Press any key to return",
+}
+
+// View renders the browser as height lines of at most width columns
+func (m *Model) View(width, height int) []string {
+	if width < 20 {
+		width = 20
+	}
+	if height < 5 {
+		height = 5
+	}
+
+	lines := make([]string, 0, height)
+	lines = append(lines, m.titleBar(width))
+
+	bodyHeight := height - 3
+	m.listHeight = bodyHeight
+
+	var body []string
+	switch {
+	case m.mode == modeHelp:
+		for _, line := range helpLines {
+			body = append(body, " "+fit(line, width-1))
+		}
+	case width >= splitMinWidth:
+		listWidth := width * 2 / 5
+		list := m.listPane(listWidth, bodyHeight)
+		detail := m.detailPane(width-listWidth-3, bodyHeight)
+		for i := 0; i < bodyHeight; i++ {
+			body = append(body, list[i]+ansiDim+" │ "+ansiReset+detail[i])
+		}
+	case m.focusDetail:
+		body = m.detailPane(width, bodyHeight)
+	default:
+		body = m.listPane(width, bodyHeight)
+	}
+	for len(body) < bodyHeight {
+		body = append(body, "")
+	}
+	lines = append(lines, body[:bodyHeight]...)
+
+	lines = append(lines, m.statusLine(width), m.keyLine(width))
+	return lines
+}
+
+func (m *Model) titleBar(width int) string {
+	title := fmt.Sprintf(" my-context · %d of %d contexts", len(m.visible), len(m.entries))
+	if m.filter != "" {
+		title += fmt.Sprintf(" · filter: %s", m.filter)
+	}
+	if m.showArchived {
+		title += " · with archived"
+	}
+	active := "none"
+	if m.active != "" {
+		active = m.active
+	}
+	right := "active: " + active + " "
+	gap := width - runeLen(title) - runeLen(right)
+	if gap < 1 {
+		return ansiReverse + fit(title, width) + ansiReset
+	}
+	return ansiReverse + title + strings.Repeat(" ", gap) + right + ansiReset
+}
+
+// listPane renders the filtered contexts, scrolled to keep the cursor in view
+func (m *Model) listPane(width, height int) []string {
+	lines := make([]string, height)
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", width)
+	}
+	if len(m.visible) == 0 {
+		lines[0] = pad("  No contexts match", width)
+		return lines
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+
+	for row := 0; row < height && m.offset+row < len(m.visible); row++ {
+		i := m.offset + row
+		ctx := m.entries[m.visible[i]].ctx
+
+		marker, color := "○", ansiDim
+		switch {
+		case ctx.Name == m.active:
+			marker, color = "●", ansiGreen
+		case ctx.IsArchived:
+			marker, color = "▫", ansiDim
+		}
+
+		label := ctx.Name
+		if ctx.Metadata.State != "" {
+			label += " [" + ctx.Metadata.State + "]"
+		}
+		text := pad(" "+marker+" "+label, width)
+
+		switch {
+		case i == m.cursor && !m.focusDetail:
+			lines[row] = ansiReverse + text + ansiReset
+		case i == m.cursor:
+			lines[row] = ansiBold + text + ansiReset
+		default:
+			lines[row] = color + text[:len(" "+marker)] + ansiReset + text[len(" "+marker):]
+		}
+	}
+	return lines
+}
+
+// detailPane renders the selected context's metadata, notes and files
+func (m *Model) detailPane(width, height int) []string {
+	e := m.selected()
+	var content []string
+	if e == nil {
+		content = []string{""}
+	} else {
+		ctx := e.ctx
+		status := ctx.Status
+		if ctx.Name == m.active {
+			status = ansiGreen + "active" + ansiReset
+		}
+		if ctx.IsArchived {
+			status += " (archived)"
+		}
+		content = append(content, ansiBold+fit(ctx.Name, width)+ansiReset)
+		field := func(name, value string) {
+			if value != "" {
+				content = append(content, ansiDim+fmt.Sprintf("%-9s", name)+ansiReset+fit(value, width-9))
+			}
+		}
+		field("Status", status)
+		field("State", ctx.Metadata.State)
+		field("Parent", ctx.Metadata.Parent)
+		field("Labels", strings.Join(ctx.Metadata.Labels, ", "))
+		field("Started", ctx.StartTime.Local().Format("Mon Jan 2 15:04"))
+		duration := time.Since(ctx.StartTime)
+		if ctx.EndTime != nil {
+			duration = ctx.EndTime.Sub(ctx.StartTime)
+		}
+		field("Duration", output.FormatDuration(duration))
+
+		content = append(content, "", ansiBold+fmt.Sprintf("Notes (%d)", len(e.notes))+ansiReset)
+		for _, note := range e.notes {
+			prefix := note.Timestamp.Local().Format("Jan 2 15:04") + "  "
+			for i, line := range wrap(output.NoteTypeLabel(note)+note.TextContent, width-runeLen(prefix)) {
+				if i == 0 {
+					content = append(content, ansiDim+prefix+ansiReset+line)
+				} else {
+					content = append(content, strings.Repeat(" ", runeLen(prefix))+line)
+				}
+			}
+		}
+
+		content = append(content, "", ansiBold+fmt.Sprintf("Files (%d)", len(e.files))+ansiReset)
+		for _, file := range e.files {
+			content = append(content, fit("  "+file.FilePath, width))
+		}
+	}
+
+	// Scroll, keeping the last screenful reachable
+	maxScroll := len(content) - height
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.detailScroll > maxScroll {
+		m.detailScroll = maxScroll
+	}
+	content = content[m.detailScroll:]
+
+	lines := make([]string, height)
+	copy(lines, content)
+	return lines
+}
+
+func (m *Model) statusLine(width int) string {
+	switch m.mode {
+	case modeInput:
+		return fit(m.prompt+": "+string(m.input), width-1) + ansiReverse + " " + ansiReset
+	case modeConfirm:
+		return ansiYellow + fit(m.prompt, width) + ansiReset
+	}
+	if m.statusErr {
+		return ansiRed + fit(m.status, width) + ansiReset
+	}
+	return fit(m.status, width)
+}
+
+func (m *Model) keyLine(width int) string {
+	keys := "enter details  s switch  x stop  n note  t tag  L link  a archive  / filter  ? help  q quit"
+	switch {
+	case m.mode == modeInput:
+		keys = "enter confirm  esc cancel"
+	case m.focusDetail:
+		keys = "↑↓ scroll  esc back  " + keys
+	}
+	return ansiDim + fit(" "+keys, width) + ansiReset
+}
+
+// fit cuts text to width columns, marking the cut with an ellipsis
+func fit(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	text = strings.ReplaceAll(text, "\n", " ")
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// pad fits text to exactly width columns
+func pad(text string, width int) string {
+	text = fit(text, width)
+	if width <= runeLen(text) {
+		return text
+	}
+	return text + strings.Repeat(" ", width-runeLen(text))
+}
+
+// wrap breaks text into lines of at most width columns at spaces where possible
+func wrap(text string, width int) []string {
+	if width < 10 {
+		width = 10
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		runes := []rune(paragraph)
+		for len(runes) > width {
+			cut := width
+			for i := width; i > width/2; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+			lines = append(lines, string(runes[:cut]))
+			runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+func runeLen(s string) int {
+	return len([]rune(s))
+}
@@ -11,6 +11,35 @@ type ContextMetadata struct {
 	CreatedBy string   `json:"created_by,omitempty"` // User who created the context
 	Parent    string   `json:"parent,omitempty"`     // Parent context name for hierarchy
 	Labels    []string `json:"labels,omitempty"`     // Labels for categorization and search
+	Handoff   *Handoff `json:"handoff,omitempty"`    // Set while the context is handed to another team
+
+	State          string     `json:"state,omitempty"`            // Workflow state (e.g., "review"), empty if never set
+	StateChangedAt *time.Time `json:"state_changed_at,omitempty"` // When State last changed
+}
+
+// Handoff statuses
+const (
+	HandoffPending  = "pending"
+	HandoffAccepted = "accepted"
+)
+
+// Handoff records a context being passed from one team to another
+type Handoff struct {
+	From       string     `json:"from,omitempty"`
+	To         string     `json:"to"`
+	Status     string     `json:"status"` // pending or accepted
+	Summary    string     `json:"summary,omitempty"`
+	Questions  []string   `json:"questions,omitempty"`
+	Files      []string   `json:"files,omitempty"`
+	Signal     string     `json:"signal,omitempty"` // Signal raised for the receiving team
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	AcceptedBy string     `json:"accepted_by,omitempty"`
+}
+
+// IsPending reports whether the handoff is waiting to be accepted
+func (h *Handoff) IsPending() bool {
+	return h != nil && h.Status == HandoffPending
 }
 
 // Validate checks if metadata is valid
@@ -44,6 +73,7 @@ func (m *ContextMetadata) Validate() error {
 
 // ContextWithMetadata extends the base Context with metadata fields
 type ContextWithMetadata struct {
+	ID               string          `json:"id,omitempty"` // Short stable ID, also the directory name
 	Name             string          `json:"name"`
 	StartTime        time.Time       `json:"start_time"`
 	EndTime          *time.Time      `json:"end_time,omitempty"`
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// QueueMessage is one message sent to a signal queue
+type QueueMessage struct {
+	ID     int64     `json:"id"`      // Sequence number, increasing from 1 within a queue
+	Queue  string    `json:"queue"`   // Queue the message was sent to
+	SentAt time.Time `json:"sent_at"` // When the message was sent
+	SignalPayload
+}
+
+// QueueConsumer is a named cursor into a queue
+type QueueConsumer struct {
+	Name      string    `json:"name"`
+	Acked     int64     `json:"acked"`      // Highest acknowledged message ID
+	UpdatedAt time.Time `json:"updated_at"` // When the cursor last moved
+}
+
+// QueueConsumerInfo summarizes a consumer's position for display
+type QueueConsumerInfo struct {
+	Name    string `json:"name"`
+	Acked   int64  `json:"acked"`
+	Pending int    `json:"pending"` // Messages not yet acknowledged
+}
+
+// QueueInfo summarizes a queue for display
+type QueueInfo struct {
+	Name      string              `json:"name"`
+	Messages  int                 `json:"messages"` // Messages still stored
+	LastID    int64               `json:"last_id"`  // ID of the newest message ever sent
+	Consumers []QueueConsumerInfo `json:"consumers"`
+}
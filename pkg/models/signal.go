@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,9 +11,24 @@ import (
 
 // Signal represents an event notification signal
 type Signal struct {
-	Name      string    `json:"name"`       // Signal name/identifier
-	CreatedAt time.Time `json:"created_at"` // When the signal was created
-	Path      string    `json:"path"`       // Full path to the signal file
+	Name      string     `json:"name"`                 // Signal name/identifier
+	CreatedAt time.Time  `json:"created_at"`           // When the signal was created
+	Path      string     `json:"path"`                 // Full path to the signal file
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // When the signal stops counting (nil = never)
+	SignalPayload
+}
+
+// SignalPayload is the data a signal carries from sender to waiter
+type SignalPayload struct {
+	Message string            `json:"message,omitempty"` // Human-readable message (e.g., "binary updated to bdac191")
+	Sender  string            `json:"sender,omitempty"`  // Who created the signal
+	Context string            `json:"context,omitempty"` // Related context name
+	Data    map[string]string `json:"data,omitempty"`    // Arbitrary key/value pairs
+}
+
+// IsEmpty reports whether the payload carries no data
+func (p SignalPayload) IsEmpty() bool {
+	return p.Message == "" && p.Sender == "" && p.Context == "" && len(p.Data) == 0
 }
 
 // NewSignal creates a new signal instance
@@ -34,7 +50,12 @@ func (s *Signal) Exists() bool {
 	return !os.IsNotExist(err)
 }
 
-// Create writes the signal file to disk
+// IsExpired reports whether the signal's TTL has passed
+func (s *Signal) IsExpired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// Create writes the signal file to disk as JSON
 func (s *Signal) Create() error {
 	// Ensure directory exists
 	dir := filepath.Dir(s.Path)
@@ -42,9 +63,17 @@ func (s *Signal) Create() error {
 		return fmt.Errorf("failed to create signals directory: %w", err)
 	}
 
-	// Create the signal file with timestamp as content
-	content := s.CreatedAt.Format(time.RFC3339) + "\n"
-	if err := os.WriteFile(s.Path, []byte(content), 0o600); err != nil {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode signal: %w", err)
+	}
+
+	// Write to a temp file and rename so waiters never see a half-written payload
+	tmpPath := s.Path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(content, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to create signal file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.Path); err != nil {
 		return fmt.Errorf("failed to create signal file: %w", err)
 	}
 
@@ -77,11 +106,27 @@ func LoadSignalFromFile(path string) (*Signal, error) {
 
 	name := filename[:len(filename)-7] // Remove .signal extension
 
-	return &Signal{
+	signal := &Signal{
 		Name:      name,
 		CreatedAt: info.ModTime(),
 		Path:      path,
-	}, nil
+	}
+
+	// Signals are JSON; older signal files only hold a timestamp, so fall back to mtime
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signal file: %w", err)
+	}
+	var stored Signal
+	if json.Unmarshal(content, &stored) == nil {
+		if !stored.CreatedAt.IsZero() {
+			signal.CreatedAt = stored.CreatedAt
+		}
+		signal.ExpiresAt = stored.ExpiresAt
+		signal.SignalPayload = stored.SignalPayload
+	}
+
+	return signal, nil
 }
 
 // SignalInfo represents summary information about a signal
@@ -89,13 +134,40 @@ type SignalInfo struct {
 	Name      string `json:"name"`
 	CreatedAt string `json:"created_at"` // Formatted timestamp
 	Path      string `json:"path"`
+	ExpiresAt string `json:"expires_at,omitempty"` // Formatted timestamp, empty if no TTL
+	Expired   bool   `json:"expired,omitempty"`
+	SignalPayload
 }
 
 // ToInfo converts a Signal to SignalInfo
 func (s *Signal) ToInfo() SignalInfo {
-	return SignalInfo{
-		Name:      s.Name,
-		CreatedAt: s.CreatedAt.Format(time.RFC3339),
-		Path:      s.Path,
+	info := SignalInfo{
+		Name:          s.Name,
+		CreatedAt:     s.CreatedAt.Format(time.RFC3339),
+		Path:          s.Path,
+		Expired:       s.IsExpired(time.Now()),
+		SignalPayload: s.SignalPayload,
 	}
+	if s.ExpiresAt != nil {
+		info.ExpiresAt = s.ExpiresAt.Format(time.RFC3339)
+	}
+	return info
+}
+
+// Signal event types recorded in signals.log
+const (
+	SignalEventCreate  = "create"
+	SignalEventClear   = "clear"
+	SignalEventConsume = "consume"
+	SignalEventExpire  = "expire"
+)
+
+// SignalEvent is one entry in the signal audit log
+type SignalEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	Event     string    `json:"event"`           // create, clear, consume or expire
+	Signal    string    `json:"signal"`          // Signal name
+	Actor     string    `json:"actor,omitempty"` // Who caused the event
+	Scope     string    `json:"scope,omitempty"` // Context of a scoped signal; set when logs from several scopes are combined
+	SignalPayload
 }
@@ -54,10 +54,15 @@ func BenchmarkExportWith500Notes(b *testing.B) {
 	tmpOutput := b.TempDir()
 
 	// Benchmark: Export context
+	exporter, err := core.GetExporter("markdown", "")
+	if err != nil {
+		b.Fatalf("GetExporter failed: %v", err)
+	}
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		outputPath := filepath.Join(tmpOutput, "export_"+string(rune('0'+i%10))+".md")
-		_, err := core.ExportContext(contextName, outputPath, false)
+		_, err := core.ExportContext(contextName, outputPath, exporter)
 		if err != nil {
 			b.Fatalf("ExportContext failed: %v", err)
 		}
@@ -102,10 +107,15 @@ func BenchmarkExportSmallContext(b *testing.B) {
 	tmpOutput := b.TempDir()
 
 	// Benchmark
+	exporter, err := core.GetExporter("markdown", "")
+	if err != nil {
+		b.Fatalf("GetExporter failed: %v", err)
+	}
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		outputPath := filepath.Join(tmpOutput, "small_export_"+string(rune('0'+i%10))+".md")
-		_, err := core.ExportContext(contextName, outputPath, false)
+		_, err := core.ExportContext(contextName, outputPath, exporter)
 		if err != nil {
 			b.Fatalf("ExportContext failed: %v", err)
 		}
@@ -150,10 +160,15 @@ func BenchmarkExportWithFiles(b *testing.B) {
 	tmpOutput := b.TempDir()
 
 	// Benchmark
+	exporter, err := core.GetExporter("markdown", "")
+	if err != nil {
+		b.Fatalf("GetExporter failed: %v", err)
+	}
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		outputPath := filepath.Join(tmpOutput, "file_heavy_export_"+string(rune('0'+i%10))+".md")
-		_, err := core.ExportContext(contextName, outputPath, false)
+		_, err := core.ExportContext(contextName, outputPath, exporter)
 		if err != nil {
 			b.Fatalf("ExportContext failed: %v", err)
 		}
@@ -0,0 +1,270 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonEnvelope mirrors the documented --json envelope (docs/JSON-OUTPUT.md)
+type jsonEnvelope struct {
+	SchemaVersion string                 `json:"schema_version"`
+	Command       string                 `json:"command"`
+	Timestamp     string                 `json:"timestamp"`
+	OK            bool                   `json:"ok"`
+	Data          map[string]interface{} `json:"data"`
+	Error         *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// jsonHarness runs a freshly built binary against a temporary context home
+type jsonHarness struct {
+	t      *testing.T
+	binary string
+	env    []string
+	schema map[string]interface{}
+}
+
+func newJSONHarness(t *testing.T) *jsonHarness {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds the binary")
+	}
+
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "my-context")
+	build := exec.Command("go", "build", "-o", binary, "../../cmd/my-context")
+	out, err := build.CombinedOutput()
+	require.NoError(t, err, "Failed to build binary: %s", out)
+
+	raw, err := os.ReadFile("../../docs/schemas/v1/my-context.schema.json")
+	require.NoError(t, err, "Failed to read JSON schema")
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &schema), "JSON schema is not valid JSON")
+
+	home := filepath.Join(dir, "home")
+	require.NoError(t, os.MkdirAll(home, 0o755))
+
+	return &jsonHarness{
+		t:      t,
+		binary: binary,
+		env: append(os.Environ(),
+			"MY_CONTEXT_HOME="+home,
+			"HOME="+dir,
+			"MC_TIMESTAMP_FORMAT=",
+		),
+		schema: schema,
+	}
+}
+
+// run executes a command with --json and decodes the envelope it prints
+func (h *jsonHarness) run(args ...string) (*jsonEnvelope, int) {
+	h.t.Helper()
+
+	cmd := exec.Command(h.binary, append(args, "--json")...)
+	cmd.Env = h.env
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		require.True(h.t, errors.As(err, &exitErr), "%v: %v", args, err)
+		exitCode = exitErr.ExitCode()
+	}
+
+	var envelope jsonEnvelope
+	require.NoError(h.t, json.Unmarshal(stdout.Bytes(), &envelope),
+		"%v: stdout is not a single JSON document\nstdout: %s\nstderr: %s", args, stdout.String(), stderr.String())
+	return &envelope, exitCode
+}
+
+// ok runs a command that must succeed and checks its data against the schema
+func (h *jsonHarness) ok(command string, args ...string) map[string]interface{} {
+	h.t.Helper()
+
+	envelope, exitCode := h.run(args...)
+	assert.Equal(h.t, 0, exitCode, "%v: exit code", args)
+	assert.True(h.t, envelope.OK, "%v: ok", args)
+	assert.Nil(h.t, envelope.Error, "%v: error", args)
+	assert.Equal(h.t, "1.0", envelope.SchemaVersion, "%v: schema_version", args)
+	assert.Equal(h.t, command, envelope.Command, "%v: command", args)
+	assert.NotEmpty(h.t, envelope.Timestamp, "%v: timestamp", args)
+	require.NotNil(h.t, envelope.Data, "%v: data", args)
+
+	for _, key := range h.requiredKeys(command) {
+		assert.Contains(h.t, envelope.Data, key, "%v: data is missing %q required by the schema", args, key)
+	}
+	return envelope.Data
+}
+
+// fails runs a command that must fail and checks the error envelope and exit code
+func (h *jsonHarness) fails(command string, code int, args ...string) {
+	h.t.Helper()
+
+	envelope, exitCode := h.run(args...)
+	assert.Equal(h.t, code, exitCode, "%v: exit code", args)
+	assert.False(h.t, envelope.OK, "%v: ok", args)
+	assert.Equal(h.t, "1.0", envelope.SchemaVersion, "%v: schema_version", args)
+	assert.Equal(h.t, command, envelope.Command, "%v: command", args)
+	assert.Nil(h.t, envelope.Data, "%v: data", args)
+	if assert.NotNil(h.t, envelope.Error, "%v: error", args) {
+		assert.Equal(h.t, code, envelope.Error.Code, "%v: error.code", args)
+		assert.NotEmpty(h.t, envelope.Error.Message, "%v: error.message", args)
+	}
+}
+
+// requiredKeys returns the data keys the schema requires for a command
+func (h *jsonHarness) requiredKeys(command string) []string {
+	h.t.Helper()
+
+	defs := h.schema["$defs"].(map[string]interface{})
+	def := h.resolve(defs, defs["data_"+schemaName(command)])
+	require.NotNil(h.t, def, "schema has no data definition for %q", command)
+
+	var keys []string
+	if required, ok := def["required"].([]interface{}); ok {
+		for _, key := range required {
+			keys = append(keys, key.(string))
+		}
+	}
+	return keys
+}
+
+func (h *jsonHarness) resolve(defs map[string]interface{}, def interface{}) map[string]interface{} {
+	m, ok := def.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if ref, ok := m["$ref"].(string); ok {
+		return h.resolve(defs, defs[filepath.Base(ref)])
+	}
+	return m
+}
+
+func schemaName(command string) string {
+	name := []byte(command)
+	for i, c := range name {
+		if c == ' ' || c == '-' {
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
+// TestJSONEnvelopeSuccess runs every scriptable command with --json and checks its envelope
+func TestJSONEnvelopeSuccess(t *testing.T) {
+	h := newJSONHarness(t)
+
+	data := h.ok("start", "start", "ps-cli: Parent")
+	assert.Equal(t, "ps-cli: Parent", data["context_name"])
+	h.ok("start", "start", "ps-cli: Child", "--parent", "ps-cli: Parent")
+	h.ok("note", "note", "first note")
+	h.ok("note", "note", "second note")
+	data = h.ok("note", "note", "--type", "todo", "write tests")
+	assert.EqualValues(t, 3, data["note_number"], "note numbers match show")
+	h.ok("todos", "todos")
+	h.ok("note done", "note", "done", "3")
+	h.ok("todos", "todos", "--done")
+	h.ok("file", "file", "go.mod")
+	h.ok("touch", "touch")
+	h.ok("show", "show")
+	h.ok("list", "list", "--all")
+	h.ok("history", "history")
+	h.ok("digest", "digest", "--since", "today")
+	h.ok("timeline", "timeline", "--week")
+	h.ok("export-ics", "export-ics", "--to", filepath.Join(t.TempDir(), "sessions.ics"))
+	data = h.ok("tag add", "tag", "add", "ps-cli: Ch", "backend")
+	assert.Equal(t, "ps-cli: Child", data["context"], "tag add resolves a prefix")
+	h.ok("tag list", "tag", "list")
+	h.ok("tag remove", "tag", "remove", "ps-cli: Child", "backend")
+	h.ok("status set", "status", "set", "in-progress")
+	h.ok("status", "status")
+	h.ok("tree", "tree")
+	h.ok("up", "up")
+	h.ok("down", "down")
+	h.ok("stop", "stop")
+	h.ok("resume", "resume", "ps-cli: Child")
+	h.ok("unlink", "unlink", "ps-cli: Child")
+	h.ok("link", "link", "ps-cli: Child", "ps-cli: Parent")
+	h.ok("which", "which")
+
+	h.ok("signal create", "signal", "create", "build-done")
+	h.ok("signal list", "signal", "list")
+	h.ok("signal wait", "signal", "wait", "build-done", "--timeout", "1s")
+	h.ok("signal clear", "signal", "clear", "build-done")
+	h.ok("signal history", "signal", "history")
+	h.ok("signal send", "signal", "send", "jobs", "-m", "hello")
+	h.ok("signal recv", "signal", "recv", "jobs", "--consumer", "worker", "--max", "0")
+	h.ok("signal ack", "signal", "ack", "jobs", "1", "--consumer", "worker")
+	h.ok("signal queues", "signal", "queues")
+	h.ok("signal gc", "signal", "gc")
+
+	h.ok("config set", "config", "set", "warn_at", "40")
+	h.ok("config get", "config", "get", "warn_at")
+	h.ok("config list", "config", "list")
+
+	h.ok("handoff", "handoff", "ps-cli: Child", "--to", "reviewer", "-m", "ready")
+	h.ok("handoff accept", "handoff", "accept", "ps-cli: Child", "--team", "reviewer")
+
+	h.ok("stop", "stop")
+	h.ok("export", "export", "ps-cli: Child", "--format", "json", "--to", filepath.Join(t.TempDir(), "child.json"))
+	h.ok("rename", "rename", "child", "ps-cli: Kid")
+	h.ok("split", "split", "ps-cli: Kid", "--after", "1", "--into", "ps-cli: Kid later")
+	h.ok("merge-contexts", "merge-contexts", "ps-cli: Kid", "ps-cli: Kid later", "--into", "ps-cli: Merged")
+	h.ok("archive", "archive", "ps-cli: Merged")
+	h.ok("unarchive", "unarchive", "ps-cli: Merged")
+	h.ok("delete", "delete", "ps-cli: Merged", "--force")
+	h.ok("trash list", "trash", "list")
+	h.ok("undo", "undo", "--list")
+	h.ok("gc", "gc", "--dry-run")
+	h.ok("trash empty", "trash", "empty", "--force")
+}
+
+// TestJSONEnvelopeErrors checks the error envelope and exit codes
+func TestJSONEnvelopeErrors(t *testing.T) {
+	h := newJSONHarness(t)
+
+	// The request can't be carried out as given
+	h.fails("note", 1, "note")
+	h.fails("note", 1, "note", "no context yet")
+	h.fails("show", 1, "show")
+	h.fails("status set", 1, "status", "set")
+	h.fails("config get", 1, "config", "get", "colour")
+	h.fails("digest", 1, "digest", "--since", "last tuesday")
+	h.fails("timeline", 1, "timeline", "--day", "--week")
+	h.fails("ui", 1, "ui")
+	h.fails("completion", 1, "completion", "bash")
+	h.fails("delete", 1, "delete", "Missing")
+	h.fails("delete", 1, "delete", "Missing", "--force")
+	h.fails("signal wait", 1, "signal", "wait", "never", "--timeout", "1s")
+
+	// Flag errors are reported before the command runs, with the same envelope
+	h.fails("list", 1, "list", "--no-such-flag")
+
+	// Bad --set overrides fail instead of being dropped
+	h.fails("list", 1, "list", "--set", "bogus=1")
+	h.fails("list", 1, "list", "--set", "warn_at=abc")
+
+	// A rejected workflow transition
+	h.ok("start", "start", "Auth rework")
+	h.ok("status set", "status", "set", "in-progress")
+	h.fails("status set", 1, "status", "set", "archived")
+
+	// A partial undo reports what it undid but exits non-zero
+	envelope, exitCode := h.run("undo", "-n", "5")
+	assert.Equal(t, 2, exitCode, "undo -n 5: exit code")
+	assert.True(t, envelope.OK, "undo -n 5: ok")
+	assert.Len(t, envelope.Data["undone"], 2, "undo -n 5: undone")
+	assert.NotEmpty(t, envelope.Data["error"], "undo -n 5: error")
+}
@@ -25,7 +25,7 @@ func TestArchiveStoppedContext(t *testing.T) {
 	}
 
 	// Verify: is_archived flag in meta.json
-	metaPath := filepath.Join(testDir, contextName, "meta.json")
+	metaPath := filepath.Join(contextDirPath(t, testDir, contextName), "meta.json")
 	content, err := os.ReadFile(metaPath)
 	if err != nil {
 		t.Fatalf("Failed to read meta.json: %v", err)
@@ -142,7 +142,7 @@ func TestArchivePreservesData(t *testing.T) {
 	runCommand("stop")
 
 	// Get pre-archive data
-	notesPath := filepath.Join(testDir, contextName, "notes.log")
+	notesPath := filepath.Join(contextDirPath(t, testDir, contextName), "notes.log")
 	preArchiveNotes, _ := os.ReadFile(notesPath)
 
 	// Execute: Archive
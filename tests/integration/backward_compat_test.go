@@ -67,8 +67,8 @@ func TestSprint1ContextsDefaultToNotArchived(t *testing.T) {
 		t.Fatalf("Failed to archive Sprint 1 context: %v", err)
 	}
 
-	// Verify: is_archived field added
-	content, _ := os.ReadFile(metaPath)
+	// Verify: is_archived field added (the context now lives in its ID directory)
+	content, _ := os.ReadFile(filepath.Join(contextDirPath(t, testDir, contextName), "meta.json"))
 	var meta map[string]interface{}
 	json.Unmarshal(content, &meta)
 
@@ -161,7 +161,8 @@ func TestBackwardCompatibilityWithDataPreservation(t *testing.T) {
 		t.Error("Sprint 1 context should load successfully in Sprint 2")
 	}
 
-	// Verify: All data files still exist
+	// Verify: All data files still exist, moved to the context's ID directory
+	contextDir = contextDirPath(t, testDir, contextName)
 	for _, file := range []string{"meta.json", "notes.log", "files.log", "touches.log"} {
 		path := filepath.Join(contextDir, file)
 		if _, err := os.Stat(path); os.IsNotExist(err) {
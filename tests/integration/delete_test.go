@@ -16,6 +16,8 @@ func TestDeleteWithConfirmationAccept(t *testing.T) {
 	createTestContext(t, contextName)
 	runCommand("stop")
 
+	contextPath := contextDirPath(t, testDir, contextName)
+
 	// Simulate user accepting confirmation
 	err := runCommandWithInput("delete", contextName, "y\n")
 	if err != nil {
@@ -23,7 +25,6 @@ func TestDeleteWithConfirmationAccept(t *testing.T) {
 	}
 
 	// Verify: Context directory removed
-	contextPath := filepath.Join(testDir, contextName)
 	if _, err := os.Stat(contextPath); !os.IsNotExist(err) {
 		t.Error("Context directory should be removed after deletion")
 	}
@@ -47,7 +48,7 @@ func TestDeleteWithConfirmationCancel(t *testing.T) {
 	}
 
 	// Verify: Context still exists
-	contextPath := filepath.Join(testDir, contextName)
+	contextPath := contextDirPath(t, testDir, contextName)
 	if _, err := os.Stat(contextPath); os.IsNotExist(err) {
 		t.Error("Context should still exist after cancellation")
 	}
@@ -62,6 +63,8 @@ func TestDeleteWithForceFlag(t *testing.T) {
 	createTestContext(t, contextName)
 	runCommand("stop")
 
+	contextPath := contextDirPath(t, testDir, contextName)
+
 	// Execute: Delete with --force
 	err := runCommand("delete", contextName, "--force")
 	if err != nil {
@@ -69,7 +72,6 @@ func TestDeleteWithForceFlag(t *testing.T) {
 	}
 
 	// Verify: Context removed without prompting
-	contextPath := filepath.Join(testDir, contextName)
 	if _, err := os.Stat(contextPath); !os.IsNotExist(err) {
 		t.Error("Context should be removed with --force")
 	}
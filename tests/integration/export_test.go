@@ -159,7 +159,7 @@ func TestExportMarkdownFormat(t *testing.T) {
 	}
 }
 
-// TestExportJSONOutput tests JSON format output with --json flag
+// TestExportJSONOutput tests JSON format output with --format json
 func TestExportJSONOutput(t *testing.T) {
 	testDir := setupTestEnvironment(t)
 	defer cleanupTestEnvironment(t, testDir)
@@ -169,7 +169,7 @@ func TestExportJSONOutput(t *testing.T) {
 
 	// Execute: Export as JSON
 	outputPath := filepath.Join(testDir, contextName+".json")
-	err := runCommand("export", contextName, "--json", "--to", outputPath)
+	err := runCommand("export", contextName, "--format", "json", "--to", outputPath)
 	if err != nil {
 		t.Fatalf("JSON export failed: %v", err)
 	}
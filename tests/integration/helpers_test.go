@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"encoding/json"
 	"io"
 	"os"
 	"os/exec"
@@ -156,6 +157,26 @@ func cleanupTestEnvironment(t *testing.T, testDir string) {
 	// Note: os.Unsetenv not needed - t.Setenv handles cleanup automatically
 }
 
+// contextDirPath returns the directory of the named context, looked up in index.json
+func contextDirPath(t *testing.T, testDir, contextName string) string {
+	t.Helper()
+	var index struct {
+		Contexts map[string]string `json:"contexts"`
+	}
+	data, err := os.ReadFile(filepath.Join(testDir, "index.json"))
+	if err != nil {
+		t.Fatalf("Failed to read index.json: %v", err)
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("Failed to parse index.json: %v", err)
+	}
+	id, ok := index.Contexts[contextName]
+	if !ok {
+		t.Fatalf("Context %q is not in index.json", contextName)
+	}
+	return filepath.Join(testDir, id)
+}
+
 // createTestContext creates a test context directory structure
 func createTestContext(t *testing.T, contextName string) {
 	t.Helper()
@@ -39,7 +39,7 @@ func TestNoteWarningsIntegration(t *testing.T) {
 		}
 
 		// Check count
-		count, err := core.GetNoteCount(testName)
+		count, err := core.CountNotes(testName)
 		if err != nil {
 			t.Fatalf("Failed to get note count: %v", err)
 		}
@@ -53,7 +53,7 @@ func TestNoteWarningsIntegration(t *testing.T) {
 		}
 
 		// Check final count
-		finalCount, err := core.GetNoteCount(testName)
+		finalCount, err := core.CountNotes(testName)
 		if err != nil {
 			t.Fatalf("Failed to get final note count: %v", err)
 		}
@@ -92,7 +92,7 @@ func TestNoteWarningsIntegration(t *testing.T) {
 		}
 
 		// Verify count
-		count, err := core.GetNoteCount(testName)
+		count, err := core.CountNotes(testName)
 		if err != nil {
 			t.Fatalf("Failed to get note count: %v", err)
 		}
@@ -125,7 +125,7 @@ func TestNoteWarningsIntegration(t *testing.T) {
 		}
 
 		// Verify final count
-		count, err := core.GetNoteCount(testName)
+		count, err := core.CountNotes(testName)
 		if err != nil {
 			t.Fatalf("Failed to get final count: %v", err)
 		}
@@ -70,7 +70,7 @@ func TestSmartResume(t *testing.T) {
 		}
 
 		// Test note count
-		noteCount, err := core.GetNoteCount(testName)
+		noteCount, err := core.CountNotes(testName)
 		if err != nil {
 			t.Fatalf("Failed to get note count: %v", err)
 		}
@@ -180,7 +180,7 @@ func TestSmartResume(t *testing.T) {
 		}
 	})
 
-	t.Run("GetNoteCount counts notes correctly", func(t *testing.T) {
+	t.Run("CountNotes counts notes correctly", func(t *testing.T) {
 		testName := "note-count-test"
 
 		// Create context
@@ -198,7 +198,7 @@ func TestSmartResume(t *testing.T) {
 		}
 
 		// Check count
-		count, err := core.GetNoteCount(testName)
+		count, err := core.CountNotes(testName)
 		if err != nil {
 			t.Fatalf("Failed to get note count: %v", err)
 		}
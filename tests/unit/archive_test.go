@@ -6,13 +6,15 @@ import (
 	"testing"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/commands"
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
 )
 
-// TestGetEnvInt tests the getEnvInt helper function (already tested in note_test.go)
+// TestArchiveGetEnvInt tests the config.EnvInt helper (already tested in note_warnings_test.go)
 // This is a duplicate test for completeness
 func TestArchiveGetEnvInt(t *testing.T) {
 	// Test default values
-	if val := commands.GetEnvInt("NON_EXISTENT_VAR", 42); val != 42 {
+	if val := config.EnvInt("NON_EXISTENT_VAR", 42); val != 42 {
 		t.Errorf("Expected default value 42, got %d", val)
 	}
 
@@ -20,7 +22,7 @@ func TestArchiveGetEnvInt(t *testing.T) {
 	os.Setenv("TEST_ARCHIVE_VAR", "123")
 	defer os.Unsetenv("TEST_ARCHIVE_VAR")
 
-	if val := commands.GetEnvInt("TEST_ARCHIVE_VAR", 42); val != 123 {
+	if val := config.EnvInt("TEST_ARCHIVE_VAR", 42); val != 123 {
 		t.Errorf("Expected 123, got %d", val)
 	}
 
@@ -28,7 +30,7 @@ func TestArchiveGetEnvInt(t *testing.T) {
 	os.Setenv("TEST_ARCHIVE_INVALID", "not-a-number")
 	defer os.Unsetenv("TEST_ARCHIVE_INVALID")
 
-	if val := commands.GetEnvInt("TEST_ARCHIVE_INVALID", 42); val != 42 {
+	if val := config.EnvInt("TEST_ARCHIVE_INVALID", 42); val != 42 {
 		t.Errorf("Expected default value 42 for invalid env var, got %d", val)
 	}
 }
@@ -126,7 +128,7 @@ func TestParseDateString(t *testing.T) {
 func TestSafetyLimitEnforcement(t *testing.T) {
 	// Test default limit
 	os.Unsetenv("MC_BULK_LIMIT")
-	limit := commands.GetEnvInt("MC_BULK_LIMIT", 100)
+	limit := config.Get().BulkLimit
 	if limit != 100 {
 		t.Errorf("Expected default limit 100, got %d", limit)
 	}
@@ -135,15 +137,37 @@ func TestSafetyLimitEnforcement(t *testing.T) {
 	os.Setenv("MC_BULK_LIMIT", "50")
 	defer os.Unsetenv("MC_BULK_LIMIT")
 
-	limit = commands.GetEnvInt("MC_BULK_LIMIT", 100)
+	limit = config.Get().BulkLimit
 	if limit != 50 {
 		t.Errorf("Expected custom limit 50, got %d", limit)
 	}
 
 	// Test invalid limit (should return default)
 	os.Setenv("MC_BULK_LIMIT", "invalid")
-	limit = commands.GetEnvInt("MC_BULK_LIMIT", 100)
+	limit = config.Get().BulkLimit
 	if limit != 100 {
 		t.Errorf("Expected default limit 100 for invalid env var, got %d", limit)
 	}
 }
+
+// TestArchiveRefusesResumedContext tests that the context state.json names as active can't be archived
+func TestArchiveRefusesResumedContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContext("resumed"); err != nil {
+		t.Fatalf("Failed to create context: %v", err)
+	}
+	core.StopContext()
+	if err := core.ResumeContext("resumed"); err != nil {
+		t.Fatalf("ResumeContext failed: %v", err)
+	}
+
+	if err := core.ArchiveContext("resumed"); err == nil {
+		t.Error("Expected archiving the active context to fail")
+	}
+}
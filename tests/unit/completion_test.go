@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/commands"
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// TestCompletion tests the dynamic shell completions of context, tag and project names
+func TestCompletion(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContextWithMetadata("ps-cli: Auth", "", "", []string{"backend", "auth"}); err != nil {
+		t.Fatalf("CreateContextWithMetadata failed: %v", err)
+	}
+	if _, _, err := core.CreateContext("garden"); err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	core.StopContext()
+	if err := core.ArchiveContext("garden"); err != nil {
+		t.Fatalf("ArchiveContext failed: %v", err)
+	}
+	if _, _, err := core.CreateContext("ps-cli: Parser"); err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+
+	jsonOutput := false
+	complete := func(cmd *cobra.Command, args ...string) []string {
+		t.Helper()
+		completions, directive := cmd.ValidArgsFunction(cmd, args, "")
+		if directive&cobra.ShellCompDirectiveNoFileComp == 0 {
+			t.Errorf("%s: expected file completion to be off", cmd.Name())
+		}
+		var names []string
+		for _, completion := range completions {
+			names = append(names, completionValue(completion))
+		}
+		return names
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		// Parser is active, so only Auth and the archived garden are stopped
+		{"resume", complete(commands.NewResumeCmd(&jsonOutput)), []string{"garden", "ps-cli: Auth"}},
+		{"archive", complete(commands.NewArchiveCmd(&jsonOutput)), []string{"ps-cli: Auth"}},
+		{"unarchive", complete(commands.NewUnarchiveCmd(&jsonOutput)), []string{"garden"}},
+		{"show after its argument", complete(commands.NewShowCmd(&jsonOutput), "garden"), nil},
+		{"merge skips given contexts", complete(commands.NewMergeContextsCmd(&jsonOutput), "garden"), []string{"ps-cli: Auth", "ps-cli: Parser"}},
+	}
+	for _, tt := range tests {
+		sort.Strings(tt.got)
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+
+	tag := commands.NewTagCmd(&jsonOutput)
+	for _, sub := range tag.Commands() {
+		switch sub.Name() {
+		case "add":
+			if got := complete(sub, "ps-cli: Parser"); !reflect.DeepEqual(got, []string{"auth", "backend"}) {
+				t.Errorf("tag add: expected all tags, got %v", got)
+			}
+			if got := complete(sub, "ps-cli: Auth"); len(got) != 0 {
+				t.Errorf("tag add: expected no tags the context already has, got %v", got)
+			}
+		case "remove":
+			if got := complete(sub, "ps-cli: Auth", "backend"); !reflect.DeepEqual(got, []string{"auth"}) {
+				t.Errorf("tag remove: expected the remaining tag, got %v", got)
+			}
+		}
+	}
+
+	list := commands.NewListCmd(&jsonOutput)
+	projectCompletion, ok := list.GetFlagCompletionFunc("project")
+	if !ok {
+		t.Fatal("Expected list --project to have completion")
+	}
+	projects, _ := projectCompletion(list, nil, "")
+	var projectNames []string
+	for _, completion := range projects {
+		projectNames = append(projectNames, completionValue(completion))
+	}
+	if !reflect.DeepEqual(projectNames, []string{"garden", "ps-cli"}) {
+		t.Errorf("Expected projects garden and ps-cli, got %v", projectNames)
+	}
+}
+
+// completionValue strips the description from a completion
+func completionValue(completion string) string {
+	value, _, _ := strings.Cut(completion, "\t")
+	return value
+}
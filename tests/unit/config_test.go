@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+)
+
+// TestConfigPrecedence tests flag > env > project > home > default resolution
+func TestConfigPrecedence(t *testing.T) {
+	home := t.TempDir()
+	os.Unsetenv("MC_WARN_AT")
+
+	cfg, err := config.Load(home, "ps-cli", nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	assertConfigValue(t, cfg, "warn_at", "50", config.SourceDefault)
+
+	if err := cfg.Set("warn_at", "40", ""); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	assertConfigValue(t, cfg, "warn_at", "40", config.SourceHome)
+
+	if err := cfg.Set("warn_at", "30", "PS-CLI"); err != nil {
+		t.Fatalf("Set for project failed: %v", err)
+	}
+	assertConfigValue(t, cfg, "warn_at", "30", config.SourceProject)
+	assertConfigValue(t, cfg.WithProject("garden"), "warn_at", "40", config.SourceHome)
+
+	os.Setenv("MC_WARN_AT", "20")
+	defer os.Unsetenv("MC_WARN_AT")
+	assertConfigValue(t, cfg, "warn_at", "20", config.SourceEnv)
+
+	// Invalid environment values fall through to the next layer
+	os.Setenv("MC_WARN_AT", "lots")
+	assertConfigValue(t, cfg, "warn_at", "30", config.SourceProject)
+
+	// Values persist in config.yaml and flags win over everything
+	reloaded, err := config.Load(home, "ps-cli", []string{"warn_at=10"})
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	assertConfigValue(t, reloaded, "warn_at", "10", config.SourceFlag)
+
+	settings := reloaded.Settings()
+	if settings.WarnAt != 10 || settings.BulkLimit != 100 || settings.TimestampFormat != "iso" {
+		t.Errorf("Unexpected typed settings: %+v", settings)
+	}
+}
+
+// TestConfigValidation tests rejection of unknown keys and mistyped values
+func TestConfigValidation(t *testing.T) {
+	home := t.TempDir()
+
+	cfg, err := config.Load(home, "", nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := cfg.Set("bulk_limit", "many", ""); err == nil {
+		t.Error("Expected non-integer bulk_limit to be rejected")
+	}
+	if err := cfg.Set("colour", "blue", ""); err == nil {
+		t.Error("Expected unknown key to be rejected")
+	}
+	if _, err := config.Load(home, "", []string{"bulk_limit"}); err == nil {
+		t.Error("Expected --set without '=' to be rejected")
+	}
+
+	// The edit template is valid YAML that sets nothing
+	if err := os.WriteFile(config.Path(home), []byte(config.Template()), 0o600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	cfg, err = config.Load(home, "", nil)
+	if err != nil {
+		t.Fatalf("Template failed to load: %v", err)
+	}
+	assertConfigValue(t, cfg, "timestamp_format", "iso", config.SourceDefault)
+
+	if err := os.WriteFile(config.Path(home), []byte("warn_at: [1, 2\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := config.Load(home, "", nil); err == nil {
+		t.Error("Expected malformed config.yaml to be reported")
+	}
+}
+
+func assertConfigValue(t *testing.T, cfg *config.Config, key, want, source string) {
+	t.Helper()
+	value, err := cfg.Lookup(key)
+	if err != nil {
+		t.Fatalf("Lookup(%s) failed: %v", key, err)
+	}
+	if value.Value != want || value.Source != source {
+		t.Errorf("Lookup(%s) = %s (%s), want %s (%s)", key, value.Value, value.Source, want, source)
+	}
+}
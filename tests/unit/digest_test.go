@@ -0,0 +1,269 @@
+package unit
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// writeTransitions replaces the transitions log with the given lines
+func writeTransitions(t *testing.T, lines ...string) {
+	t.Helper()
+	if err := os.WriteFile(core.GetTransitionsLogPath(), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write transitions: %v", err)
+	}
+}
+
+// TestGetSessions tests that sessions are rebuilt from start, switch, stop and rename transitions
+func TestGetSessions(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	writeTransitions(t,
+		"2025-10-20T09:00:00Z|NULL|api|start",
+		"2025-10-20T10:00:00Z|api|web|switch",
+		"2025-10-20T10:30:00Z|web|NULL|stop",
+		"2025-10-20T11:00:00Z|web|web-v2|rename",
+		"2025-10-20T13:00:00Z|NULL|web-v2|start",
+	)
+
+	sessions, err := core.GetSessions()
+	if err != nil {
+		t.Fatalf("GetSessions failed: %v", err)
+	}
+	if len(sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d: %+v", len(sessions), sessions)
+	}
+	if sessions[0].Context != "api" || sessions[0].Duration() != time.Hour {
+		t.Errorf("Unexpected first session: %+v", sessions[0])
+	}
+	if sessions[1].Context != "web-v2" || sessions[1].Duration() != 30*time.Minute {
+		t.Errorf("Expected renamed session of 30m, got %+v", sessions[1])
+	}
+	if !sessions[2].Ongoing {
+		t.Errorf("Expected last session to be ongoing, got %+v", sessions[2])
+	}
+
+	// Clipping keeps only the part inside the window
+	from := time.Date(2025, 10, 20, 9, 30, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	clipped, err := core.GetSessionsBetween(from, to)
+	if err != nil {
+		t.Fatalf("GetSessionsBetween failed: %v", err)
+	}
+	if len(clipped) != 2 || clipped[0].Duration() != 30*time.Minute || !clipped[0].Start.Equal(from) {
+		t.Errorf("Unexpected clipped sessions: %+v", clipped)
+	}
+}
+
+// TestParseSince tests the keywords, durations and dates accepted by --since
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 10, 22, 15, 30, 0, 0, time.UTC)
+	midnight := time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"today", midnight},
+		{"yesterday", midnight.AddDate(0, 0, -1)},
+		{"week", midnight.AddDate(0, 0, -6)},
+		{"3d", now.AddDate(0, 0, -3)},
+		{"36h", now.Add(-36 * time.Hour)},
+		{"2025-10-01", time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-10-01T08:00:00Z", time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := core.ParseSince(tt.value, now)
+		if err != nil {
+			t.Errorf("ParseSince(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, err := core.ParseSince("last tuesday", now); err == nil {
+		t.Error("Expected an error for an unknown --since value")
+	}
+}
+
+// TestBuildDigest tests that the digest groups time, notes and changes by project and context
+func TestBuildDigest(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: Auth")
+	core.AddNote("wired token refresh")
+	core.CreateContext("ps-cli: Docs")
+	core.CreateContext("garden")
+	core.AddNote("ordered seeds")
+	core.StopContext()
+
+	// Replace the recorded history with known times
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(minutesAgo int) string {
+		return now.Add(-time.Duration(minutesAgo) * time.Minute).Format(time.RFC3339)
+	}
+	writeTransitions(t,
+		at(300)+"|NULL|ps-cli: Auth|start",
+		at(180)+"|ps-cli: Auth|garden|switch",
+		at(150)+"|garden|NULL|stop",
+	)
+
+	from, to := now.Add(-4*time.Hour), now.Add(time.Minute)
+	digest, err := core.BuildDigest(from, to, "")
+	if err != nil {
+		t.Fatalf("BuildDigest failed: %v", err)
+	}
+	if digest.TimeSpent != int((90 * time.Minute).Seconds()) {
+		t.Errorf("Expected 1h 30m in the window, got %ds", digest.TimeSpent)
+	}
+	if digest.NoteCount != 2 {
+		t.Errorf("Expected 2 notes, got %d", digest.NoteCount)
+	}
+	if len(digest.Projects) != 2 || digest.Projects[0].Name != "ps-cli" {
+		t.Fatalf("Expected ps-cli first of 2 projects, got %+v", digest.Projects)
+	}
+
+	// ps-cli: Docs has no activity left in the window
+	psCli := digest.Projects[0]
+	if len(psCli.Contexts) != 1 || psCli.Contexts[0].Name != "ps-cli: Auth" {
+		t.Fatalf("Unexpected ps-cli contexts: %+v", psCli.Contexts)
+	}
+	if psCli.Contexts[0].TimeSpent != int(time.Hour.Seconds()) {
+		t.Errorf("Expected 1h in ps-cli: Auth, got %ds", psCli.Contexts[0].TimeSpent)
+	}
+
+	var stopped bool
+	for _, change := range digest.Projects[1].Contexts[0].StateChanges {
+		if change.Change == "stopped" {
+			stopped = true
+		}
+	}
+	if !stopped {
+		t.Errorf("Expected garden to be reported as stopped: %+v", digest.Projects[1].Contexts[0].StateChanges)
+	}
+
+	markdown := output.FormatDigestMarkdown(digest)
+	for _, want := range []string{"## ps-cli (1h 0m)", "### garden (30m)", "wired token refresh"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected markdown to contain %q:\n%s", want, markdown)
+		}
+	}
+
+	// --project keeps only that project
+	digest, err = core.BuildDigest(from, to, "garden")
+	if err != nil {
+		t.Fatalf("BuildDigest with project failed: %v", err)
+	}
+	if len(digest.Projects) != 1 || digest.Projects[0].Name != "garden" {
+		t.Errorf("Expected only garden, got %+v", digest.Projects)
+	}
+}
+
+// TestBuildDigestReusedName tests that a context reusing a renamed context's old name keeps its own changes
+func TestBuildDigestReusedName(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("api-v2")
+	core.CreateContext("api")
+	core.StopContext()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(minutesAgo int) string {
+		return now.Add(-time.Duration(minutesAgo) * time.Minute).Format(time.RFC3339)
+	}
+	writeTransitions(t,
+		at(200)+"|NULL|api|start",
+		at(190)+"|api|NULL|stop",
+		at(180)+"|api|api-v2|rename",
+		at(100)+"|NULL|api|start",
+		at(90)+"|api|NULL|stop",
+	)
+
+	digest, err := core.BuildDigest(now.Add(-4*time.Hour), now.Add(time.Minute), "")
+	if err != nil {
+		t.Fatalf("BuildDigest failed: %v", err)
+	}
+
+	changes := make(map[string]int)
+	for _, p := range digest.Projects {
+		for _, ctx := range p.Contexts {
+			changes[ctx.Name] = len(ctx.StateChanges)
+			if ctx.TimeSpent != int((10 * time.Minute).Seconds()) {
+				t.Errorf("Expected 10m in %s, got %ds", ctx.Name, ctx.TimeSpent)
+			}
+		}
+	}
+	if changes["api-v2"] != 3 || changes["api"] != 2 {
+		t.Errorf("Expected start, stop and rename for api-v2 and start, stop for api, got %v", changes)
+	}
+}
+
+// TestBuildDigestGoneContexts tests that trashed and deleted contexts keep the time logged for them
+func TestBuildDigestGoneContexts(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: spike")
+	core.StopContext()
+	if _, err := core.TrashContext("ps-cli: spike", "delete"); err != nil {
+		t.Fatalf("TrashContext failed: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(minutesAgo int) string {
+		return now.Add(-time.Duration(minutesAgo) * time.Minute).Format(time.RFC3339)
+	}
+	writeTransitions(t,
+		at(100)+"|NULL|ps-cli: spike|start",
+		at(80)+"|ps-cli: spike|ps-cli: gone|switch",
+		at(50)+"|ps-cli: gone|NULL|stop",
+		at(40)+"|NULL|other: work|start",
+		at(30)+"|other: work|NULL|stop",
+	)
+
+	digest, err := core.BuildDigest(now.Add(-2*time.Hour), now.Add(time.Minute), "ps-cli")
+	if err != nil {
+		t.Fatalf("BuildDigest failed: %v", err)
+	}
+	if len(digest.Projects) != 1 || digest.TimeSpent != int((50*time.Minute).Seconds()) {
+		t.Fatalf("Expected 50m in ps-cli only, got %+v", digest)
+	}
+
+	status := make(map[string]string)
+	spent := make(map[string]int)
+	for _, ctx := range digest.Projects[0].Contexts {
+		status[ctx.Name] = ctx.Status
+		spent[ctx.Name] = ctx.TimeSpent
+	}
+	if status["ps-cli: spike"] != "trashed" || spent["ps-cli: spike"] != int((20*time.Minute).Seconds()) {
+		t.Errorf("Expected 20m in the trashed spike, got %q %ds", status["ps-cli: spike"], spent["ps-cli: spike"])
+	}
+	if status["ps-cli: gone"] != "deleted" || spent["ps-cli: gone"] != int((30*time.Minute).Seconds()) {
+		t.Errorf("Expected 30m in the deleted context, got %q %ds", status["ps-cli: gone"], spent["ps-cli: gone"])
+	}
+}
@@ -0,0 +1,311 @@
+package unit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// TestExportFormats tests that every registered exporter writes a file for one context
+func TestExportFormats(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: Export")
+	core.AddNote(`Use <b>bold</b>, "quotes" and commas`)
+	core.AddFile("main.go")
+	core.AddTouch()
+	core.StopContext()
+
+	want := map[string]string{
+		"markdown": "# Context: ps-cli: Export",
+		"json":     `"touch_count": 1`,
+		"html":     "&lt;b&gt;bold&lt;/b&gt;",
+		"org":      "#+TITLE: Context: ps-cli: Export",
+		"csv":      "context,parent,type,timestamp,text,note_type,done",
+		"jsonl":    `"type":"note"`,
+	}
+
+	for _, format := range output.ExporterNames() {
+		expected, ok := want[format]
+		if !ok {
+			t.Errorf("No expectation for registered format %q", format)
+			continue
+		}
+
+		exporter, _ := output.GetExporter(format)
+		path, err := core.ExportContext("ps-cli: Export", filepath.Join(tempDir, "out", "export-"+format), exporter)
+		if err != nil {
+			t.Fatalf("ExportContext(%s) failed: %v", format, err)
+		}
+		content, _ := os.ReadFile(path)
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("%s export missing %q:\n%s", format, expected, content)
+		}
+	}
+
+	if _, err := output.GetExporter("MD"); err != nil {
+		t.Errorf("Expected the md alias to resolve case-insensitively: %v", err)
+	}
+	if _, err := output.GetExporter("pdf"); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+// TestExportEventRows tests the one-row-per-event CSV and JSONL layouts
+func TestExportEventRows(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("Events")
+	core.AddNote("first, with a comma")
+	core.AddNote("second")
+	core.StopContext()
+
+	csvExporter, _ := output.GetExporter("csv")
+	path, err := core.ExportContext("Events", filepath.Join(tempDir, "events.csv"), csvExporter)
+	if err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	f, _ := os.Open(path)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV export is not valid CSV: %v", err)
+	}
+
+	var types []string
+	for _, row := range rows[1:] {
+		types = append(types, row[2])
+	}
+	if got := strings.Join(types, ","); got != "start,note,note,stop" {
+		t.Errorf("Expected start,note,note,stop rows, got %s", got)
+	}
+	if rows[2][4] != "first, with a comma" {
+		t.Errorf("Expected note text in the text column, got %q", rows[2][4])
+	}
+
+	jsonlExporter, _ := output.GetExporter("jsonl")
+	path, err = core.ExportContext("Events", filepath.Join(tempDir, "events.jsonl"), jsonlExporter)
+	if err != nil {
+		t.Fatalf("JSONL export failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 JSONL lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		var event output.ExportEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil || event.Context != "Events" {
+			t.Errorf("Invalid JSONL line %q: %v", line, err)
+		}
+	}
+}
+
+// TestExportCombined tests writing every context into one document with a table of contents
+func TestExportCombined(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("Alpha")
+	core.CreateContext("Beta")
+	core.StopContext()
+
+	htmlExporter, _ := output.GetExporter("html")
+	path, err := core.ExportCombined(filepath.Join(tempDir, "all.html"), htmlExporter)
+	if err != nil {
+		t.Fatalf("ExportCombined failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	for _, want := range []string{`<a href="#alpha">Alpha</a>`, `<section id="beta">`, "Contents"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Combined HTML missing %q", want)
+		}
+	}
+
+	jsonExporter, _ := output.GetExporter("json")
+	path, err = core.ExportCombined(filepath.Join(tempDir, "all.json"), jsonExporter)
+	if err != nil {
+		t.Fatalf("Combined JSON export failed: %v", err)
+	}
+	var combined struct {
+		Contexts []output.ExportData `json:"contexts"`
+	}
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &combined); err != nil {
+		t.Fatalf("Combined JSON is invalid: %v", err)
+	}
+	if len(combined.Contexts) != 2 || combined.Contexts[0].Name != "Alpha" {
+		t.Errorf("Expected Alpha then Beta, got %+v", combined.Contexts)
+	}
+}
+
+// TestExportTemplates tests rendering with built-in, user and file templates
+func TestExportTemplates(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("Sprint 3")
+	core.AddNote("planned the sprint")
+	core.CreateContextWithMetadata("Login", "", "Sprint 3", nil)
+	core.AddNote("fixed the form")
+	core.StopContext()
+
+	// Built-in templates see children and rolled-up counts
+	exporter, err := core.GetExporter("", "retro")
+	if err != nil {
+		t.Fatalf("Built-in retro template not found: %v", err)
+	}
+	if exporter.Extension() != ".md" {
+		t.Errorf("Expected retro to write .md files, got %s", exporter.Extension())
+	}
+	path, err := core.ExportContext("Sprint 3", filepath.Join(tempDir, "retro.md"), exporter)
+	if err != nil {
+		t.Fatalf("Template export failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	for _, want := range []string{"# Retrospective: Sprint 3", "| Notes | 1 (2 including subtasks) |", "**Login**"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Retro export missing %q:\n%s", want, content)
+		}
+	}
+
+	// A user template overrides the built-in one with the same name
+	userDir := filepath.Join(tempDir, output.TemplateDir)
+	os.MkdirAll(userDir, 0o755)
+	os.WriteFile(filepath.Join(userDir, "standup.txt.tmpl"), []byte("{{.Context.Name}} has {{len .Notes}} notes\n"), 0o644)
+
+	exporter, err = core.GetExporter("", "standup")
+	if err != nil {
+		t.Fatalf("User standup template not found: %v", err)
+	}
+	path, _ = core.ExportContext("Login", filepath.Join(tempDir, "standup.txt"), exporter)
+	content, _ = os.ReadFile(path)
+	if string(content) != "Login has 1 notes\n" {
+		t.Errorf("Expected the user template output, got %q", content)
+	}
+
+	var sources []string
+	for _, tmpl := range output.ListTemplates(tempDir) {
+		sources = append(sources, tmpl.Name+"="+tmpl.Source)
+	}
+	if got := strings.Join(sources, ","); got != "pr=builtin,retro=builtin,standup=user" {
+		t.Errorf("Unexpected template list: %s", got)
+	}
+
+	// Templates can also be given as a path, and parse errors are reported
+	bad := filepath.Join(tempDir, "bad.tmpl")
+	os.WriteFile(bad, []byte("{{.Context.Name"), 0o644)
+	if _, err := core.GetExporter("", bad); err == nil {
+		t.Error("Expected a parse error for a malformed template")
+	}
+	if _, err := core.GetExporter("", "missing"); err == nil {
+		t.Error("Expected an error for an unknown template")
+	}
+}
+
+// TestExportFooterVersion tests that markdown exports name the running version
+func TestExportFooterVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	originalVersion := output.Version
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+		output.Version = originalVersion
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+	output.Version = "3.4.5"
+
+	core.CreateContext("Footer")
+	exporter, _ := output.GetExporter("markdown")
+	path, err := core.ExportContext("Footer", filepath.Join(tempDir, "footer.md"), exporter)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "*Exported from my-context v3.4.5*") {
+		t.Errorf("Expected footer with v3.4.5, got:\n%s", content)
+	}
+}
+
+// TestExportTree tests exporting a context hierarchy as one document with rolled-up totals
+func TestExportTree(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("Sprint 3")
+	core.AddNote("planned")
+	core.CreateContextWithMetadata("Login", "", "Sprint 3", nil)
+	core.AddNote("form")
+	core.CreateContextWithMetadata("Login emails", "", "Login", nil)
+	core.AddNote("smtp")
+	core.AddNote("templates")
+	core.CreateContext("Unrelated")
+	core.AddNote("not in the tree")
+	core.StopContext()
+
+	jsonExporter, _ := output.GetExporter("json")
+	path, err := core.ExportTree("Sprint 3", filepath.Join(tempDir, "tree.json"), jsonExporter)
+	if err != nil {
+		t.Fatalf("ExportTree failed: %v", err)
+	}
+	var root output.ExportTreeData
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &root); err != nil {
+		t.Fatalf("Tree JSON is invalid: %v", err)
+	}
+	if root.TotalNotes != 4 || len(root.Children) != 1 {
+		t.Fatalf("Expected 4 notes under one child, got %d under %d", root.TotalNotes, len(root.Children))
+	}
+	login := root.Children[0]
+	if login.Name != "Login" || login.TotalNotes != 3 || len(login.Children) != 1 {
+		t.Errorf("Expected Login with 3 notes and one child, got %s with %d and %d", login.Name, login.TotalNotes, len(login.Children))
+	}
+	if root.TotalDuration < login.TotalDuration {
+		t.Errorf("Expected the root total duration to include its children")
+	}
+
+	// Every format renders the whole tree and nothing outside it
+	for _, format := range output.ExporterNames() {
+		exporter, _ := output.GetExporter(format)
+		path, err := core.ExportTree("Sprint 3", filepath.Join(tempDir, "tree", "sprint."+format), exporter)
+		if err != nil {
+			t.Fatalf("%s tree export failed: %v", format, err)
+		}
+		content, _ := os.ReadFile(path)
+		if !strings.Contains(string(content), "Login emails") || strings.Contains(string(content), "Unrelated") {
+			t.Errorf("%s tree export doesn't cover exactly the tree:\n%s", format, content)
+		}
+	}
+
+	if _, err := core.ExportTree("Missing", "", jsonExporter); err == nil {
+		t.Error("Expected an error for an unknown root")
+	}
+}
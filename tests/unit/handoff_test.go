@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/signal"
+	pkgmodels "github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// TestHandoffAndAccept tests the full handoff round trip between two teams
+func TestHandoffAndAccept(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("final-completion")
+	if _, err := core.AddFile("README.md"); err != nil {
+		t.Fatalf("Failed to add file: %v", err)
+	}
+
+	result, err := core.HandoffContext("final-completion", core.HandoffOptions{
+		To:        "deb-sanity",
+		From:      "my-context",
+		Summary:   "Sprint 004 done",
+		Questions: []string{"Does install work on 22.04?"},
+	})
+	if err != nil {
+		t.Fatalf("HandoffContext failed: %v", err)
+	}
+	if !result.WasActive {
+		t.Error("Expected handoff to stop the active context")
+	}
+
+	// Context is stopped, meta.json holds the handoff and a structured note was written
+	state, _ := core.GetActiveContext()
+	if state.HasActiveContext() {
+		t.Error("Expected no active context after handoff")
+	}
+	ctx, notes, _, _, err := core.GetContextWithMetadata("final-completion")
+	if err != nil {
+		t.Fatalf("Failed to load context: %v", err)
+	}
+	if !ctx.Metadata.Handoff.IsPending() || ctx.Metadata.Handoff.To != "deb-sanity" {
+		t.Errorf("Expected pending handoff to deb-sanity, got %+v", ctx.Metadata.Handoff)
+	}
+	if len(ctx.Metadata.Handoff.Files) != 1 {
+		t.Errorf("Expected the associated file in the handoff, got %v", ctx.Metadata.Handoff.Files)
+	}
+	if len(notes) != 1 || !strings.HasPrefix(notes[0].TextContent, "HANDOFF TO deb-sanity") ||
+		!strings.Contains(notes[0].TextContent, "Does install work on 22.04?") {
+		t.Errorf("Expected structured handoff note, got %v", notes)
+	}
+
+	manager, err := signal.NewManager(core.GetSignalsDir())
+	if err != nil {
+		t.Fatalf("Failed to create signal manager: %v", err)
+	}
+	if !manager.SignalExists(core.HandoffSignalName("deb-sanity", "final-completion")) {
+		t.Error("Expected handoff signal to be raised")
+	}
+
+	// A second handoff waits for the first to be accepted
+	if _, err := core.HandoffContext("final-completion", core.HandoffOptions{To: "other"}); err == nil {
+		t.Error("Expected handoff of a pending context to fail")
+	}
+
+	pending, err := core.PendingHandoffs("deb-sanity")
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("Expected one pending handoff, got %d (%v)", len(pending), err)
+	}
+
+	// The receiving side is working on something else
+	core.CreateContext("deb-sanity: triage")
+
+	accepted, err := core.AcceptHandoff("final-completion", "bob")
+	if err != nil {
+		t.Fatalf("AcceptHandoff failed: %v", err)
+	}
+	if accepted.Superseded != "deb-sanity: triage" {
+		t.Errorf("Expected triage context to be stopped, got %q", accepted.Superseded)
+	}
+
+	state, _ = core.GetActiveContext()
+	if state.GetActiveContextName() != "final-completion" {
+		t.Errorf("Expected final-completion to be active, got %q", state.GetActiveContextName())
+	}
+	ctx, _, _, _, _ = core.GetContextWithMetadata("final-completion")
+	if ctx.Metadata.Handoff.Status != pkgmodels.HandoffAccepted || ctx.Metadata.Handoff.AcceptedBy != "bob" {
+		t.Errorf("Expected accepted handoff, got %+v", ctx.Metadata.Handoff)
+	}
+	if manager.SignalExists(core.HandoffSignalName("deb-sanity", "final-completion")) {
+		t.Error("Expected handoff signal to be cleared on accept")
+	}
+	if !manager.SignalExists(core.HandoffAcceptedSignalName("final-completion")) {
+		t.Error("Expected acknowledgement signal on accept")
+	}
+
+	if _, err := core.AcceptHandoff("final-completion", "bob"); err == nil {
+		t.Error("Expected accepting twice to fail")
+	}
+
+	// Undo puts the handoff back and re-activates the superseded context
+	entry, err := core.UndoLast()
+	if err != nil || entry.Op != core.JournalOpHandoffAccept {
+		t.Fatalf("Expected to undo the accept, got %v (%v)", entry, err)
+	}
+	state, _ = core.GetActiveContext()
+	if state.GetActiveContextName() != "deb-sanity: triage" {
+		t.Errorf("Expected deb-sanity: triage to be active again, got %q", state.GetActiveContextName())
+	}
+	ctx, _, _, _, _ = core.GetContextWithMetadata("final-completion")
+	if !ctx.Metadata.Handoff.IsPending() {
+		t.Errorf("Expected the handoff to be pending again, got %+v", ctx.Metadata.Handoff)
+	}
+}
@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+)
+
+// TestExportICS tests that each session becomes a VEVENT described with the notes written during it
+func TestExportICS(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: Auth")
+	core.CreateContext("garden")
+	core.StopContext()
+
+	// Two sessions of ps-cli: Auth around one of garden, with a note in each
+	writeTransitions(t,
+		"2025-10-20T09:00:00Z|NULL|ps-cli: Auth|start",
+		"2025-10-20T10:00:00Z|ps-cli: Auth|garden|switch",
+		"2025-10-20T11:00:00Z|garden|ps-cli: Auth|switch",
+		"2025-10-20T12:00:00Z|ps-cli: Auth|NULL|stop",
+	)
+	notes := "2025-10-20T09:30:00Z|designed token refresh; see RFC 6749, section 6\n" +
+		"2025-10-20T11:15:00Z|wired it up\n"
+	if err := os.WriteFile(core.GetNotesLogPath("ps-cli: Auth"), []byte(notes), 0644); err != nil {
+		t.Fatalf("Failed to write notes: %v", err)
+	}
+
+	events, err := core.GetCalendarEvents(time.Time{}, "ps-cli")
+	if err != nil {
+		t.Fatalf("GetCalendarEvents failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 ps-cli sessions, got %d", len(events))
+	}
+	if len(events[0].Notes) != 1 || !strings.HasPrefix(events[0].Notes[0].TextContent, "designed") {
+		t.Errorf("Expected the 09:30 note in the first session, got %+v", events[0].Notes)
+	}
+	if len(events[1].Notes) != 1 || events[1].Notes[0].TextContent != "wired it up" {
+		t.Errorf("Expected the 11:15 note in the second session, got %+v", events[1].Notes)
+	}
+
+	// --since drops sessions that ended before it
+	since := time.Date(2025, 10, 20, 10, 30, 0, 0, time.UTC)
+	events, err = core.GetCalendarEvents(since, "")
+	if err != nil {
+		t.Fatalf("GetCalendarEvents with since failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Session.Context != "garden" {
+		t.Errorf("Expected garden and the second ps-cli session, got %+v", events)
+	}
+
+	path := filepath.Join(tempDir, "out", "sessions.ics")
+	written, count, err := core.ExportICS(time.Time{}, "", path)
+	if err != nil {
+		t.Fatalf("ExportICS failed: %v", err)
+	}
+	if written != path || count != 3 {
+		t.Errorf("Expected 3 events in %s, got %d in %s", path, count, written)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read ics: %v", err)
+	}
+	ics := string(content)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20251020T090000Z\r\nDTEND:20251020T100000Z\r\nSUMMARY:ps-cli: Auth\r\n",
+		"SUMMARY:garden\r\n",
+		`see RFC 6749\, section 6`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("Expected ics to contain %q:\n%s", want, ics)
+		}
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 3 {
+		t.Errorf("Expected 3 VEVENTs:\n%s", ics)
+	}
+	for _, line := range strings.Split(ics, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Expected lines folded at 75 octets, got %d: %q", len(line), line)
+		}
+	}
+}
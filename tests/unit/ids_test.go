@@ -0,0 +1,159 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+)
+
+// TestContextIDs tests that contexts keep a stable ID that names their directory
+func TestContextIDs(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	colon, _, err := core.CreateContext("a:b")
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	underscore, _, err := core.CreateContext("a_b")
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	core.StopContext()
+
+	t.Run("names that sanitize alike don't collide", func(t *testing.T) {
+		if underscore.Name != "a_b" {
+			t.Errorf("Expected a_b to keep its name, got %q", underscore.Name)
+		}
+		if colon.ID == "" || colon.ID == underscore.ID {
+			t.Errorf("Expected distinct IDs, got %q and %q", colon.ID, underscore.ID)
+		}
+		if got := core.GetContextDir(colon.ID); colon.SubdirectoryPath != got {
+			t.Errorf("Expected directory %s, got %s", got, colon.SubdirectoryPath)
+		}
+	})
+
+	t.Run("resolve by ID", func(t *testing.T) {
+		if got := core.ContextNameOrID(colon.ID); got != "a:b" {
+			t.Errorf("Expected a:b for its ID, got %q", got)
+		}
+		if ctx, err := core.ResolveContext(underscore.ID, nil); err != nil || ctx.Name != "a_b" {
+			t.Errorf("Expected a_b, got %v (%v)", ctx, err)
+		}
+	})
+
+	t.Run("rename keeps the ID and directory", func(t *testing.T) {
+		if _, err := core.RenameContext("a:b", "renamed"); err != nil {
+			t.Fatalf("RenameContext failed: %v", err)
+		}
+		ctx, err := core.LoadContext("renamed")
+		if err != nil {
+			t.Fatalf("LoadContext failed: %v", err)
+		}
+		if ctx.ID != colon.ID || ctx.SubdirectoryPath != colon.SubdirectoryPath {
+			t.Errorf("Expected ID %s in %s, got %s in %s", colon.ID, colon.SubdirectoryPath, ctx.ID, ctx.SubdirectoryPath)
+		}
+		if _, err := core.GetContextID("a:b"); err == nil {
+			t.Error("Expected the old name to be gone from the index")
+		}
+	})
+
+	t.Run("trash and restore keep the ID", func(t *testing.T) {
+		if _, err := core.TrashContext("a_b", "test"); err != nil {
+			t.Fatalf("TrashContext failed: %v", err)
+		}
+		if core.FileExists(underscore.SubdirectoryPath) {
+			t.Error("Expected the directory to move to the trash")
+		}
+		if _, err := core.RestoreFromTrash("a_b"); err != nil {
+			t.Fatalf("RestoreFromTrash failed: %v", err)
+		}
+		if id, err := core.GetContextID("a_b"); err != nil || id != underscore.ID {
+			t.Errorf("Expected ID %s after restore, got %q (%v)", underscore.ID, id, err)
+		}
+	})
+}
+
+// TestContextDirMigration tests that directories named after their context move to ID directories
+func TestContextDirMigration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	legacyDir := filepath.Join(tempDir, "legacy_work")
+	if err := os.MkdirAll(legacyDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"name":"legacy: work","start_time":"2025-10-05T14:30:00Z","status":"stopped","custom":"kept"}`
+	os.WriteFile(filepath.Join(legacyDir, "meta.json"), []byte(meta), 0o644)
+	os.WriteFile(filepath.Join(legacyDir, "notes.log"), []byte("2025-10-05T14:35:00Z|old note\n"), 0o644)
+
+	ctx, notes, _, _, err := core.GetContextWithMetadata("legacy: work")
+	if err != nil {
+		t.Fatalf("Failed to load migrated context: %v", err)
+	}
+	if ctx.ID == "" || ctx.SubdirectoryPath != core.GetContextDir(ctx.ID) {
+		t.Errorf("Expected the context in its ID directory, got %q in %s", ctx.ID, ctx.SubdirectoryPath)
+	}
+	if len(notes) != 1 {
+		t.Errorf("Expected the note to move with the context, got %d notes", len(notes))
+	}
+	if core.FileExists(legacyDir) {
+		t.Error("Expected the old directory to be moved")
+	}
+
+	// Fields this version doesn't know survive the migration
+	data, _ := os.ReadFile(filepath.Join(core.GetContextDir(ctx.ID), "meta.json"))
+	if !strings.Contains(string(data), `"custom"`) {
+		t.Errorf("Expected unknown meta.json fields to be kept, got %s", data)
+	}
+
+	// Migrating again changes nothing
+	dirs, err := core.ListContextDirs()
+	if err != nil || len(dirs) != 1 || dirs[0] != ctx.ID {
+		t.Errorf("Expected only %s, got %v (%v)", ctx.ID, dirs, err)
+	}
+}
+
+// TestContextIndexCache tests that index.json is read once and re-read only for names it doesn't know
+func TestContextIndexCache(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	ctx, _, err := core.CreateContext("cached")
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	indexPath := filepath.Join(tempDir, "index.json")
+	saved, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatalf("Failed to read index.json: %v", err)
+	}
+
+	// A known name is answered from the cache without reading the file
+	os.WriteFile(indexPath, []byte("not json"), 0o600)
+	if id, err := core.GetContextID("cached"); err != nil || id != ctx.ID {
+		t.Errorf("Expected the cached ID %q, got %q (%v)", ctx.ID, id, err)
+	}
+
+	// An unknown name re-reads it, finding contexts added by another process
+	other := strings.Replace(string(saved), `"cached"`, `"other": "zzzzzz", "cached"`, 1)
+	os.WriteFile(indexPath, []byte(other), 0o600)
+	if id, err := core.GetContextID("other"); err != nil || id != "zzzzzz" {
+		t.Errorf("Expected other's ID from index.json, got %q (%v)", id, err)
+	}
+}
@@ -0,0 +1,278 @@
+package unit
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// appendNoteAt writes a note with a fixed timestamp so ordering is deterministic
+func appendNoteAt(t *testing.T, contextName, text string, ts time.Time) {
+	t.Helper()
+	note := &models.Note{Timestamp: ts, TextContent: text}
+	if err := core.AppendLog(core.GetNotesLogPath(contextName), note.ToLogLine()); err != nil {
+		t.Fatalf("Failed to append note: %v", err)
+	}
+}
+
+// lineAfter returns the log line following the first line ending in suffix
+func lineAfter(lines []string, suffix string) string {
+	for i, line := range lines {
+		if strings.HasSuffix(line, suffix) && i+1 < len(lines) {
+			return lines[i+1]
+		}
+	}
+	return ""
+}
+
+// TestMergeContexts tests interleaving, label union and child re-linking
+func TestMergeContexts(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContextWithMetadata("spike", "", "", []string{"auth"})
+	core.CreateContextWithMetadata("bug", "", "", []string{"auth", "urgent"})
+	core.CreateContext("bug tests")
+	core.StopContext()
+	if err := core.SetParent("bug tests", "bug"); err != nil {
+		t.Fatalf("Failed to link: %v", err)
+	}
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	appendNoteAt(t, "spike", "spike 1", base)
+	core.AppendLog(core.GetNotesLogPath("spike"), "not a note line")
+	appendNoteAt(t, "bug", "bug 1", base.Add(time.Minute))
+	appendNoteAt(t, "spike", "spike 2", base.Add(2*time.Minute))
+
+	result, err := core.MergeContexts("spike", "bug", "auth rework")
+	if err != nil {
+		t.Fatalf("MergeContexts failed: %v", err)
+	}
+	if len(result.Labels) != 2 {
+		t.Errorf("Expected labels to be unioned, got %v", result.Labels)
+	}
+
+	_, notes, _, _, err := core.GetContext("auth rework")
+	if err != nil {
+		t.Fatalf("Failed to load merged context: %v", err)
+	}
+	var texts []string
+	for _, note := range notes {
+		texts = append(texts, note.TextContent)
+	}
+	if len(texts) != 3 || texts[0] != "spike 1" || texts[1] != "bug 1" || texts[2] != "spike 2" {
+		t.Errorf("Expected notes interleaved by time, got %v", texts)
+	}
+	if result.NoteCount != 3 {
+		t.Errorf("Expected 3 notes counted, got %d", result.NoteCount)
+	}
+
+	// Lines that don't parse are kept, next to the line they followed
+	lines, _ := core.ReadLog(core.GetNotesLogPath("auth rework"))
+	if got := lineAfter(lines, "spike 1"); got != "not a note line" {
+		t.Errorf("Expected the unparsed line to be kept after spike 1, got %q", lines)
+	}
+
+	children, _ := core.GetChildren("auth rework")
+	if len(children) != 1 || children[0] != "bug tests" {
+		t.Errorf("Expected child to be re-linked, got %v", children)
+	}
+
+	// Sources are recoverable from the trash
+	if _, err := core.LoadContext("spike"); err == nil {
+		t.Error("Expected source context to be moved to trash")
+	}
+	trashed, _ := core.ListTrash()
+	if len(trashed) != 2 {
+		t.Errorf("Expected 2 trashed sources, got %d", len(trashed))
+	}
+
+	// Time spent in the sources now counts for the merged context
+	sessions, err := core.GetSessions()
+	if err != nil {
+		t.Fatalf("GetSessions failed: %v", err)
+	}
+	merged := 0
+	for _, s := range sessions {
+		if s.Context == "spike" || s.Context == "bug" {
+			t.Errorf("Expected no sessions under a merged source, got %+v", s)
+		}
+		if s.Context == "auth rework" {
+			merged++
+		}
+	}
+	if merged != 2 {
+		t.Errorf("Expected both sources' sessions under auth rework, got %d", merged)
+	}
+}
+
+// TestMergeRejectsActiveContext tests that the active context cannot be merged
+func TestMergeRejectsActiveContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("a")
+	core.CreateContext("b")
+
+	if _, err := core.MergeContexts("a", "b", "ab"); err == nil {
+		t.Error("Expected error when merging the active context")
+	}
+}
+
+// TestMergeRejectsResumedContext tests that a resumed context counts as active even though meta.json says stopped
+func TestMergeRejectsResumedContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("a")
+	core.CreateContext("b")
+	core.StopContext()
+	appendNoteAt(t, "a", "first", time.Now().Add(-time.Minute))
+	appendNoteAt(t, "a", "second", time.Now())
+	if err := core.ResumeContext("a"); err != nil {
+		t.Fatalf("ResumeContext failed: %v", err)
+	}
+
+	if _, err := core.MergeContexts("a", "b", "ab"); err == nil {
+		t.Error("Expected error when merging the resumed context")
+	}
+	if _, err := core.SplitContext("a", 1, "a2"); err == nil {
+		t.Error("Expected error when splitting the resumed context")
+	}
+}
+
+// TestMergeRollsBackOnFailure tests that a merge failing partway restores the sources and children
+func TestMergeRollsBackOnFailure(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	for _, name := range []string{"a", "b", "good child", "broken child"} {
+		core.CreateContext(name)
+	}
+	core.StopContext()
+	core.SetParent("good child", "a")
+	core.SetParent("broken child", "b")
+
+	// A child whose meta.json name isn't in the index can't be re-linked
+	var meta map[string]interface{}
+	metaPath := core.GetMetaJSONPath("broken child")
+	core.ReadJSON(metaPath, &meta)
+	meta["name"] = "ghost"
+	core.WriteJSON(metaPath, meta)
+
+	if _, err := core.MergeContexts("a", "b", "ab"); err == nil {
+		t.Fatal("Expected the merge to fail")
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if _, err := core.LoadContext(name); err != nil {
+			t.Errorf("Expected %s to be restored: %v", name, err)
+		}
+	}
+	if _, err := core.LoadContext("ab"); err == nil {
+		t.Error("Expected the partly merged context to be removed")
+	}
+	if trashed, _ := core.ListTrash(); len(trashed) != 0 {
+		t.Errorf("Expected an empty trash, got %d entries", len(trashed))
+	}
+	if children, _ := core.GetChildren("a"); len(children) != 1 || children[0] != "good child" {
+		t.Errorf("Expected good child to be linked to a again, got %v", children)
+	}
+}
+
+// TestSplitContext tests moving later entries into a new linked context
+func TestSplitContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContextWithMetadata("sprint", "", "", []string{"q3"})
+	core.StopContext()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	appendNoteAt(t, "sprint", "planning", base)
+	core.AppendLog(core.GetNotesLogPath("sprint"), "not a note line")
+	appendNoteAt(t, "sprint", "coding", base.Add(time.Minute))
+	appendNoteAt(t, "sprint", "release prep", base.Add(2*time.Minute))
+	file := &models.FileAssociation{Timestamp: base.Add(3 * time.Minute), FilePath: "/tmp/CHANGELOG.md"}
+	if err := core.AppendLog(core.GetFilesLogPath("sprint"), file.ToLogLine()); err != nil {
+		t.Fatalf("Failed to append file: %v", err)
+	}
+
+	if _, err := core.SplitContext("sprint", 3, "release"); err == nil {
+		t.Error("Expected error when splitting after the last note")
+	}
+
+	result, err := core.SplitContext("sprint", 2, "release")
+	if err != nil {
+		t.Fatalf("SplitContext failed: %v", err)
+	}
+	if result.NoteCount != 1 || result.FileCount != 1 {
+		t.Errorf("Expected 1 note and 1 file moved, got %+v", result)
+	}
+
+	_, kept, keptFiles, _, _ := core.GetContext("sprint")
+	if len(kept) != 2 || len(keptFiles) != 0 {
+		t.Errorf("Expected original to keep 2 notes and no files, got %d notes, %d files", len(kept), len(keptFiles))
+	}
+	lines, _ := core.ReadLog(core.GetNotesLogPath("sprint"))
+	if got := lineAfter(lines, "planning"); got != "not a note line" {
+		t.Errorf("Expected the original to keep its line order, got %q", lines)
+	}
+
+	ctx, moved, _, _, err := core.GetContextWithMetadata("release")
+	if err != nil {
+		t.Fatalf("Failed to load split context: %v", err)
+	}
+	if len(moved) != 1 || moved[0].TextContent != "release prep" {
+		t.Errorf("Unexpected notes in split context: %+v", moved)
+	}
+	if ctx.Metadata.Parent != "sprint" || ctx.Status != "stopped" {
+		t.Errorf("Expected stopped child of sprint, got parent=%q status=%q", ctx.Metadata.Parent, ctx.Status)
+	}
+	if len(ctx.Metadata.Labels) != 1 || ctx.Metadata.Labels[0] != "q3" {
+		t.Errorf("Expected labels to be copied, got %v", ctx.Metadata.Labels)
+	}
+}
+
+// TestSplitSingleNoteContext tests that a context with one note is reported as unsplittable
+func TestSplitSingleNoteContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("tiny")
+	core.StopContext()
+	appendNoteAt(t, "tiny", "only note", time.Now().Truncate(time.Second))
+
+	_, err := core.SplitContext("tiny", 1, "tiny 2")
+	if err == nil || !strings.Contains(err.Error(), "can't be split") {
+		t.Errorf("Expected a context with one note to be unsplittable, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// TestNoteTypeLogLines tests that typed notes round-trip through notes.log and old lines still parse
+func TestNoteTypeLogLines(t *testing.T) {
+	notes := []*models.Note{
+		{TextContent: "plain | with pipe"},
+		{TextContent: "ship it", Type: models.NoteTypeDecision},
+		{TextContent: "write tests", Type: models.NoteTypeTodo, Done: true},
+	}
+	for _, note := range notes {
+		parsed, err := models.ParseNoteLogLine(note.ToLogLine())
+		if err != nil {
+			t.Fatalf("ParseNoteLogLine(%q) failed: %v", note.ToLogLine(), err)
+		}
+		if parsed.TextContent != note.TextContent || parsed.Type != note.Type || parsed.Done != note.Done {
+			t.Errorf("Expected %+v, got %+v", note, parsed)
+		}
+	}
+
+	// A plain note whose text starts like a type keeps its text
+	old, err := models.ParseNoteLogLine(`2025-10-05T14:35:00Z|todo\|not a type`)
+	if err != nil {
+		t.Fatalf("ParseNoteLogLine failed: %v", err)
+	}
+	if old.Type != "" || old.TextContent != "todo|not a type" {
+		t.Errorf("Expected an untyped note, got type %q text %q", old.Type, old.TextContent)
+	}
+
+	if err := models.ValidateNoteType("bogus"); err == nil {
+		t.Error("Expected an unknown note type to be rejected")
+	}
+}
+
+// TestTodoNotes tests adding, listing, closing and reopening todos
+func TestTodoNotes(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContext("ps-cli: Todos"); err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	core.AddNote("plain note")
+	if _, err := core.AddTypedNote("write tests", models.NoteTypeTodo); err != nil {
+		t.Fatalf("AddTypedNote failed: %v", err)
+	}
+	core.AddTypedNote("use JWT", models.NoteTypeDecision)
+
+	if count, err := core.CountNotes("ps-cli: Todos"); err != nil || count != 3 {
+		t.Errorf("Expected 3 numbered notes, got %d (%v)", count, err)
+	}
+
+	t.Run("note references", func(t *testing.T) {
+		for _, ref := range []string{"2", "#2", "ps-cli: Todos#2", "ps-cli: To#2", "*Todos#2"} {
+			name, number, err := core.ParseNoteRef(ref)
+			if err != nil || name != "ps-cli: Todos" || number != 2 {
+				t.Errorf("ParseNoteRef(%q) = %q, %d, %v", ref, name, number, err)
+			}
+		}
+		if _, _, err := core.ParseNoteRef("nowhere#2"); err == nil {
+			t.Error("Expected an unknown context to be rejected")
+		}
+		if _, _, err := core.ParseNoteRef("x#0"); err == nil {
+			t.Error("Expected note number 0 to be rejected")
+		}
+	})
+
+	t.Run("only todos can be done", func(t *testing.T) {
+		if _, err := core.MarkNoteDone("ps-cli: Todos", 3); err == nil {
+			t.Error("Expected marking a decision done to fail")
+		}
+		if _, err := core.MarkNoteDone("ps-cli: Todos", 9); err == nil {
+			t.Error("Expected a missing note number to fail")
+		}
+	})
+
+	todos, err := core.ListTodos(false, false)
+	if err != nil || len(todos) != 1 {
+		t.Fatalf("Expected 1 open todo, got %d (%v)", len(todos), err)
+	}
+	if todos[0].Number != 2 || !strings.HasSuffix(todos[0].Ref, "#2") {
+		t.Errorf("Expected todo #2, got %d (%s)", todos[0].Number, todos[0].Ref)
+	}
+
+	if _, err := core.MarkNoteDone("ps-cli: Todos", 2); err != nil {
+		t.Fatalf("MarkNoteDone failed: %v", err)
+	}
+	if _, err := core.MarkNoteDone("ps-cli: Todos", 2); err == nil {
+		t.Error("Expected a done todo to stay done")
+	}
+	if todos, _ := core.ListTodos(false, false); len(todos) != 0 {
+		t.Errorf("Expected no open todos, got %d", len(todos))
+	}
+	if todos, _ := core.ListTodos(true, false); len(todos) != 1 || !todos[0].Note.Done {
+		t.Errorf("Expected the done todo with --done, got %v", todos)
+	}
+
+	entry, err := core.UndoLast()
+	if err != nil || entry.Op != core.JournalOpNoteDone {
+		t.Fatalf("Expected to undo note done, got %v (%v)", entry, err)
+	}
+	if todos, _ := core.ListTodos(false, false); len(todos) != 1 {
+		t.Errorf("Expected undo to reopen the todo, got %d open", len(todos))
+	}
+}
+
+// TestNoteGroupsExport tests that exports group typed notes under headings
+func TestNoteGroupsExport(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: Groups")
+	core.AddTypedNote("open question", models.NoteTypeQuestion)
+	core.AddNote("plain note")
+	core.AddTypedNote("write tests", models.NoteTypeTodo)
+	core.StopContext()
+
+	_, notes, _, _, err := core.GetContext("ps-cli: Groups")
+	if err != nil {
+		t.Fatalf("GetContext failed: %v", err)
+	}
+	plain := make([]models.Note, len(notes))
+	for i, note := range notes {
+		plain[i] = *note
+	}
+
+	groups := output.GroupNotes(plain)
+	var types []string
+	for _, group := range groups {
+		types = append(types, group.Type)
+	}
+	if strings.Join(types, ",") != ",todo,question" {
+		t.Errorf("Expected plain notes, then todos and questions, got %q", types)
+	}
+
+	exporter, _ := output.GetExporter("markdown")
+	path, err := core.ExportContext("ps-cli: Groups", filepath.Join(tempDir, "groups.md"), exporter)
+	if err != nil {
+		t.Fatalf("ExportContext failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	for _, want := range []string{"### Todos", "- [ ] ", "### Questions"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Markdown export missing %q:\n%s", want, content)
+		}
+	}
+}
+
+// TestMarkNoteDoneIdenticalTodos tests that note done and undo change the numbered todo, not a twin with the same line
+func TestMarkNoteDoneIdenticalTodos(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("twins")
+	core.StopContext()
+	twin := &models.Note{Timestamp: time.Now().Truncate(time.Second), TextContent: "same", Type: models.NoteTypeTodo}
+	for i := 0; i < 2; i++ {
+		core.AppendLog(core.GetNotesLogPath("twins"), twin.ToLogLine())
+	}
+
+	doneStates := func() []bool {
+		_, notes, _, _, err := core.GetContext("twins")
+		if err != nil {
+			t.Fatalf("GetContext failed: %v", err)
+		}
+		var done []bool
+		for _, note := range notes {
+			done = append(done, note.Done)
+		}
+		return done
+	}
+
+	if _, err := core.MarkNoteDone("twins", 1); err != nil {
+		t.Fatalf("MarkNoteDone failed: %v", err)
+	}
+	if got := doneStates(); !got[0] || got[1] {
+		t.Errorf("Expected only #1 done, got %v", got)
+	}
+
+	if _, err := core.MarkNoteDone("twins", 2); err != nil {
+		t.Fatalf("MarkNoteDone failed: %v", err)
+	}
+	if _, err := core.UndoLast(); err != nil {
+		t.Fatalf("UndoLast failed: %v", err)
+	}
+	if got := doneStates(); !got[0] || got[1] {
+		t.Errorf("Expected undo to reopen #2 only, got %v", got)
+	}
+}
@@ -5,12 +5,13 @@ import (
 	"testing"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/commands"
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
 )
 
-// TestGetEnvInt tests the getEnvInt helper function
+// TestGetEnvInt tests the config.EnvInt helper
 func TestGetEnvInt(t *testing.T) {
 	// Test default values
-	if val := commands.GetEnvInt("NON_EXISTENT_VAR", 42); val != 42 {
+	if val := config.EnvInt("NON_EXISTENT_VAR", 42); val != 42 {
 		t.Errorf("Expected default value 42, got %d", val)
 	}
 
@@ -18,7 +19,7 @@ func TestGetEnvInt(t *testing.T) {
 	os.Setenv("TEST_VAR", "123")
 	defer os.Unsetenv("TEST_VAR")
 
-	if val := commands.GetEnvInt("TEST_VAR", 42); val != 123 {
+	if val := config.EnvInt("TEST_VAR", 42); val != 123 {
 		t.Errorf("Expected 123, got %d", val)
 	}
 
@@ -26,7 +27,7 @@ func TestGetEnvInt(t *testing.T) {
 	os.Setenv("TEST_VAR_INVALID", "not-a-number")
 	defer os.Unsetenv("TEST_VAR_INVALID")
 
-	if val := commands.GetEnvInt("TEST_VAR_INVALID", 42); val != 42 {
+	if val := config.EnvInt("TEST_VAR_INVALID", 42); val != 42 {
 		t.Errorf("Expected default value 42 for invalid env var, got %d", val)
 	}
 }
@@ -0,0 +1,99 @@
+package unit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// TestRenameContext tests that rename updates the directory, children, state and history
+func TestRenameContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("child")
+	core.CreateContext("Sprint 3")
+	if err := core.SetParent("child", "Sprint 3"); err != nil {
+		t.Fatalf("Failed to link: %v", err)
+	}
+	if _, err := core.AddNote("planning done"); err != nil {
+		t.Fatalf("Failed to add note: %v", err)
+	}
+
+	result, err := core.RenameContext("Sprint 3", "ps-cli: Sprint 3")
+	if err != nil {
+		t.Fatalf("RenameContext failed: %v", err)
+	}
+	if !result.WasActive {
+		t.Error("Expected rename to report the context was active")
+	}
+	if len(result.ChildrenUpdated) != 1 || result.ChildrenUpdated[0] != "child" {
+		t.Errorf("Expected child to be re-linked, got %v", result.ChildrenUpdated)
+	}
+
+	// Old directory is gone, data lives under the new name
+	if core.FileExists(core.GetContextDir("Sprint_3")) {
+		t.Error("Expected old directory to be moved")
+	}
+	ctx, notes, _, _, err := core.GetContextWithMetadata("ps-cli: Sprint 3")
+	if err != nil {
+		t.Fatalf("Failed to load renamed context: %v", err)
+	}
+	if ctx.Name != "ps-cli: Sprint 3" || len(notes) != 1 {
+		t.Errorf("Unexpected renamed context: name=%q notes=%d", ctx.Name, len(notes))
+	}
+
+	children, _ := core.GetChildren("ps-cli: Sprint 3")
+	if len(children) != 1 {
+		t.Errorf("Expected 1 child under new name, got %v", children)
+	}
+
+	state, _ := core.GetActiveContext()
+	if state.GetActiveContextName() != "ps-cli: Sprint 3" {
+		t.Errorf("Expected state to follow rename, got %q", state.GetActiveContextName())
+	}
+
+	transitions, _ := core.GetTransitions()
+	last := transitions[len(transitions)-1]
+	if last.TransitionType != models.TransitionRename || *last.PreviousContext != "Sprint 3" || *last.NewContext != "ps-cli: Sprint 3" {
+		t.Errorf("Expected rename transition, got %+v", last)
+	}
+
+	// Earlier operations remain undoable under the new name
+	if _, err := core.UndoLast(); err != nil {
+		t.Fatalf("Failed to undo note after rename: %v", err)
+	}
+	_, notes, _, _, _ = core.GetContext("ps-cli: Sprint 3")
+	if len(notes) != 0 {
+		t.Errorf("Expected note to be undone in renamed context, got %d notes", len(notes))
+	}
+}
+
+// TestRenameContextConflicts tests rename validation
+func TestRenameContextConflicts(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("a")
+	core.CreateContext("b")
+
+	if _, err := core.RenameContext("a", "b"); err == nil {
+		t.Error("Expected error when renaming onto an existing context")
+	}
+	if _, err := core.RenameContext("missing", "c"); err == nil {
+		t.Error("Expected error when renaming a missing context")
+	}
+	if _, err := core.RenameContext("a", "  "); err == nil {
+		t.Error("Expected error for empty new name")
+	}
+}
@@ -0,0 +1,137 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/tui"
+)
+
+// TestResolveContext tests how command-line arguments are matched to contexts
+func TestResolveContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	for _, name := range []string{"ps-cli: Auth", "ps-cli: Parser", "garden", "Garden party"} {
+		if _, _, err := core.CreateContext(name); err != nil {
+			t.Fatalf("CreateContext(%q) failed: %v", name, err)
+		}
+	}
+	core.StopContext()
+
+	tests := []struct {
+		query     string
+		want      string
+		ambiguous int
+	}{
+		{query: "garden", want: "garden"},
+		{query: "GARDEN", want: "garden"},
+		{query: "ps-cli: a", want: "ps-cli: Auth"},
+		{query: "pars", want: "ps-cli: Parser"},
+		{query: "pcp", want: "ps-cli: Parser"},
+		{query: "gard", ambiguous: 2},
+		{query: "ps-cli*", ambiguous: 2},
+		{query: "ps", ambiguous: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			ctx, err := core.ResolveContext(tt.query, nil)
+			if tt.ambiguous > 0 {
+				var ambiguous *core.AmbiguousContextError
+				if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != tt.ambiguous {
+					t.Errorf("Expected %d ambiguous matches, got %v (%v)", tt.ambiguous, ctx, err)
+				}
+				return
+			}
+			if err != nil || ctx.Name != tt.want {
+				t.Errorf("Expected %q, got %v (%v)", tt.want, ctx, err)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		var notFound *core.ContextNotFoundError
+		if _, err := core.ResolveContext("zzz", nil); !errors.As(err, &notFound) {
+			t.Errorf("Expected ContextNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("check", func(t *testing.T) {
+		notGarden := func(ctx *models.Context) error {
+			if ctx.Name == "garden" {
+				return fmt.Errorf("not garden")
+			}
+			return nil
+		}
+		// A loose match skips contexts the check rejects...
+		if ctx, err := core.ResolveContext("gard", notGarden); err != nil || ctx.Name != "Garden party" {
+			t.Errorf("Expected Garden party, got %v (%v)", ctx, err)
+		}
+		// ...but an exact name reports why it can't be used
+		if _, err := core.ResolveContext("garden", notGarden); err == nil || err.Error() != "not garden" {
+			t.Errorf("Expected the check's error, got %v", err)
+		}
+	})
+
+	t.Run("previous", func(t *testing.T) {
+		// Sessions were opened in creation order; Garden party is still the last
+		for _, tt := range []struct{ query, want string }{
+			{"-", "Garden party"},
+			{"@{-1}", "Garden party"},
+			{"@{-2}", "garden"},
+			{"@{-4}", "ps-cli: Auth"},
+		} {
+			if ctx, err := core.ResolveContext(tt.query, nil); err != nil || ctx.Name != tt.want {
+				t.Errorf("%s: expected %q, got %v (%v)", tt.query, tt.want, ctx, err)
+			}
+		}
+		if _, err := core.ResolveContext("@{-5}", nil); err == nil {
+			t.Error("Expected an error past the start of history")
+		}
+
+		// The active context is not its own previous context
+		if err := core.ResumeContext("garden"); err != nil {
+			t.Fatalf("ResumeContext failed: %v", err)
+		}
+		if ctx, err := core.ResolveContext("-", nil); err != nil || ctx.Name != "Garden party" {
+			t.Errorf("Expected Garden party while garden is active, got %v (%v)", ctx, err)
+		}
+	})
+}
+
+// TestPicker tests narrowing and choosing in the interactive picker
+func TestPicker(t *testing.T) {
+	p := tui.NewPicker([]string{"ps-cli: Auth", "ps-cli: Parser", "garden"}, "")
+	if len(p.Matches()) != 3 {
+		t.Fatalf("Expected all items with no query, got %v", p.Matches())
+	}
+
+	for _, r := range "pars" {
+		p.HandleKey(tui.RuneKey(r))
+	}
+	if len(p.Matches()) != 1 || p.Matches()[0] != "ps-cli: Parser" {
+		t.Errorf("Expected only ps-cli: Parser, got %v", p.Matches())
+	}
+
+	p.HandleKey(tui.Key{Type: tui.KeyBackspace})
+	p.HandleKey(tui.Key{Type: tui.KeyBackspace})
+	p.HandleKey(tui.Key{Type: tui.KeyDown})
+	p.HandleKey(tui.Key{Type: tui.KeyEnter})
+	if !p.Done() || p.Picked() != p.Matches()[1] {
+		t.Errorf("Expected the second match to be picked, got %q from %v", p.Picked(), p.Matches())
+	}
+
+	cancelled := tui.NewPicker([]string{"garden"}, "")
+	cancelled.HandleKey(tui.Key{Type: tui.KeyEsc})
+	if !cancelled.Done() || cancelled.Picked() != "" {
+		t.Errorf("Expected Esc to cancel, got %q", cancelled.Picked())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
 )
 
 // TestGetMostRecentStopped tests the GetMostRecentStopped function
@@ -169,3 +170,40 @@ func TestMatchesPattern(t *testing.T) {
 	// The unit tests above already cover this functionality
 	t.Skip("Pattern matching is tested indirectly through FindContextsByPattern")
 }
+
+// TestSwitchContext tests that switching logs one switch transition and a failed switch keeps the active context
+func TestSwitchContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("first")
+	core.CreateContext("second")
+
+	if _, err := core.SwitchContext("missing"); err == nil {
+		t.Error("Expected switching to a missing context to fail")
+	}
+	if state, _ := core.GetActiveContext(); state.GetActiveContextName() != "second" {
+		t.Errorf("Expected second to stay active, got %q", state.GetActiveContextName())
+	}
+
+	before, _ := core.GetTransitions()
+	previous, err := core.SwitchContext("first")
+	if err != nil || previous != "second" {
+		t.Fatalf("SwitchContext = %q, %v", previous, err)
+	}
+	if state, _ := core.GetActiveContext(); state.GetActiveContextName() != "first" {
+		t.Errorf("Expected first to be active, got %q", state.GetActiveContextName())
+	}
+	if ctx, err := core.FindContextByName("second"); err != nil || ctx.Status != "stopped" {
+		t.Errorf("Expected second to be stopped, got %+v (%v)", ctx, err)
+	}
+
+	after, _ := core.GetTransitions()
+	if len(after) != len(before)+1 || after[len(after)-1].TransitionType != models.TransitionSwitch {
+		t.Errorf("Expected exactly one switch transition, got %d new", len(after)-len(before))
+	}
+}
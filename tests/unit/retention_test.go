@@ -0,0 +1,263 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/config"
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+)
+
+// backdateContext rewrites a stopped context's timestamps so it looks idle for the given duration
+func backdateContext(t *testing.T, contextName string, idle time.Duration) {
+	t.Helper()
+
+	var ctx models.Context
+	metaPath := core.GetMetaJSONPath(contextName)
+	if err := core.ReadJSON(metaPath, &ctx); err != nil {
+		t.Fatalf("Failed to read meta.json for %s: %v", contextName, err)
+	}
+
+	end := time.Now().Add(-idle)
+	ctx.StartTime = end.Add(-time.Hour)
+	ctx.EndTime = &end
+
+	if err := core.WriteJSON(metaPath, &ctx); err != nil {
+		t.Fatalf("Failed to write meta.json for %s: %v", contextName, err)
+	}
+}
+
+// TestUnarchiveContext tests archiving and unarchiving round-trips
+func TestUnarchiveContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContextWithMetadata("tagged-work", "", "", []string{"keep"}); err != nil {
+		t.Fatalf("Failed to create context: %v", err)
+	}
+	core.StopContext()
+
+	// Unarchiving a context that isn't archived is an error
+	if err := core.UnarchiveContext("tagged-work"); err == nil {
+		t.Error("Expected error when unarchiving a non-archived context")
+	}
+
+	if err := core.ArchiveContext("tagged-work"); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+	if err := core.UnarchiveContext("tagged-work"); err != nil {
+		t.Fatalf("Failed to unarchive: %v", err)
+	}
+
+	ctx, err := core.LoadContext("tagged-work")
+	if err != nil {
+		t.Fatalf("Failed to load context: %v", err)
+	}
+	if ctx.IsArchived {
+		t.Error("Expected context to no longer be archived")
+	}
+
+	// Stop, archive and unarchive must not drop labels
+	tags, err := core.GetContextTags("tagged-work")
+	if err != nil {
+		t.Fatalf("Failed to get tags: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != "keep" {
+		t.Errorf("Expected labels to survive archive round-trip, got %v", tags)
+	}
+}
+
+// TestPlanAndApplyGC tests that the retention policy archives, then trashes, idle contexts
+func TestPlanAndApplyGC(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	for _, name := range []string{"fresh", "stale", "ancient"} {
+		if _, _, err := core.CreateContext(name); err != nil {
+			t.Fatalf("Failed to create context %s: %v", name, err)
+		}
+		core.StopContext()
+	}
+	backdateContext(t, "stale", 40*24*time.Hour)
+	backdateContext(t, "ancient", 200*24*time.Hour)
+	if err := core.ArchiveContext("ancient"); err != nil {
+		t.Fatalf("Failed to archive ancient: %v", err)
+	}
+
+	// Active contexts are never collected, however old
+	if _, _, err := core.CreateContext("running"); err != nil {
+		t.Fatalf("Failed to create running context: %v", err)
+	}
+
+	policy := &core.RetentionPolicy{ArchiveAfterDays: 30, DeleteAfterDays: 90}
+	actions, err := core.PlanGC(policy, time.Now())
+	if err != nil {
+		t.Fatalf("PlanGC failed: %v", err)
+	}
+
+	planned := make(map[string]string)
+	for _, action := range actions {
+		planned[action.ContextName] = action.Action
+	}
+	if len(planned) != 2 || planned["stale"] != core.GCActionArchive || planned["ancient"] != core.GCActionTrash {
+		t.Fatalf("Unexpected gc plan: %v", planned)
+	}
+
+	applied, failures := core.ApplyGC(actions)
+	if len(failures) != 0 {
+		t.Fatalf("Unexpected gc failures: %v", failures)
+	}
+	if len(applied) != 2 {
+		t.Errorf("Expected 2 applied actions, got %d", len(applied))
+	}
+
+	stale, err := core.LoadContext("stale")
+	if err != nil || !stale.IsArchived {
+		t.Errorf("Expected stale to be archived (err: %v)", err)
+	}
+
+	if _, err := core.LoadContext("ancient"); err == nil {
+		t.Error("Expected ancient to be moved out of the context home")
+	}
+	entries, err := os.ReadDir(core.GetTrashDir())
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected one trashed context, got %d (err: %v)", len(entries), err)
+	}
+
+	// The trash directory must not show up as a context
+	for _, dir := range mustListContextDirs(t) {
+		if dir == ".trash" {
+			t.Error("Trash directory should not be listed as a context")
+		}
+	}
+}
+
+// TestGCSkipsResumedContext tests that gc leaves a resumed context alone even though its meta.json says stopped
+func TestGCSkipsResumedContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContext("resumed"); err != nil {
+		t.Fatalf("Failed to create context: %v", err)
+	}
+	core.StopContext()
+	backdateContext(t, "resumed", 40*24*time.Hour)
+	if err := core.ResumeContext("resumed"); err != nil {
+		t.Fatalf("ResumeContext failed: %v", err)
+	}
+
+	actions, err := core.PlanGC(&core.RetentionPolicy{ArchiveAfterDays: 1}, time.Now())
+	if err != nil {
+		t.Fatalf("PlanGC failed: %v", err)
+	}
+	if len(actions) != 0 {
+		t.Errorf("Expected gc to skip the active context, got %v", actions)
+	}
+}
+
+// TestRetentionPolicyPersistence tests saving and loading the policy through config.yaml
+func TestRetentionPolicyPersistence(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	policy, err := core.LoadRetentionPolicy()
+	if err != nil {
+		t.Fatalf("Failed to load default policy: %v", err)
+	}
+	if policy.ArchiveAfterDays != 0 || policy.DeleteAfterDays != 0 || policy.TrashPurgeDays != core.DefaultTrashPurgeDays {
+		t.Errorf("Unexpected default policy: %+v", policy)
+	}
+
+	if err := core.SaveRetentionPolicy(&core.RetentionPolicy{ArchiveAfterDays: -1}); err == nil {
+		t.Error("Expected error for negative retention days")
+	}
+
+	if err := core.SaveRetentionPolicy(&core.RetentionPolicy{ArchiveAfterDays: 14, DeleteAfterDays: 60}); err != nil {
+		t.Fatalf("Failed to save policy: %v", err)
+	}
+
+	policy, err = core.LoadRetentionPolicy()
+	if err != nil {
+		t.Fatalf("Failed to reload policy: %v", err)
+	}
+	if policy.ArchiveAfterDays != 14 || policy.DeleteAfterDays != 60 {
+		t.Errorf("Unexpected policy after reload: %+v", policy)
+	}
+	cfg, err := core.LoadConfig(nil)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, ok := cfg.FileValue("archive_after_days"); !ok || value != "14" {
+		t.Errorf("Expected archive_after_days in config.yaml, got %q", value)
+	}
+
+	os.Setenv("MC_DELETE_AFTER_DAYS", "7")
+	defer os.Unsetenv("MC_DELETE_AFTER_DAYS")
+	if policy, _ := core.LoadRetentionPolicy(); policy.DeleteAfterDays != 7 {
+		t.Errorf("Expected the environment to override config.yaml, got %+v", policy)
+	}
+}
+
+// TestRetentionPolicyMigration tests that a retention.json from an older version moves into config.yaml
+func TestRetentionPolicyMigration(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	legacy := filepath.Join(tempDir, "retention.json")
+	if err := os.WriteFile(legacy, []byte(`{"archive_after_days": 21, "delete_after_days": 0, "trash_purge_days": 10}`), 0644); err != nil {
+		t.Fatalf("Failed to write retention.json: %v", err)
+	}
+	if err := os.WriteFile(config.Path(tempDir), []byte("trash_purge_days: \"5\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config.yaml: %v", err)
+	}
+
+	policy, err := core.LoadRetentionPolicy()
+	if err != nil {
+		t.Fatalf("LoadRetentionPolicy failed: %v", err)
+	}
+	if policy.ArchiveAfterDays != 21 || policy.TrashPurgeDays != 5 {
+		t.Errorf("Expected retention.json values without overriding config.yaml, got %+v", policy)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("Expected retention.json to be removed after migrating")
+	}
+	cfg, err := core.LoadConfig(nil)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if value, _ := cfg.FileValue("archive_after_days"); value != "21" {
+		t.Errorf("Expected archive_after_days moved into config.yaml, got %q", value)
+	}
+}
+
+func mustListContextDirs(t *testing.T) []string {
+	t.Helper()
+	dirs, err := core.ListContextDirs()
+	if err != nil {
+		t.Fatalf("Failed to list context dirs: %v", err)
+	}
+	return dirs
+}
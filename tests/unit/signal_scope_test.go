@@ -0,0 +1,104 @@
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/signal"
+	"github.com/jefferycaldwell/my-context-copilot/pkg/models"
+)
+
+// TestSignalsFollowContextHome tests that global and context-scoped signals live under MY_CONTEXT_HOME
+func TestSignalsFollowContextHome(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if got, want := core.GetSignalsDir(), filepath.Join(tempDir, "signals"); got != want {
+		t.Errorf("Expected global signals in %s, got %s", want, got)
+	}
+
+	ctx, _, err := core.CreateContext("proj A")
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	scopedDir := core.GetContextSignalsDir("proj A")
+	if want := filepath.Join(tempDir, ctx.ID, "signals"); scopedDir != want {
+		t.Errorf("Expected scoped signals in %s, got %s", want, scopedDir)
+	}
+
+	global, err := signal.NewManager(core.GetSignalsDir())
+	if err != nil {
+		t.Fatalf("Failed to create global manager: %v", err)
+	}
+	if _, err := global.CreateSignal("build-done"); err != nil {
+		t.Fatalf("Failed to create global signal: %v", err)
+	}
+
+	scoped, err := signal.NewManager(scopedDir)
+	if err != nil {
+		t.Fatalf("Failed to create scoped manager: %v", err)
+	}
+	if _, err := scoped.CreateSignalWithPayload("review-ready", models.SignalPayload{Message: "PR #12"}, 0); err != nil {
+		t.Fatalf("Failed to create scoped signal: %v", err)
+	}
+
+	// Scopes don't see each other's signals
+	if scoped.SignalExists("build-done") {
+		t.Error("Expected global signal to be invisible to the context scope")
+	}
+	if global.SignalExists("review-ready") {
+		t.Error("Expected scoped signal to be invisible globally")
+	}
+
+	// The signals directory is not a context
+	dirs, err := core.ListContextDirs()
+	if err != nil {
+		t.Fatalf("ListContextDirs failed: %v", err)
+	}
+	if len(dirs) != 1 || dirs[0] != ctx.ID {
+		t.Errorf("Expected only %s to be listed, got %v", ctx.ID, dirs)
+	}
+
+	// Scoped signals move with the context on rename
+	if _, err := core.RenameContext("proj A", "proj B"); err != nil {
+		t.Fatalf("RenameContext failed: %v", err)
+	}
+	moved, err := signal.NewManager(core.GetContextSignalsDir("proj B"))
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+	if !moved.SignalExists("review-ready") {
+		t.Error("Expected scoped signal to move with the renamed context")
+	}
+}
+
+// TestContextNamedSignals tests that a context named signals doesn't use the signals directory
+func TestContextNamedSignals(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	ctx, _, err := core.CreateContext("signals")
+	if err != nil {
+		t.Fatalf("CreateContext failed: %v", err)
+	}
+	if ctx.Name != "signals" {
+		t.Errorf("Expected the name to be kept, got %q", ctx.Name)
+	}
+	if core.FileExists(filepath.Join(tempDir, "signals", "meta.json")) {
+		t.Error("Expected a context named signals to live in its ID directory")
+	}
+	dirs, _ := core.ListContextDirs()
+	if len(dirs) != 1 || dirs[0] != ctx.ID {
+		t.Errorf("Expected the context in %s, got %v", ctx.ID, dirs)
+	}
+}
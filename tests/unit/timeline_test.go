@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/output"
+)
+
+// TestTimeline tests the timeline rows and the chart drawn from them
+func TestTimeline(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: Auth")
+	core.CreateContext("garden")
+	core.StopContext()
+
+	writeTransitions(t,
+		"2025-10-20T08:00:00Z|NULL|garden|start",
+		"2025-10-20T09:00:00Z|garden|ps-cli: Auth|switch",
+		"2025-10-20T11:00:00Z|ps-cli: Auth|garden|switch",
+		"2025-10-20T12:00:00Z|garden|NULL|stop",
+	)
+	if err := os.WriteFile(core.GetNotesLogPath("ps-cli: Auth"), []byte("2025-10-20T10:00:00Z|halfway\n"), 0644); err != nil {
+		t.Fatalf("Failed to write notes: %v", err)
+	}
+
+	from := time.Date(2025, 10, 20, 8, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 10, 20, 14, 0, 0, 0, time.UTC)
+	rows, err := core.GetTimeline(from, to, "")
+	if err != nil {
+		t.Fatalf("GetTimeline failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Context != "garden" || rows[1].Context != "ps-cli: Auth" {
+		t.Fatalf("Expected garden then ps-cli: Auth, got %+v", rows)
+	}
+	if len(rows[0].Sessions) != 2 || rows[0].TimeSpent() != 2*time.Hour {
+		t.Errorf("Expected 2 garden sessions of 2h in total, got %+v", rows[0].Sessions)
+	}
+	if len(rows[1].Notes) != 1 {
+		t.Errorf("Expected the ps-cli note, got %+v", rows[1].Notes)
+	}
+
+	filtered, err := core.GetTimeline(from, to, "ps-cli")
+	if err != nil || len(filtered) != 1 {
+		t.Errorf("Expected only ps-cli with --project, got %+v (%v)", filtered, err)
+	}
+
+	// 6h over 48 columns: one column is 7.5 minutes
+	chart := output.FormatTimeline(rows, from, to, output.TimelineOptions{Width: 72, ASCII: true})
+	lines := strings.Split(chart, "\n")
+	garden, psCli := lines[2], lines[3]
+	for _, line := range []string{garden, psCli} {
+		if len(line) != 72 {
+			t.Errorf("Expected rows of 72 columns, got %d: %q", len(line), line)
+		}
+	}
+	bars := func(line string) string {
+		return line[strings.Index(line, "|")+1 : strings.LastIndex(line, "|")]
+	}
+	if got := bars(garden); !strings.HasPrefix(got, strings.Repeat("#", 8)) || strings.Count(got, "#") != 16 {
+		t.Errorf("Expected garden bars at 08:00-09:00 and 11:00-12:00 only, got %q", got)
+	}
+	if got := bars(psCli); strings.Index(got, "*") != 16 {
+		t.Errorf("Expected the note marker at 10:00 (column 16), got %q", got)
+	}
+	if !strings.Contains(chart, "2h 0m") {
+		t.Errorf("Expected totals:\n%s", chart)
+	}
+	for _, r := range chart {
+		if r > utf8.RuneSelf {
+			t.Fatalf("Expected plain ASCII output, found %q:\n%s", r, chart)
+		}
+	}
+
+	colored := output.FormatTimeline(rows, from, to, output.TimelineOptions{Width: 72, Color: true})
+	if !strings.Contains(colored, "\033[") || !strings.Contains(colored, "█") || !strings.Contains(colored, "◆") {
+		t.Errorf("Expected colored box-drawing output:\n%s", colored)
+	}
+}
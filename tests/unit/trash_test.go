@@ -0,0 +1,182 @@
+package unit
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+)
+
+// TestDeleteMovesContextToTrash tests that delete is recoverable via the trash
+func TestDeleteMovesContextToTrash(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContext("demo: Journal"); err != nil {
+		t.Fatalf("Failed to create context: %v", err)
+	}
+	if _, err := core.AddNote("something worth keeping"); err != nil {
+		t.Fatalf("Failed to add note: %v", err)
+	}
+	core.StopContext()
+
+	entry, err := core.DeleteContext("demo: Journal", true, true)
+	if err != nil {
+		t.Fatalf("DeleteContext failed: %v", err)
+	}
+	if entry.Reason != "delete --force" {
+		t.Errorf("Expected reason 'delete --force', got %q", entry.Reason)
+	}
+	if entry.NoteCount != 1 {
+		t.Errorf("Expected note count 1, got %d", entry.NoteCount)
+	}
+
+	if _, err := core.LoadContext("demo: Journal"); err == nil {
+		t.Fatal("Expected deleted context to be gone from the context home")
+	}
+
+	trashed, err := core.ListTrash()
+	if err != nil {
+		t.Fatalf("ListTrash failed: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].ContextName != "demo: Journal" {
+		t.Fatalf("Expected one trashed context named 'demo: Journal', got %+v", trashed)
+	}
+
+	// Restore by display name
+	restored, err := core.RestoreFromTrash("demo: Journal")
+	if err != nil {
+		t.Fatalf("RestoreFromTrash failed: %v", err)
+	}
+	if restored.ContextName != "demo: Journal" {
+		t.Errorf("Unexpected restored context: %q", restored.ContextName)
+	}
+
+	_, notes, _, _, err := core.GetContext("demo: Journal")
+	if err != nil {
+		t.Fatalf("Restored context could not be loaded: %v", err)
+	}
+	if len(notes) != 1 || notes[0].TextContent != "something worth keeping" {
+		t.Errorf("Expected notes to survive the trash round-trip, got %+v", notes)
+	}
+	if core.FileExists(restored.Path + "/trash.json") {
+		t.Error("Expected trash.json to be removed on restore")
+	}
+
+	trashed, _ = core.ListTrash()
+	if len(trashed) != 0 {
+		t.Errorf("Expected trash to be empty after restore, got %d entries", len(trashed))
+	}
+}
+
+// TestDeleteRefusesResumedContext tests that the context state.json names as active can't be trashed
+func TestDeleteRefusesResumedContext(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContext("resumed"); err != nil {
+		t.Fatalf("Failed to create context: %v", err)
+	}
+	core.StopContext()
+	if err := core.ResumeContext("resumed"); err != nil {
+		t.Fatalf("ResumeContext failed: %v", err)
+	}
+
+	if _, err := core.DeleteContext("resumed", true, true); err == nil {
+		t.Fatal("Expected deleting the active context to fail")
+	}
+	if _, err := core.LoadContext("resumed"); err != nil {
+		t.Errorf("Expected the active context to stay in place: %v", err)
+	}
+}
+
+// TestRestoreRefusesNameCollision tests that restoring never overwrites a live context
+func TestRestoreRefusesNameCollision(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("reused")
+	core.StopContext()
+	entry, err := core.DeleteContext("reused", false, true)
+	if err != nil {
+		t.Fatalf("DeleteContext failed: %v", err)
+	}
+
+	// Same name created again after the delete
+	core.CreateContext("reused")
+	core.StopContext()
+
+	if _, err := core.RestoreFromTrash(entry.ID); err == nil {
+		t.Error("Expected restore to fail while a context with the same name exists")
+	}
+}
+
+// TestEmptyTrash tests permanent removal with and without an age cutoff
+func TestEmptyTrash(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	for _, name := range []string{"one", "two"} {
+		core.CreateContext(name)
+		core.StopContext()
+		if _, err := core.DeleteContext(name, true, true); err != nil {
+			t.Fatalf("DeleteContext(%s) failed: %v", name, err)
+		}
+	}
+
+	// Nothing is older than an hour ago
+	removed, err := core.EmptyTrash(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("EmptyTrash with cutoff failed: %v", err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("Expected no entries removed by cutoff, got %d", len(removed))
+	}
+
+	// Default policy keeps entries for 30 days
+	purged, err := core.PurgeExpiredTrash()
+	if err != nil {
+		t.Fatalf("PurgeExpiredTrash failed: %v", err)
+	}
+	if len(purged) != 0 {
+		t.Errorf("Expected fresh entries to survive automatic purge, got %d purged", len(purged))
+	}
+
+	// The gc preview lists what a purge would remove without removing it
+	if expired, _ := core.ExpiredTrash(time.Now().Add(-time.Hour)); len(expired) != 0 {
+		t.Errorf("Expected nothing expired by the cutoff, got %d", len(expired))
+	}
+	if expired, _ := core.ExpiredTrash(time.Time{}); len(expired) != 2 {
+		t.Errorf("Expected both entries without a cutoff, got %d", len(expired))
+	}
+
+	removed, err = core.EmptyTrash(time.Time{})
+	if err != nil {
+		t.Fatalf("EmptyTrash failed: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("Expected 2 entries removed, got %d", len(removed))
+	}
+
+	trashed, _ := core.ListTrash()
+	if len(trashed) != 0 {
+		t.Errorf("Expected empty trash, got %d entries", len(trashed))
+	}
+}
@@ -0,0 +1,136 @@
+package unit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+	"github.com/jefferycaldwell/my-context-copilot/internal/models"
+	"github.com/jefferycaldwell/my-context-copilot/internal/tui"
+)
+
+// typeKeys sends each rune of text to the model as a key press
+func typeKeys(m *tui.Model, text string) {
+	for _, r := range text {
+		m.HandleKey(tui.RuneKey(r))
+	}
+}
+
+func screen(m *tui.Model) string {
+	return strings.Join(m.View(100, 20), "\n")
+}
+
+// TestTUIBrowser tests the ui browser's actions against the stored contexts
+func TestTUIBrowser(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("ps-cli: Auth")
+	core.StopContext()
+	core.CreateContext("garden")
+
+	m, err := tui.NewModel()
+	if err != nil {
+		t.Fatalf("NewModel failed: %v", err)
+	}
+	if !strings.Contains(screen(m), "active: garden") {
+		t.Errorf("Expected the title bar to show the active context, got:\n%s", screen(m))
+	}
+
+	// The list is ordered as ListContexts orders it; select ps-cli: Auth by filtering
+	typeKeys(m, "/auth")
+	m.HandleKey(tui.Key{Type: tui.KeyEnter})
+	if !strings.Contains(screen(m), "1 of 2 contexts") {
+		t.Errorf("Expected the filter to leave one context, got:\n%s", screen(m))
+	}
+
+	t.Run("switch", func(t *testing.T) {
+		typeKeys(m, "s")
+		state, _ := core.GetActiveContext()
+		if state.GetActiveContextName() != "ps-cli: Auth" {
+			t.Errorf("Expected ps-cli: Auth to be active, got %q", state.GetActiveContextName())
+		}
+		if ctx, err := core.FindContextByName("garden"); err != nil || ctx.Status != "stopped" {
+			t.Errorf("Expected garden to be stopped, got %+v (%v)", ctx, err)
+		}
+		transitions, err := core.GetTransitions()
+		if err != nil || len(transitions) == 0 {
+			t.Fatalf("GetTransitions failed: %v", err)
+		}
+		last := transitions[len(transitions)-1]
+		if last.TransitionType != models.TransitionSwitch || *last.PreviousContext != "garden" {
+			t.Errorf("Expected a switch from garden to be logged, got %+v", last)
+		}
+	})
+
+	t.Run("note", func(t *testing.T) {
+		typeKeys(m, "nfrom the browser")
+		m.HandleKey(tui.Key{Type: tui.KeyEnter})
+		_, notes, _, _, err := core.GetContextWithMetadata("ps-cli: Auth")
+		if err != nil || len(notes) != 1 || notes[0].TextContent != "from the browser" {
+			t.Errorf("Expected the note to be saved, got %+v (%v)", notes, err)
+		}
+		if !strings.Contains(screen(m), "from the browser") {
+			t.Errorf("Expected the note in the details pane, got:\n%s", screen(m))
+		}
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		typeKeys(m, "tbackend, urgent")
+		m.HandleKey(tui.Key{Type: tui.KeyEnter})
+		ctx, _, _, _, err := core.GetContextWithMetadata("ps-cli: Auth")
+		if err != nil || len(ctx.Metadata.Labels) != 2 {
+			t.Errorf("Expected two labels, got %+v (%v)", ctx, err)
+		}
+	})
+
+	t.Run("link", func(t *testing.T) {
+		typeKeys(m, "Lnowhere")
+		m.HandleKey(tui.Key{Type: tui.KeyEnter})
+		if !strings.Contains(screen(m), "nowhere") {
+			t.Errorf("Expected an error for an unknown parent, got:\n%s", screen(m))
+		}
+
+		typeKeys(m, "Lgarden")
+		m.HandleKey(tui.Key{Type: tui.KeyEnter})
+		ctx, _, _, _, err := core.GetContextWithMetadata("ps-cli: Auth")
+		if err != nil || ctx.Metadata.Parent != "garden" {
+			t.Errorf("Expected garden as parent, got %+v (%v)", ctx, err)
+		}
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		// Clear the filter and select the stopped garden context
+		m.HandleKey(tui.Key{Type: tui.KeyEsc})
+		typeKeys(m, "/garden")
+		m.HandleKey(tui.Key{Type: tui.KeyEnter})
+
+		typeKeys(m, "an")
+		if ctx, _ := core.FindContextByName("garden"); ctx.IsArchived {
+			t.Error("Expected answering n to leave garden unarchived")
+		}
+
+		typeKeys(m, "ay")
+		if ctx, _ := core.FindContextByName("garden"); !ctx.IsArchived {
+			t.Error("Expected garden to be archived")
+		}
+		if !strings.Contains(screen(m), "0 of 2 contexts") {
+			t.Errorf("Expected archived contexts to be hidden, got:\n%s", screen(m))
+		}
+
+		typeKeys(m, "A")
+		if !strings.Contains(screen(m), "1 of 2 contexts") {
+			t.Errorf("Expected A to show archived contexts, got:\n%s", screen(m))
+		}
+	})
+
+	typeKeys(m, "q")
+	if !m.Quit() {
+		t.Error("Expected q to quit")
+	}
+}
@@ -0,0 +1,182 @@
+package unit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+)
+
+// TestUndoAccidentalStart tests that undoing a start restores the previously active context
+func TestUndoAccidentalStart(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, _, err := core.CreateContext("real-work"); err != nil {
+		t.Fatalf("Failed to create context: %v", err)
+	}
+	if _, err := core.AddNote("keep this"); err != nil {
+		t.Fatalf("Failed to add note: %v", err)
+	}
+	if _, _, err := core.CreateContext("oops"); err != nil {
+		t.Fatalf("Failed to create second context: %v", err)
+	}
+
+	entry, err := core.UndoLast()
+	if err != nil {
+		t.Fatalf("UndoLast failed: %v", err)
+	}
+	if entry.Op != core.JournalOpStart || entry.Context != "oops" {
+		t.Errorf("Expected to undo start of 'oops', got %s %q", entry.Op, entry.Context)
+	}
+
+	state, err := core.GetActiveContext()
+	if err != nil {
+		t.Fatalf("Failed to read state: %v", err)
+	}
+	if state.GetActiveContextName() != "real-work" {
+		t.Errorf("Expected 'real-work' to be active again, got %q", state.GetActiveContextName())
+	}
+
+	ctx, err := core.LoadContext("real-work")
+	if err != nil {
+		t.Fatalf("Failed to load context: %v", err)
+	}
+	if !ctx.IsActive() || ctx.EndTime != nil {
+		t.Errorf("Expected 'real-work' to be running again, got status %q", ctx.Status)
+	}
+
+	if _, err := core.LoadContext("oops"); err == nil {
+		t.Error("Expected undone context to be removed")
+	}
+
+	// The switch transition is gone; only the original start remains
+	transitions, err := core.GetTransitions()
+	if err != nil {
+		t.Fatalf("Failed to read transitions: %v", err)
+	}
+	if len(transitions) != 1 {
+		t.Errorf("Expected 1 transition after undo, got %d", len(transitions))
+	}
+
+	// Earlier operations are still undoable in order
+	if _, err := core.UndoLast(); err != nil {
+		t.Fatalf("Failed to undo note: %v", err)
+	}
+	_, notes, _, _, _ := core.GetContext("real-work")
+	if len(notes) != 0 {
+		t.Errorf("Expected note to be removed, got %d notes", len(notes))
+	}
+}
+
+// TestUndoMetadataOperations tests undo of stop, tag, link and archive
+func TestUndoMetadataOperations(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("parent")
+	core.CreateContext("child")
+	if _, err := core.StopContext(); err != nil {
+		t.Fatalf("Failed to stop: %v", err)
+	}
+
+	if _, err := core.AddTags("child", []string{"wip"}); err != nil {
+		t.Fatalf("Failed to add tags: %v", err)
+	}
+	if err := core.SetParent("child", "parent"); err != nil {
+		t.Fatalf("Failed to link: %v", err)
+	}
+	if err := core.ArchiveContext("child"); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	// Undo archive, link and tag
+	for i := 0; i < 3; i++ {
+		if _, err := core.UndoLast(); err != nil {
+			t.Fatalf("Undo %d failed: %v", i+1, err)
+		}
+	}
+
+	ctx, _, _, _, err := core.GetContextWithMetadata("child")
+	if err != nil {
+		t.Fatalf("Failed to load child: %v", err)
+	}
+	if ctx.IsArchived || ctx.Metadata.Parent != "" || len(ctx.Metadata.Labels) != 0 {
+		t.Errorf("Expected archive, link and tag to be reverted, got archived=%v parent=%q labels=%v",
+			ctx.IsArchived, ctx.Metadata.Parent, ctx.Metadata.Labels)
+	}
+
+	// Undo stop
+	if _, err := core.UndoLast(); err != nil {
+		t.Fatalf("Failed to undo stop: %v", err)
+	}
+	state, _ := core.GetActiveContext()
+	if state.GetActiveContextName() != "child" {
+		t.Errorf("Expected 'child' to be active after undoing stop, got %q", state.GetActiveContextName())
+	}
+}
+
+// TestUndoEmptyJournal tests that undo reports when there is nothing to revert
+func TestUndoEmptyJournal(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	if _, err := core.UndoLast(); err == nil {
+		t.Error("Expected error when journal is empty")
+	}
+}
+
+// TestUndoResumeAndSwitch tests that resume and switch are undone as their own steps
+func TestUndoResumeAndSwitch(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("first")
+	core.StopContext()
+	core.CreateContext("second")
+	core.StopContext()
+
+	if err := core.ResumeContext("first"); err != nil {
+		t.Fatalf("ResumeContext failed: %v", err)
+	}
+	if _, err := core.SwitchContext("second"); err != nil {
+		t.Fatalf("SwitchContext failed: %v", err)
+	}
+
+	entry, err := core.UndoLast()
+	if err != nil || entry.Op != core.JournalOpSwitch {
+		t.Fatalf("Expected to undo the switch, got %v (%v)", entry, err)
+	}
+	if state, _ := core.GetActiveContext(); state.GetActiveContextName() != "first" {
+		t.Errorf("Expected first to be active again, got %q", state.GetActiveContextName())
+	}
+
+	entry, err = core.UndoLast()
+	if err != nil || entry.Op != core.JournalOpResume {
+		t.Fatalf("Expected to undo the resume, got %v (%v)", entry, err)
+	}
+	if state, _ := core.GetActiveContext(); state.HasActiveContext() {
+		t.Errorf("Expected no active context, got %q", state.GetActiveContextName())
+	}
+	for _, name := range []string{"first", "second"} {
+		if _, err := core.LoadContext(name); err != nil {
+			t.Errorf("Expected %s to stay in place: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package unit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jefferycaldwell/my-context-copilot/internal/core"
+)
+
+// TestSetContextStateDefaultWorkflow tests moving a context through the default workflow
+func TestSetContextStateDefaultWorkflow(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	core.CreateContext("Auth rework")
+
+	// A context without a state may move to any state
+	change, err := core.SetContextState("Auth rework", "in-progress", false)
+	if err != nil {
+		t.Fatalf("SetContextState failed: %v", err)
+	}
+	if change.From != "" || change.To != "in-progress" {
+		t.Errorf("Expected '' → in-progress, got %+v", change)
+	}
+
+	// planned is reachable from in-progress, archived is not
+	if _, err := core.SetContextState("Auth rework", "archived", false); err == nil {
+		t.Error("Expected in-progress → archived to be rejected")
+	}
+	if _, err := core.SetContextState("Auth rework", "shipped", true); err == nil {
+		t.Error("Expected unknown state to be rejected even with force")
+	}
+	if _, err := core.SetContextState("Auth rework", "review", false); err != nil {
+		t.Fatalf("Expected in-progress → review to be allowed: %v", err)
+	}
+
+	// Force skips the transition check
+	change, err = core.SetContextState("Auth rework", "planned", true)
+	if err != nil {
+		t.Fatalf("Forced transition failed: %v", err)
+	}
+	if change.From != "review" || !change.Forced {
+		t.Errorf("Expected forced review → planned, got %+v", change)
+	}
+
+	state, err := core.GetContextState("Auth rework")
+	if err != nil || state != "planned" {
+		t.Errorf("Expected state planned, got %q (%v)", state, err)
+	}
+
+	// Undo restores the previous state
+	if _, err := core.UndoLast(); err != nil {
+		t.Fatalf("UndoLast failed: %v", err)
+	}
+	state, _ = core.GetContextState("Auth rework")
+	if state != "review" {
+		t.Errorf("Expected undo to restore review, got %q", state)
+	}
+}
+
+// TestProjectWorkflow tests that a project's workflow from workflows.json applies to its contexts
+func TestProjectWorkflow(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("MY_CONTEXT_HOME")
+	defer func() {
+		os.Setenv("MY_CONTEXT_HOME", originalHome)
+	}()
+	os.Setenv("MY_CONTEXT_HOME", tempDir)
+
+	config := &core.WorkflowConfig{
+		Projects: map[string]*core.Workflow{
+			"ps-cli": {
+				States:      []string{"todo", "doing", "shipped"},
+				Transitions: map[string][]string{"todo": {"doing"}, "doing": {"shipped"}},
+				DoneStates:  []string{"shipped"},
+			},
+		},
+	}
+	if err := core.WriteJSON(core.GetWorkflowsPath(), config); err != nil {
+		t.Fatalf("Failed to write workflows: %v", err)
+	}
+
+	workflow, err := core.WorkflowFor("PS-CLI: Auth rework")
+	if err != nil {
+		t.Fatalf("WorkflowFor failed: %v", err)
+	}
+	if !workflow.HasState("doing") || workflow.HasState("review") {
+		t.Errorf("Expected the ps-cli workflow, got %v", workflow.States)
+	}
+	if !workflow.IsDone("shipped") {
+		t.Error("Expected shipped to be a done state")
+	}
+
+	workflow, _ = core.WorkflowFor("Unrelated context")
+	if !workflow.HasState("review") {
+		t.Errorf("Expected the default workflow for other contexts, got %v", workflow.States)
+	}
+
+	// An invalid workflow is reported rather than silently ignored
+	config.Projects["ps-cli"].DoneStates = []string{"released"}
+	core.WriteJSON(core.GetWorkflowsPath(), config)
+	if _, err := core.WorkflowFor("ps-cli: Auth rework"); err == nil {
+		t.Error("Expected an error for a done state outside the workflow")
+	}
+}
+
+// TestFilterTreeByState tests pruning a context tree to one workflow state
+func TestFilterTreeByState(t *testing.T) {
+	tree := &core.ContextTreeNode{
+		Name: "Sprint 3",
+		Children: []*core.ContextTreeNode{
+			{Name: "Login", State: "review"},
+			{Name: "Signup", State: "done", Children: []*core.ContextTreeNode{
+				{Name: "Signup emails", State: "review"},
+			}},
+			{Name: "Billing", State: "planned"},
+		},
+	}
+
+	filtered := core.FilterTreeByState(tree, "review")
+	if filtered == nil || len(filtered.Children) != 2 {
+		t.Fatalf("Expected two branches leading to review contexts, got %+v", filtered)
+	}
+	if filtered.Children[1].Name != "Signup" || len(filtered.Children[1].Children) != 1 {
+		t.Errorf("Expected Signup kept as the parent of a review context, got %+v", filtered.Children[1])
+	}
+
+	if core.FilterTreeByState(tree, "archived") != nil {
+		t.Error("Expected nil when no context matches")
+	}
+}